@@ -0,0 +1,227 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/client"
+	"watchdog/database"
+	"watchdog/ent/service"
+	"watchdog/probe"
+)
+
+const (
+	// reconnectBackoff is the first delay before reconnecting, it doubles up
+	// to maxReconnectBackoff
+	reconnectBackoff    = time.Second
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// Config configures a remote agent
+type Config struct {
+	Address string
+	Token   string
+	// TLS connects over TLS when set, plaintext otherwise
+	TLS *tls.Config
+	// PlaintextToken allows sending the token without TLS
+	PlaintextToken bool
+	// Name is matched against the agent label of services, defaults to the
+	// host name
+	Name string
+	// Concurrency is the number of checks run at once, defaults to 4
+	Concurrency int
+}
+
+// Agent connects to the watchdog server and runs the checks it is sent
+type Agent struct {
+	cfg      Config
+	hostname string
+	client   *client.Client
+}
+
+// New creates an agent, nothing is sent before Run
+func New(cfg Config) (*Agent, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("watchdog address is required")
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host name: %w", err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = hostname
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 4
+	}
+
+	opts := []client.Option{client.WithRetry(client.RetryPolicy{MaxAttempts: 1})}
+	if cfg.Token != "" {
+		opts = append(opts, client.WithToken(cfg.Token))
+	}
+	if cfg.TLS != nil {
+		opts = append(opts, client.WithTLS(cfg.TLS))
+	}
+	if cfg.PlaintextToken {
+		opts = append(opts, client.WithPlaintextToken())
+	}
+	c, err := client.New(cfg.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Agent{cfg: cfg, hostname: hostname, client: c}, nil
+}
+
+// Run keeps a stream to the server open and runs the checks it receives
+// until ctx is done, reconnecting with backoff when the stream breaks
+func (a *Agent) Run(ctx context.Context) error {
+	defer a.client.Close()
+
+	backoff := reconnectBackoff
+	for {
+		connected, err := a.session(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		switch status.Code(err) {
+		case codes.PermissionDenied, codes.Unauthenticated, codes.InvalidArgument, codes.Unimplemented:
+			return err
+		}
+
+		if connected {
+			backoff = reconnectBackoff
+		}
+		logger.WarnContext(ctx, "connection to watchdog lost, reconnecting", "error", err, "retry_in", backoff)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+// session runs one stream. connected reports whether the server accepted
+// the agent, which resets the backoff.
+func (a *Agent) session(ctx context.Context) (connected bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := a.client.API().AgentConnect(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var sendMu sync.Mutex
+	send := func(msg *api.AgentMessage) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(msg)
+	}
+
+	err = send(&api.AgentMessage{Message: &api.AgentMessage_Hello{Hello: &api.AgentHello{
+		Name:     a.cfg.Name,
+		Hostname: a.hostname,
+		Version:  version(),
+	}}})
+	if err != nil {
+		return false, err
+	}
+	if _, err := stream.Header(); err != nil {
+		return false, err
+	}
+	connected = true
+	logger.InfoContext(ctx, "connected to watchdog", "address", a.cfg.Address, "agent", a.cfg.Name)
+
+	// Checks still running when the stream breaks finish and are dropped
+	slots := make(chan struct{}, a.cfg.Concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		check, err := stream.Recv()
+		if err != nil {
+			return connected, err
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return connected, ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result := a.run(ctx, check)
+			if err := send(&api.AgentMessage{Message: &api.AgentMessage_Result{Result: result}}); err != nil {
+				logger.DebugContext(ctx, "failed to send check result", "check_id", check.CheckId, "error", err)
+			}
+		}()
+	}
+}
+
+// run checks a service with the same probes the server uses
+func (a *Agent) run(ctx context.Context, check *api.AgentCheck) *api.AgentCheckResult {
+	record, err := fromAPIService(check.Service)
+	if err != nil {
+		return &api.AgentCheckResult{CheckId: check.CheckId, Error: err.Error()}
+	}
+
+	result := probe.Run(ctx, record)
+	reply := &api.AgentCheckResult{
+		CheckId: check.CheckId,
+		Result: &api.CheckResult{
+			Status:    result.Status,
+			Message:   result.Message,
+			LatencyMs: result.Latency.Milliseconds(),
+			CheckedAt: result.CheckedAt.Unix(),
+		},
+	}
+	if result.Err != nil {
+		reply.Error = result.Err.Error()
+	}
+	return reply
+}
+
+func fromAPIService(info *api.ServiceInfo) (*database.ServiceRecord, error) {
+	if info == nil {
+		return nil, errors.New("check without a service")
+	}
+	id, err := strconv.ParseInt(info.Id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service ID %q", info.Id)
+	}
+
+	return &database.ServiceRecord{
+		ID:            id,
+		Name:          info.Name,
+		Endpoint:      info.Endpoint,
+		Type:          service.Type(info.Type.String()),
+		Namespace:     info.Namespace,
+		CheckInterval: int(info.CheckIntervalSeconds),
+		Labels:        info.Labels,
+	}, nil
+}
+
+// version is the module version the agent was built from
+func version() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return ""
+}
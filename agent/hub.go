@@ -0,0 +1,293 @@
+// Package agent runs health checks on remote hosts. The Hub is the server
+// side, it hands the checks of services labeled agent=<name> to the agent
+// with that name, and runs the checks of services labeled
+// locations=<names> at every location to reach a quorum verdict. Agent is
+// the process on the remote host, it connects outbound and runs the checks
+// with the probe package.
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/database"
+	"watchdog/logging"
+	"watchdog/probe"
+)
+
+const (
+	// Label assigns a service to the agent with this name
+	Label = "agent"
+	// LocationsLabel lists the comma-separated locations a service is
+	// checked from, the server's own location or agent names
+	LocationsLabel = "locations"
+	// QuorumLabel overrides the number of locations that must report the
+	// service unhealthy
+	QuorumLabel = "quorum"
+)
+
+// checkTimeout bounds how long the hub waits for an agent to answer
+const checkTimeout = 30 * time.Second
+
+// Connected is the number of agents connected to this server
+var Connected = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "watchdog_agents_connected",
+	Help: "Number of remote probe agents connected.",
+})
+
+var (
+	tracer = otel.Tracer("watchdog/agent")
+	logger = logging.For("agent")
+)
+
+// Hub tracks the connected agents and routes checks to them
+type Hub struct {
+	// local is the name of the server in the locations label
+	local string
+	// quorum is the default number of unhealthy locations for an unhealthy
+	// verdict, 0 is a majority
+	quorum int
+
+	mu sync.Mutex
+	// agents is in connection order, the longest connected agent of a name
+	// gets its checks
+	agents []*conn
+
+	checkIDs atomic.Int64
+}
+
+// conn is the stream of one connected agent
+type conn struct {
+	name     string
+	hostname string
+	stream   api.WatchdogService_AgentConnectServer
+	done     chan struct{}
+
+	// sendMu serializes Send, which is not safe for concurrent use
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string]chan *api.AgentCheckResult
+}
+
+// NewHub creates a hub without agents. local names the server itself in the
+// locations label, quorum is the default quorum of multi-location checks.
+func NewHub(local string, quorum int) *Hub {
+	return &Hub{local: local, quorum: quorum}
+}
+
+// Serve handles the stream of one agent until it disconnects. name is the
+// agent name bound to the caller's token, empty allows any name.
+func (h *Hub) Serve(stream api.WatchdogService_AgentConnectServer, name string) error {
+	ctx := stream.Context()
+
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := msg.GetHello()
+	if hello == nil || hello.Name == "" {
+		return status.Errorf(codes.InvalidArgument, "first message must be a hello with the agent name")
+	}
+	if name != "" && hello.Name != name {
+		logger.WarnContext(ctx, "agent refused, name does not match its token", "agent", hello.Name, "hostname", hello.Hostname)
+		return status.Errorf(codes.PermissionDenied, "the token does not belong to agent %s", hello.Name)
+	}
+
+	c := &conn{
+		name:     hello.Name,
+		hostname: hello.Hostname,
+		stream:   stream,
+		done:     make(chan struct{}),
+		pending:  make(map[string]chan *api.AgentCheckResult),
+	}
+	h.add(c)
+	defer h.remove(c)
+
+	// Headers tell the agent it was accepted before any check is sent
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	logger.InfoContext(ctx, "agent connected", "agent", c.name, "hostname", c.hostname, "version", hello.Version)
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			logger.InfoContext(ctx, "agent disconnected", "agent", c.name, "hostname", c.hostname)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if result := msg.GetResult(); result != nil {
+			c.deliver(result)
+		}
+	}
+}
+
+func (h *Hub) add(c *conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.agents = append(h.agents, c)
+	Connected.Set(float64(len(h.agents)))
+}
+
+func (h *Hub) remove(c *conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, agent := range h.agents {
+		if agent == c {
+			h.agents = append(h.agents[:i], h.agents[i+1:]...)
+			break
+		}
+	}
+	Connected.Set(float64(len(h.agents)))
+	close(c.done)
+}
+
+// find returns the longest connected agent with a name. Host names are
+// reported by the agents themselves, so they are not matched.
+func (h *Hub) find(target string) *conn {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, c := range h.agents {
+		if c.name == target {
+			return c
+		}
+	}
+	return nil
+}
+
+// Probe checks a service from every location in its locations label, on
+// its agent when it has the agent label, and locally otherwise
+func (h *Hub) Probe(ctx context.Context, service *database.ServiceRecord) probe.Result {
+	if locations := parseLocations(service.Labels[LocationsLabel]); len(locations) > 0 {
+		return h.probeQuorum(ctx, service, locations)
+	}
+
+	result, _ := h.probeAt(ctx, service, service.Labels[Label])
+	return result
+}
+
+// probeAt checks a service at one location, "" being the server itself.
+// available is false when the location could not run the check.
+func (h *Hub) probeAt(ctx context.Context, service *database.ServiceRecord, location string) (result probe.Result, available bool) {
+	if location == "" || location == h.local {
+		return probe.Run(ctx, service), true
+	}
+
+	c := h.find(location)
+	if c == nil {
+		return unavailable(fmt.Errorf("no agent %q is connected", location), time.Now()), false
+	}
+
+	ctx, span := tracer.Start(ctx, "agent check", trace.WithAttributes(
+		attribute.String("watchdog.agent.name", c.name),
+		attribute.Int64("watchdog.service.id", service.ID),
+	))
+	defer span.End()
+
+	return c.check(ctx, strconv.FormatInt(h.checkIDs.Add(1), 10), service)
+}
+
+// unavailable is the result of a check that could not reach its location
+func unavailable(err error, start time.Time) probe.Result {
+	return probe.Result{
+		Status:    "unhealthy",
+		Message:   fmt.Sprintf("Service is unreachable: %v", err),
+		Latency:   time.Since(start),
+		CheckedAt: start,
+		Err:       err,
+	}
+}
+
+// check sends a check to the agent and waits for its result
+func (c *conn) check(ctx context.Context, checkID string, service *database.ServiceRecord) (probe.Result, bool) {
+	start := time.Now()
+
+	results := make(chan *api.AgentCheckResult, 1)
+	c.mu.Lock()
+	c.pending[checkID] = results
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, checkID)
+		c.mu.Unlock()
+	}()
+
+	c.sendMu.Lock()
+	err := c.stream.Send(&api.AgentCheck{CheckId: checkID, Service: toAPIService(service)})
+	c.sendMu.Unlock()
+	if err != nil {
+		return unavailable(fmt.Errorf("failed to send check to agent %s: %w", c.name, err), start), false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	select {
+	case result := <-results:
+		return fromAgentResult(result, start), true
+	case <-c.done:
+		return unavailable(fmt.Errorf("agent %s disconnected", c.name), start), false
+	case <-ctx.Done():
+		return unavailable(fmt.Errorf("agent %s did not answer: %w", c.name, ctx.Err()), start), false
+	}
+}
+
+// deliver hands a result to the check waiting for it, late results of
+// checks that timed out are dropped
+func (c *conn) deliver(result *api.AgentCheckResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if results, ok := c.pending[result.CheckId]; ok {
+		results <- result
+		delete(c.pending, result.CheckId)
+	}
+}
+
+func fromAgentResult(result *api.AgentCheckResult, start time.Time) probe.Result {
+	r := probe.Result{
+		Status:  result.GetResult().GetStatus(),
+		Message: result.GetResult().GetMessage(),
+		Latency: time.Duration(result.GetResult().GetLatencyMs()) * time.Millisecond,
+		// The clock of the server orders the history, not the agent's
+		CheckedAt: start,
+	}
+
+	switch result.Error {
+	case "":
+	case probe.ErrUnsupportedServiceType.Error():
+		r.Err = probe.ErrUnsupportedServiceType
+	default:
+		r.Err = errors.New(result.Error)
+	}
+	return r
+}
+
+func toAPIService(service *database.ServiceRecord) *api.ServiceInfo {
+	return &api.ServiceInfo{
+		Id:                   strconv.FormatInt(service.ID, 10),
+		Name:                 service.Name,
+		Endpoint:             service.Endpoint,
+		Type:                 api.ServiceType(api.ServiceType_value[string(service.Type)]),
+		Namespace:            service.Namespace,
+		CheckIntervalSeconds: int32(service.CheckInterval),
+		Labels:               service.Labels,
+	}
+}
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"watchdog/database"
+	"watchdog/probe"
+)
+
+// parseLocations splits the locations label, dropping blanks and duplicates
+func parseLocations(label string) []string {
+	var locations []string
+	seen := make(map[string]bool)
+	for _, location := range strings.Split(label, ",") {
+		location = strings.TrimSpace(location)
+		if location != "" && !seen[location] {
+			seen[location] = true
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// quorumFor is the number of unhealthy locations that make a service
+// unhealthy, from its quorum label or the default, capped at the number of
+// locations
+func (h *Hub) quorumFor(service *database.ServiceRecord, locations int) int {
+	quorum := h.quorum
+	if value, err := strconv.Atoi(service.Labels[QuorumLabel]); err == nil && value > 0 {
+		quorum = value
+	}
+	if quorum <= 0 {
+		quorum = locations/2 + 1
+	}
+	return min(quorum, locations)
+}
+
+// probeQuorum checks a service from every location at once. It is unhealthy
+// when at least quorum locations say so, and healthy when fewer do and at
+// least one location reports it healthy. Locations that cannot run the check
+// do not count either way, so a service no location reports healthy stays
+// unhealthy.
+func (h *Hub) probeQuorum(ctx context.Context, service *database.ServiceRecord, locations []string) probe.Result {
+	start := time.Now()
+	quorum := h.quorumFor(service, len(locations))
+
+	results := make([]probe.Result, len(locations))
+	available := make([]bool, len(locations))
+	var wg sync.WaitGroup
+	for i, location := range locations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], available[i] = h.probeAt(ctx, service, location)
+		}()
+	}
+	wg.Wait()
+
+	return quorumVerdict(locations, results, available, quorum, start)
+}
+
+// quorumVerdict combines the results of a check at every location, available
+// telling which locations could run it
+func quorumVerdict(locations []string, results []probe.Result, available []bool, quorum int, start time.Time) probe.Result {
+	verdict := probe.Result{
+		Latency:   time.Since(start),
+		CheckedAt: start,
+		Locations: make([]database.LocationResult, len(locations)),
+	}
+
+	var healthy, unhealthy, unsupported []string
+	for i, result := range results {
+		location := database.LocationResult{
+			Location:  locations[i],
+			Status:    result.Status,
+			Message:   result.Message,
+			LatencyMs: result.Latency.Milliseconds(),
+		}
+		switch {
+		case !available[i]:
+			location.Status = "unknown"
+		case errors.Is(result.Err, probe.ErrUnsupportedServiceType):
+			location.Status = "unknown"
+			location.Message = result.Err.Error()
+			unsupported = append(unsupported, locations[i])
+		case result.Status == "healthy":
+			healthy = append(healthy, locations[i])
+		default:
+			unhealthy = append(unhealthy, locations[i]+": "+result.Message)
+		}
+		verdict.Locations[i] = location
+	}
+
+	switch {
+	case len(unsupported) > 0 && len(healthy)+len(unhealthy) == 0:
+		verdict.Status = "unhealthy"
+		verdict.Err = probe.ErrUnsupportedServiceType
+	case len(unhealthy) >= quorum:
+		verdict.Status = "unhealthy"
+		verdict.Message = fmt.Sprintf("Unhealthy at %d of %d locations (quorum %d): %s",
+			len(unhealthy), len(locations), quorum, strings.Join(unhealthy, "; "))
+		verdict.Err = errors.New(verdict.Message)
+	case len(healthy) > 0:
+		verdict.Status = "healthy"
+		verdict.Message = fmt.Sprintf("Healthy at %d of %d locations", len(healthy), len(locations))
+		if len(unhealthy) > 0 {
+			verdict.Message += fmt.Sprintf(", below quorum %d: %s", quorum, strings.Join(unhealthy, "; "))
+		}
+	case len(unhealthy) > 0:
+		verdict.Status = "unhealthy"
+		verdict.Message = fmt.Sprintf("Unhealthy at %d of %d locations, none reports it healthy: %s",
+			len(unhealthy), len(locations), strings.Join(unhealthy, "; "))
+		verdict.Err = errors.New(verdict.Message)
+	default:
+		verdict.Status = "unhealthy"
+		verdict.Message = "Service is unreachable: no location could run the check"
+		verdict.Err = errors.New("no location could run the check")
+	}
+
+	return verdict
+}
@@ -0,0 +1,262 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"watchdog/database"
+	"watchdog/ent/service"
+	"watchdog/probe"
+)
+
+func TestParseLocations(t *testing.T) {
+	tests := []struct {
+		label string
+		want  []string
+	}{
+		{label: "", want: nil},
+		{label: "local", want: []string{"local"}},
+		{label: "local, edge-1 ,edge-2", want: []string{"local", "edge-1", "edge-2"}},
+		{label: "edge-1,,edge-1, ,edge-2", want: []string{"edge-1", "edge-2"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseLocations(tt.label); !slices.Equal(got, tt.want) {
+			t.Errorf("parseLocations(%q) = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestQuorumFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		quorum    int
+		label     string
+		locations int
+		want      int
+	}{
+		{name: "majority of three", locations: 3, want: 2},
+		{name: "majority of four", locations: 4, want: 3},
+		{name: "majority of one", locations: 1, want: 1},
+		{name: "hub default", quorum: 2, locations: 5, want: 2},
+		{name: "label overrides hub default", quorum: 2, label: "4", locations: 5, want: 4},
+		{name: "capped at locations", label: "5", locations: 3, want: 3},
+		{name: "hub default capped", quorum: 7, locations: 2, want: 2},
+		{name: "invalid label ignored", label: "most", locations: 3, want: 2},
+		{name: "zero label ignored", quorum: 1, label: "0", locations: 3, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub("local", tt.quorum)
+			svc := &database.ServiceRecord{Labels: map[string]string{}}
+			if tt.label != "" {
+				svc.Labels[QuorumLabel] = tt.label
+			}
+			if got := h.quorumFor(svc, tt.locations); got != tt.want {
+				t.Errorf("quorumFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// outcome is the result of a check at one location in the verdict table
+type outcome int
+
+const (
+	healthy outcome = iota
+	unhealthy
+	unsupported
+	unavailableAt
+)
+
+func (o outcome) result() (probe.Result, bool) {
+	switch o {
+	case healthy:
+		return probe.Result{Status: "healthy", Message: "ok"}, true
+	case unhealthy:
+		return probe.Result{Status: "unhealthy", Message: "refused", Err: errors.New("refused")}, true
+	case unsupported:
+		return probe.Result{Status: "unhealthy", Err: probe.ErrUnsupportedServiceType}, true
+	default:
+		return probe.Result{Status: "unhealthy", Message: "no agent", Err: errors.New("no agent")}, false
+	}
+}
+
+func TestQuorumVerdict(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcomes []outcome
+		quorum   int
+		want     string
+		// unsupported expects probe.ErrUnsupportedServiceType
+		unsupported bool
+		// statuses are the recorded statuses per location
+		statuses []string
+	}{
+		{
+			name:     "all healthy",
+			outcomes: []outcome{healthy, healthy, healthy},
+			quorum:   2,
+			want:     "healthy",
+			statuses: []string{"healthy", "healthy", "healthy"},
+		},
+		{
+			name:     "below quorum",
+			outcomes: []outcome{healthy, unhealthy, healthy},
+			quorum:   2,
+			want:     "healthy",
+			statuses: []string{"healthy", "unhealthy", "healthy"},
+		},
+		{
+			name:     "at quorum",
+			outcomes: []outcome{unhealthy, unhealthy, healthy},
+			quorum:   2,
+			want:     "unhealthy",
+			statuses: []string{"unhealthy", "unhealthy", "healthy"},
+		},
+		{
+			name:     "quorum of one",
+			outcomes: []outcome{healthy, unhealthy, healthy},
+			quorum:   1,
+			want:     "unhealthy",
+			statuses: []string{"healthy", "unhealthy", "healthy"},
+		},
+		{
+			name:     "unavailable locations do not count",
+			outcomes: []outcome{healthy, unavailableAt, unavailableAt},
+			quorum:   2,
+			want:     "healthy",
+			statuses: []string{"healthy", "unknown", "unknown"},
+		},
+		{
+			name:     "unhealthy below quorum with nobody healthy",
+			outcomes: []outcome{unhealthy, unavailableAt, unavailableAt},
+			quorum:   2,
+			want:     "unhealthy",
+			statuses: []string{"unhealthy", "unknown", "unknown"},
+		},
+		{
+			name:     "no location available",
+			outcomes: []outcome{unavailableAt, unavailableAt},
+			quorum:   2,
+			want:     "unhealthy",
+			statuses: []string{"unknown", "unknown"},
+		},
+		{
+			name:        "unsupported everywhere",
+			outcomes:    []outcome{unsupported, unsupported},
+			quorum:      2,
+			want:        "unhealthy",
+			unsupported: true,
+			statuses:    []string{"unknown", "unknown"},
+		},
+		{
+			name:     "unsupported at some locations",
+			outcomes: []outcome{unsupported, healthy, unhealthy},
+			quorum:   2,
+			want:     "healthy",
+			statuses: []string{"unknown", "healthy", "unhealthy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations := make([]string, len(tt.outcomes))
+			results := make([]probe.Result, len(tt.outcomes))
+			available := make([]bool, len(tt.outcomes))
+			for i, o := range tt.outcomes {
+				locations[i] = string(rune('a' + i))
+				results[i], available[i] = o.result()
+			}
+
+			verdict := quorumVerdict(locations, results, available, tt.quorum, time.Now())
+			if verdict.Status != tt.want {
+				t.Errorf("status = %q, want %q (%s)", verdict.Status, tt.want, verdict.Message)
+			}
+			if (verdict.Status == "healthy") != (verdict.Err == nil) {
+				t.Errorf("status %q with error %v", verdict.Status, verdict.Err)
+			}
+			if got := errors.Is(verdict.Err, probe.ErrUnsupportedServiceType); got != tt.unsupported {
+				t.Errorf("unsupported = %v, want %v", got, tt.unsupported)
+			}
+
+			var statuses []string
+			for i, location := range verdict.Locations {
+				if location.Location != locations[i] {
+					t.Errorf("location %d = %q, want %q", i, location.Location, locations[i])
+				}
+				statuses = append(statuses, location.Status)
+			}
+			if !slices.Equal(statuses, tt.statuses) {
+				t.Errorf("location statuses = %q, want %q", statuses, tt.statuses)
+			}
+		})
+	}
+}
+
+func TestProbeWithoutAgents(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "local only",
+			labels: map[string]string{LocationsLabel: "local"},
+			want:   "healthy",
+		},
+		{
+			name:   "disconnected agents do not outvote the server",
+			labels: map[string]string{LocationsLabel: "local,edge-1,edge-2"},
+			want:   "healthy",
+		},
+		{
+			name:   "no location available",
+			labels: map[string]string{LocationsLabel: "edge-1,edge-2"},
+			want:   "unhealthy",
+		},
+		{
+			name:   "missing agent",
+			labels: map[string]string{Label: "edge-1"},
+			want:   "unhealthy",
+		},
+	}
+
+	h := NewHub("local", 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &database.ServiceRecord{
+				ID:       1,
+				Endpoint: backend.URL,
+				Type:     service.TypeSERVICE_TYPE_HTTP,
+				Labels:   tt.labels,
+			}
+			if got := h.Probe(context.Background(), svc); got.Status != tt.want {
+				t.Errorf("Probe() = %q (%s), want %q", got.Status, got.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeUnsupportedType(t *testing.T) {
+	h := NewHub("local", 0)
+	svc := &database.ServiceRecord{
+		ID:     1,
+		Type:   service.TypeSERVICE_TYPE_UNSPECIFIED,
+		Labels: map[string]string{LocationsLabel: "local,edge-1"},
+	}
+
+	got := h.Probe(context.Background(), svc)
+	if !errors.Is(got.Err, probe.ErrUnsupportedServiceType) {
+		t.Errorf("Probe() error = %v, want %v", got.Err, probe.ErrUnsupportedServiceType)
+	}
+}
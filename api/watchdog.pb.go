@@ -97,6 +97,7 @@ func (ServiceType) EnumDescriptor() ([]byte, []int) {
 type CheckServiceHealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -138,8 +139,16 @@ func (x *CheckServiceHealthRequest) GetServiceId() string {
 	return ""
 }
 
+func (x *CheckServiceHealthRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
 type HealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -174,10 +183,20 @@ func (*HealthRequest) Descriptor() ([]byte, []int) {
 	return file_proto_watchdog_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *HealthRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
 type HealthResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Status  string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Replica running the scheduler when leader election is enabled, empty
+	// otherwise or while no replica holds the lease
+	Leader        string `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -226,16 +245,30 @@ func (x *HealthResponse) GetMessage() string {
 	return ""
 }
 
+func (x *HealthResponse) GetLeader() string {
+	if x != nil {
+		return x.Leader
+	}
+	return ""
+}
+
 type ServiceInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	LastHeartbeat int64                  `protobuf:"varint,5,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
-	Type          ServiceType            `protobuf:"varint,6,opt,name=type,proto3,enum=watchdog.ServiceType" json:"type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Endpoint             string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Status               string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	LastHeartbeat        int64                  `protobuf:"varint,5,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	Type                 ServiceType            `protobuf:"varint,6,opt,name=type,proto3,enum=watchdog.ServiceType" json:"type,omitempty"`
+	Namespace            string                 `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CheckIntervalSeconds int32                  `protobuf:"varint,8,opt,name=check_interval_seconds,json=checkIntervalSeconds,proto3" json:"check_interval_seconds,omitempty"`
+	Labels               map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Most recent health check, zero values when the service was never checked
+	LastCheckedAt      int64  `protobuf:"varint,10,opt,name=last_checked_at,json=lastCheckedAt,proto3" json:"last_checked_at,omitempty"`
+	LastCheckStatus    string `protobuf:"bytes,11,opt,name=last_check_status,json=lastCheckStatus,proto3" json:"last_check_status,omitempty"`
+	LastCheckLatencyMs int64  `protobuf:"varint,12,opt,name=last_check_latency_ms,json=lastCheckLatencyMs,proto3" json:"last_check_latency_ms,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ServiceInfo) Reset() {
@@ -310,13 +343,58 @@ func (x *ServiceInfo) GetType() ServiceType {
 	return ServiceType_SERVICE_TYPE_UNSPECIFIED
 }
 
+func (x *ServiceInfo) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ServiceInfo) GetCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.CheckIntervalSeconds
+	}
+	return 0
+}
+
+func (x *ServiceInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ServiceInfo) GetLastCheckedAt() int64 {
+	if x != nil {
+		return x.LastCheckedAt
+	}
+	return 0
+}
+
+func (x *ServiceInfo) GetLastCheckStatus() string {
+	if x != nil {
+		return x.LastCheckStatus
+	}
+	return ""
+}
+
+func (x *ServiceInfo) GetLastCheckLatencyMs() int64 {
+	if x != nil {
+		return x.LastCheckLatencyMs
+	}
+	return 0
+}
+
 type RegisterServiceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	Type          ServiceType            `protobuf:"varint,3,opt,name=type,proto3,enum=watchdog.ServiceType" json:"type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Endpoint             string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Type                 ServiceType            `protobuf:"varint,3,opt,name=type,proto3,enum=watchdog.ServiceType" json:"type,omitempty"`
+	Namespace            string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CheckIntervalSeconds int32                  `protobuf:"varint,5,opt,name=check_interval_seconds,json=checkIntervalSeconds,proto3" json:"check_interval_seconds,omitempty"`
+	Labels               map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *RegisterServiceRequest) Reset() {
@@ -370,6 +448,27 @@ func (x *RegisterServiceRequest) GetType() ServiceType {
 	return ServiceType_SERVICE_TYPE_UNSPECIFIED
 }
 
+func (x *RegisterServiceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RegisterServiceRequest) GetCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.CheckIntervalSeconds
+	}
+	return 0
+}
+
+func (x *RegisterServiceRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type RegisterServiceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
@@ -425,6 +524,7 @@ func (x *RegisterServiceResponse) GetMessage() string {
 type UnregisterServiceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -466,6 +566,13 @@ func (x *UnregisterServiceRequest) GetServiceId() string {
 	return ""
 }
 
+func (x *UnregisterServiceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
 type UnregisterServiceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -511,7 +618,10 @@ func (x *UnregisterServiceResponse) GetMessage() string {
 }
 
 type ListServicesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// List services from every namespace. Requires an admin token.
+	AllNamespaces bool `protobuf:"varint,2,opt,name=all_namespaces,json=allNamespaces,proto3" json:"all_namespaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -546,6 +656,20 @@ func (*ListServicesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_watchdog_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *ListServicesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListServicesRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.AllNamespaces
+	}
+	return false
+}
+
 type ListServicesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Services      []*ServiceInfo         `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
@@ -590,20 +714,89 @@ func (x *ListServicesResponse) GetServices() []*ServiceInfo {
 	return nil
 }
 
+type WatchServicesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Watch services from every namespace. Requires an admin token.
+	AllNamespaces bool `protobuf:"varint,2,opt,name=all_namespaces,json=allNamespaces,proto3" json:"all_namespaces,omitempty"`
+	// Only watch the instances of the service with this name, every
+	// service when empty.
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchServicesRequest) Reset() {
+	*x = WatchServicesRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchServicesRequest) ProtoMessage() {}
+
+func (x *WatchServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchServicesRequest.ProtoReflect.Descriptor instead.
+func (*WatchServicesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchServicesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchServicesRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.AllNamespaces
+	}
+	return false
+}
+
+func (x *WatchServicesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type UpdateServiceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Type          ServiceType            `protobuf:"varint,4,opt,name=type,proto3,enum=watchdog.ServiceType" json:"type,omitempty"`
-	Endpoint      string                 `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId            string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Status               string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Name                 string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 ServiceType            `protobuf:"varint,4,opt,name=type,proto3,enum=watchdog.ServiceType" json:"type,omitempty"`
+	Endpoint             string                 `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Namespace            string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CheckIntervalSeconds int32                  `protobuf:"varint,7,opt,name=check_interval_seconds,json=checkIntervalSeconds,proto3" json:"check_interval_seconds,omitempty"`
+	// Replaces all labels when non-empty
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Removes all labels
+	ClearLabels   bool `protobuf:"varint,9,opt,name=clear_labels,json=clearLabels,proto3" json:"clear_labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateServiceRequest) Reset() {
 	*x = UpdateServiceRequest{}
-	mi := &file_proto_watchdog_proto_msgTypes[10]
+	mi := &file_proto_watchdog_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -615,7 +808,7 @@ func (x *UpdateServiceRequest) String() string {
 func (*UpdateServiceRequest) ProtoMessage() {}
 
 func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_watchdog_proto_msgTypes[10]
+	mi := &file_proto_watchdog_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +821,7 @@ func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateServiceRequest.ProtoReflect.Descriptor instead.
 func (*UpdateServiceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_watchdog_proto_rawDescGZIP(), []int{10}
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateServiceRequest) GetServiceId() string {
@@ -666,6 +859,34 @@ func (x *UpdateServiceRequest) GetEndpoint() string {
 	return ""
 }
 
+func (x *UpdateServiceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UpdateServiceRequest) GetCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.CheckIntervalSeconds
+	}
+	return 0
+}
+
+func (x *UpdateServiceRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateServiceRequest) GetClearLabels() bool {
+	if x != nil {
+		return x.ClearLabels
+	}
+	return false
+}
+
 type UpdateServiceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -675,7 +896,7 @@ type UpdateServiceResponse struct {
 
 func (x *UpdateServiceResponse) Reset() {
 	*x = UpdateServiceResponse{}
-	mi := &file_proto_watchdog_proto_msgTypes[11]
+	mi := &file_proto_watchdog_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -687,7 +908,7 @@ func (x *UpdateServiceResponse) String() string {
 func (*UpdateServiceResponse) ProtoMessage() {}
 
 func (x *UpdateServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_watchdog_proto_msgTypes[11]
+	mi := &file_proto_watchdog_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,7 +921,7 @@ func (x *UpdateServiceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateServiceResponse.ProtoReflect.Descriptor instead.
 func (*UpdateServiceResponse) Descriptor() ([]byte, []int) {
-	return file_proto_watchdog_proto_rawDescGZIP(), []int{11}
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateServiceResponse) GetMessage() string {
@@ -710,128 +931,2793 @@ func (x *UpdateServiceResponse) GetMessage() string {
 	return ""
 }
 
-var File_proto_watchdog_proto protoreflect.FileDescriptor
+type HeartbeatRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Ready switches an "active" service to "not_ready" and back. Other
+	// statuses set by operators are kept.
+	Ready         bool `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_watchdog_proto_rawDesc = "" +
-	"\n" +
-	"\x14proto/watchdog.proto\x12\bwatchdog\":\n" +
-	"\x19CheckServiceHealthRequest\x12\x1d\n" +
-	"\n" +
-	"service_id\x18\x01 \x01(\tR\tserviceId\"\x0f\n" +
-	"\rHealthRequest\"B\n" +
-	"\x0eHealthResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xb7\x01\n" +
-	"\vServiceInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
-	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
-	"\x0elast_heartbeat\x18\x05 \x01(\x03R\rlastHeartbeat\x12)\n" +
-	"\x04type\x18\x06 \x01(\x0e2\x15.watchdog.ServiceTypeR\x04type\"s\n" +
-	"\x16RegisterServiceRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
-	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12)\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x15.watchdog.ServiceTypeR\x04type\"R\n" +
-	"\x17RegisterServiceResponse\x12\x1d\n" +
-	"\n" +
-	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"9\n" +
-	"\x18UnregisterServiceRequest\x12\x1d\n" +
-	"\n" +
-	"service_id\x18\x01 \x01(\tR\tserviceId\"5\n" +
-	"\x19UnregisterServiceResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\x15\n" +
-	"\x13ListServicesRequest\"I\n" +
-	"\x14ListServicesResponse\x121\n" +
-	"\bservices\x18\x01 \x03(\v2\x15.watchdog.ServiceInfoR\bservices\"\xa8\x01\n" +
-	"\x14UpdateServiceRequest\x12\x1d\n" +
-	"\n" +
-	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
-	"\x04type\x18\x04 \x01(\x0e2\x15.watchdog.ServiceTypeR\x04type\x12\x1a\n" +
-	"\bendpoint\x18\x05 \x01(\tR\bendpoint\"1\n" +
-	"\x15UpdateServiceResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage*\xae\x02\n" +
-	"\vServiceType\x12\x1c\n" +
-	"\x18SERVICE_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
-	"\x11SERVICE_TYPE_HTTP\x10\x01\x12\x15\n" +
-	"\x11SERVICE_TYPE_GRPC\x10\x02\x12\x19\n" +
-	"\x15SERVICE_TYPE_DATABASE\x10\x03\x12\x16\n" +
-	"\x12SERVICE_TYPE_CACHE\x10\x04\x12\x16\n" +
-	"\x12SERVICE_TYPE_QUEUE\x10\x05\x12\x18\n" +
-	"\x14SERVICE_TYPE_STORAGE\x10\x06\x12\x1d\n" +
-	"\x19SERVICE_TYPE_EXTERNAL_API\x10\a\x12\x1d\n" +
-	"\x19SERVICE_TYPE_MICROSERVICE\x10\b\x12\x16\n" +
-	"\x12SERVICE_TYPE_OTHER\x10\t\x12\x18\n" +
-	"\x14SERVICE_TYPE_SYSTEMD\x10\n" +
-	"2\xfd\x03\n" +
-	"\x0fWatchdogService\x12>\n" +
-	"\tGetHealth\x12\x17.watchdog.HealthRequest\x1a\x18.watchdog.HealthResponse\x12V\n" +
-	"\x0fRegisterService\x12 .watchdog.RegisterServiceRequest\x1a!.watchdog.RegisterServiceResponse\x12\\\n" +
-	"\x11UnregisterService\x12\".watchdog.UnregisterServiceRequest\x1a#.watchdog.UnregisterServiceResponse\x12M\n" +
-	"\fListServices\x12\x1d.watchdog.ListServicesRequest\x1a\x1e.watchdog.ListServicesResponse\x12P\n" +
-	"\rUpdateService\x12\x1e.watchdog.UpdateServiceRequest\x1a\x1f.watchdog.UpdateServiceResponse\x12S\n" +
-	"\x12CheckServiceHealth\x12#.watchdog.CheckServiceHealthRequest\x1a\x18.watchdog.HealthResponseB\x0eZ\fwatchdog/apib\x06proto3"
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_proto_watchdog_proto_rawDescOnce sync.Once
-	file_proto_watchdog_proto_rawDescData []byte
-)
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_proto_watchdog_proto_rawDescGZIP() []byte {
-	file_proto_watchdog_proto_rawDescOnce.Do(func() {
-		file_proto_watchdog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_watchdog_proto_rawDesc), len(file_proto_watchdog_proto_rawDesc)))
-	})
-	return file_proto_watchdog_proto_rawDescData
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_proto_watchdog_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_watchdog_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_proto_watchdog_proto_goTypes = []any{
-	(ServiceType)(0),                  // 0: watchdog.ServiceType
-	(*CheckServiceHealthRequest)(nil), // 1: watchdog.CheckServiceHealthRequest
-	(*HealthRequest)(nil),             // 2: watchdog.HealthRequest
-	(*HealthResponse)(nil),            // 3: watchdog.HealthResponse
-	(*ServiceInfo)(nil),               // 4: watchdog.ServiceInfo
-	(*RegisterServiceRequest)(nil),    // 5: watchdog.RegisterServiceRequest
-	(*RegisterServiceResponse)(nil),   // 6: watchdog.RegisterServiceResponse
-	(*UnregisterServiceRequest)(nil),  // 7: watchdog.UnregisterServiceRequest
-	(*UnregisterServiceResponse)(nil), // 8: watchdog.UnregisterServiceResponse
-	(*ListServicesRequest)(nil),       // 9: watchdog.ListServicesRequest
-	(*ListServicesResponse)(nil),      // 10: watchdog.ListServicesResponse
-	(*UpdateServiceRequest)(nil),      // 11: watchdog.UpdateServiceRequest
-	(*UpdateServiceResponse)(nil),     // 12: watchdog.UpdateServiceResponse
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{13}
 }
-var file_proto_watchdog_proto_depIdxs = []int32{
-	0,  // 0: watchdog.ServiceInfo.type:type_name -> watchdog.ServiceType
-	0,  // 1: watchdog.RegisterServiceRequest.type:type_name -> watchdog.ServiceType
-	4,  // 2: watchdog.ListServicesResponse.services:type_name -> watchdog.ServiceInfo
-	0,  // 3: watchdog.UpdateServiceRequest.type:type_name -> watchdog.ServiceType
-	2,  // 4: watchdog.WatchdogService.GetHealth:input_type -> watchdog.HealthRequest
-	5,  // 5: watchdog.WatchdogService.RegisterService:input_type -> watchdog.RegisterServiceRequest
-	7,  // 6: watchdog.WatchdogService.UnregisterService:input_type -> watchdog.UnregisterServiceRequest
-	9,  // 7: watchdog.WatchdogService.ListServices:input_type -> watchdog.ListServicesRequest
-	11, // 8: watchdog.WatchdogService.UpdateService:input_type -> watchdog.UpdateServiceRequest
-	1,  // 9: watchdog.WatchdogService.CheckServiceHealth:input_type -> watchdog.CheckServiceHealthRequest
-	3,  // 10: watchdog.WatchdogService.GetHealth:output_type -> watchdog.HealthResponse
-	6,  // 11: watchdog.WatchdogService.RegisterService:output_type -> watchdog.RegisterServiceResponse
-	8,  // 12: watchdog.WatchdogService.UnregisterService:output_type -> watchdog.UnregisterServiceResponse
-	10, // 13: watchdog.WatchdogService.ListServices:output_type -> watchdog.ListServicesResponse
-	12, // 14: watchdog.WatchdogService.UpdateService:output_type -> watchdog.UpdateServiceResponse
-	3,  // 15: watchdog.WatchdogService.CheckServiceHealth:output_type -> watchdog.HealthResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+
+func (x *HeartbeatRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
 }
 
-func init() { file_proto_watchdog_proto_init() }
-func file_proto_watchdog_proto_init() {
-	if File_proto_watchdog_proto != nil {
-		return
+func (x *HeartbeatRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type HeartbeatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the service after the heartbeat
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HeartbeatResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type AgentMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*AgentMessage_Hello
+	//	*AgentMessage_Result
+	Message       isAgentMessage_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentMessage) Reset() {
+	*x = AgentMessage{}
+	mi := &file_proto_watchdog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentMessage) ProtoMessage() {}
+
+func (x *AgentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentMessage.ProtoReflect.Descriptor instead.
+func (*AgentMessage) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AgentMessage) GetMessage() isAgentMessage_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetHello() *AgentHello {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_Hello); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetResult() *AgentCheckResult {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isAgentMessage_Message interface {
+	isAgentMessage_Message()
+}
+
+type AgentMessage_Hello struct {
+	// First message of every stream
+	Hello *AgentHello `protobuf:"bytes,1,opt,name=hello,proto3,oneof"`
+}
+
+type AgentMessage_Result struct {
+	Result *AgentCheckResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*AgentMessage_Hello) isAgentMessage_Message() {}
+
+func (*AgentMessage_Result) isAgentMessage_Message() {}
+
+type AgentHello struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentHello) Reset() {
+	*x = AgentHello{}
+	mi := &file_proto_watchdog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentHello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentHello) ProtoMessage() {}
+
+func (x *AgentHello) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentHello.ProtoReflect.Descriptor instead.
+func (*AgentHello) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AgentHello) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AgentHello) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *AgentHello) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// AgentCheck asks the agent to check one service now
+type AgentCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckId       string                 `protobuf:"bytes,1,opt,name=check_id,json=checkId,proto3" json:"check_id,omitempty"`
+	Service       *ServiceInfo           `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentCheck) Reset() {
+	*x = AgentCheck{}
+	mi := &file_proto_watchdog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCheck) ProtoMessage() {}
+
+func (x *AgentCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCheck.ProtoReflect.Descriptor instead.
+func (*AgentCheck) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AgentCheck) GetCheckId() string {
+	if x != nil {
+		return x.CheckId
+	}
+	return ""
+}
+
+func (x *AgentCheck) GetService() *ServiceInfo {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type AgentCheckResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	CheckId string                 `protobuf:"bytes,1,opt,name=check_id,json=checkId,proto3" json:"check_id,omitempty"`
+	Result  *CheckResult           `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// Set when the check could not run, e.g. for an unsupported service type
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentCheckResult) Reset() {
+	*x = AgentCheckResult{}
+	mi := &file_proto_watchdog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentCheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCheckResult) ProtoMessage() {}
+
+func (x *AgentCheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCheckResult.ProtoReflect.Descriptor instead.
+func (*AgentCheckResult) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AgentCheckResult) GetCheckId() string {
+	if x != nil {
+		return x.CheckId
+	}
+	return ""
+}
+
+func (x *AgentCheckResult) GetResult() *CheckResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *AgentCheckResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceRequest) Reset() {
+	*x = GetServiceRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceRequest) ProtoMessage() {}
+
+func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetServiceRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *GetServiceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CheckResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Status    string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message   string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	LatencyMs int64                  `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	CheckedAt int64                  `protobuf:"varint,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Outcome at every location of a multi-location check, the status above
+	// is the quorum verdict
+	Locations     []*LocationResult `protobuf:"bytes,5,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_proto_watchdog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CheckResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckResult) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *CheckResult) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *CheckResult) GetLocations() []*LocationResult {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type LocationResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// "unknown" when the location could not run the check
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	LatencyMs     int64  `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationResult) Reset() {
+	*x = LocationResult{}
+	mi := &file_proto_watchdog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationResult) ProtoMessage() {}
+
+func (x *LocationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationResult.ProtoReflect.Descriptor instead.
+func (*LocationResult) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LocationResult) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *LocationResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LocationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LocationResult) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+type ListCheckResultsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Unix timestamp, only results at or after it are returned, 0 means unbounded
+	Since int64 `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	// Maximum number of results, newest first, defaults to 100
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCheckResultsRequest) Reset() {
+	*x = ListCheckResultsRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCheckResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCheckResultsRequest) ProtoMessage() {}
+
+func (x *ListCheckResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCheckResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListCheckResultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListCheckResultsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ListCheckResultsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListCheckResultsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListCheckResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCheckResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CheckResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCheckResultsResponse) Reset() {
+	*x = ListCheckResultsResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCheckResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCheckResultsResponse) ProtoMessage() {}
+
+func (x *ListCheckResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCheckResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListCheckResultsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCheckResultsResponse) GetResults() []*CheckResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type NamespaceInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Maximum number of services in the namespace, 0 means unlimited.
+	MaxServices int32 `protobuf:"varint,3,opt,name=max_services,json=maxServices,proto3" json:"max_services,omitempty"`
+	// Smallest check interval services may use, 0 means no limit.
+	MinCheckIntervalSeconds int32 `protobuf:"varint,4,opt,name=min_check_interval_seconds,json=minCheckIntervalSeconds,proto3" json:"min_check_interval_seconds,omitempty"`
+	ServiceCount            int32 `protobuf:"varint,5,opt,name=service_count,json=serviceCount,proto3" json:"service_count,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *NamespaceInfo) Reset() {
+	*x = NamespaceInfo{}
+	mi := &file_proto_watchdog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceInfo) ProtoMessage() {}
+
+func (x *NamespaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceInfo.ProtoReflect.Descriptor instead.
+func (*NamespaceInfo) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NamespaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamespaceInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *NamespaceInfo) GetMaxServices() int32 {
+	if x != nil {
+		return x.MaxServices
+	}
+	return 0
+}
+
+func (x *NamespaceInfo) GetMinCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.MinCheckIntervalSeconds
+	}
+	return 0
+}
+
+func (x *NamespaceInfo) GetServiceCount() int32 {
+	if x != nil {
+		return x.ServiceCount
+	}
+	return 0
+}
+
+type CreateNamespaceRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Name                    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description             string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	MaxServices             int32                  `protobuf:"varint,3,opt,name=max_services,json=maxServices,proto3" json:"max_services,omitempty"`
+	MinCheckIntervalSeconds int32                  `protobuf:"varint,4,opt,name=min_check_interval_seconds,json=minCheckIntervalSeconds,proto3" json:"min_check_interval_seconds,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetMaxServices() int32 {
+	if x != nil {
+		return x.MaxServices
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) GetMinCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.MinCheckIntervalSeconds
+	}
+	return 0
+}
+
+type CreateNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceResponse) Reset() {
+	*x = CreateNamespaceResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceResponse) ProtoMessage() {}
+
+func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateNamespaceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{27}
+}
+
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []*NamespaceInfo       `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*NamespaceInfo {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+type UpdateNamespaceRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Name                    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description             string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	MaxServices             int32                  `protobuf:"varint,3,opt,name=max_services,json=maxServices,proto3" json:"max_services,omitempty"`
+	MinCheckIntervalSeconds int32                  `protobuf:"varint,4,opt,name=min_check_interval_seconds,json=minCheckIntervalSeconds,proto3" json:"min_check_interval_seconds,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *UpdateNamespaceRequest) Reset() {
+	*x = UpdateNamespaceRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNamespaceRequest) ProtoMessage() {}
+
+func (x *UpdateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateNamespaceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateNamespaceRequest) GetMaxServices() int32 {
+	if x != nil {
+		return x.MaxServices
+	}
+	return 0
+}
+
+func (x *UpdateNamespaceRequest) GetMinCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.MinCheckIntervalSeconds
+	}
+	return 0
+}
+
+type UpdateNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNamespaceResponse) Reset() {
+	*x = UpdateNamespaceResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNamespaceResponse) ProtoMessage() {}
+
+func (x *UpdateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateNamespaceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceRequest) Reset() {
+	*x = DeleteNamespaceRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceRequest) ProtoMessage() {}
+
+func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceResponse) Reset() {
+	*x = DeleteNamespaceResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceResponse) ProtoMessage() {}
+
+func (x *DeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteNamespaceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ID of the mutated service, empty for other entities
+	ServiceId string `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// Namespace of the service, the name of a mutated namespace, empty for
+	// incidents
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// One of "create", "update" or "delete"
+	Action    string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Actor     string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Peer      string `protobuf:"bytes,6,opt,name=peer,proto3" json:"peer,omitempty"`
+	RequestId string `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Entity fields before and after the mutation, JSON encoded
+	BeforeJson string `protobuf:"bytes,8,opt,name=before_json,json=beforeJson,proto3" json:"before_json,omitempty"`
+	AfterJson  string `protobuf:"bytes,9,opt,name=after_json,json=afterJson,proto3" json:"after_json,omitempty"`
+	// Changed fields mapped to {"from": ..., "to": ...}, JSON encoded
+	ChangesJson string `protobuf:"bytes,10,opt,name=changes_json,json=changesJson,proto3" json:"changes_json,omitempty"`
+	PrevHash    string `protobuf:"bytes,11,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash        string `protobuf:"bytes,12,opt,name=hash,proto3" json:"hash,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// One of "service", "namespace", "incident" or "status_update"
+	Entity string `protobuf:"bytes,14,opt,name=entity,proto3" json:"entity,omitempty"`
+	// ID of the mutated namespace, incident or status update, empty for
+	// services
+	EntityId      string `protobuf:"bytes,15,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_proto_watchdog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetBeforeJson() string {
+	if x != nil {
+		return x.BeforeJson
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfterJson() string {
+	if x != nil {
+		return x.AfterJson
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetChangesJson() string {
+	if x != nil {
+		return x.ChangesJson
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// List events from every namespace. Requires an admin token.
+	AllNamespaces bool   `protobuf:"varint,2,opt,name=all_namespaces,json=allNamespaces,proto3" json:"all_namespaces,omitempty"`
+	ServiceId     string `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Actor         string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Unix timestamps bounding the event time, 0 means unbounded
+	Since int64 `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until int64 `protobuf:"varint,6,opt,name=until,proto3" json:"until,omitempty"`
+	// Maximum number of events, defaults to 100
+	Limit         int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListAuditEventsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.AllNamespaces
+	}
+	return false
+}
+
+func (x *ListAuditEventsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type VerifyAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAuditLogRequest) Reset() {
+	*x = VerifyAuditLogRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditLogRequest) ProtoMessage() {}
+
+func (x *VerifyAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{36}
+}
+
+type VerifyAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intact        bool                   `protobuf:"varint,1,opt,name=intact,proto3" json:"intact,omitempty"`
+	CheckedEvents int64                  `protobuf:"varint,2,opt,name=checked_events,json=checkedEvents,proto3" json:"checked_events,omitempty"`
+	// First event whose hash or link does not match, empty if intact
+	FirstBrokenId string `protobuf:"bytes,3,opt,name=first_broken_id,json=firstBrokenId,proto3" json:"first_broken_id,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAuditLogResponse) Reset() {
+	*x = VerifyAuditLogResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditLogResponse) ProtoMessage() {}
+
+func (x *VerifyAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VerifyAuditLogResponse) GetIntact() bool {
+	if x != nil {
+		return x.Intact
+	}
+	return false
+}
+
+func (x *VerifyAuditLogResponse) GetCheckedEvents() int64 {
+	if x != nil {
+		return x.CheckedEvents
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetFirstBrokenId() string {
+	if x != nil {
+		return x.FirstBrokenId
+	}
+	return ""
+}
+
+func (x *VerifyAuditLogResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type IncidentUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "investigating", "identified", "monitoring" or "resolved"
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncidentUpdate) Reset() {
+	*x = IncidentUpdate{}
+	mi := &file_proto_watchdog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncidentUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidentUpdate) ProtoMessage() {}
+
+func (x *IncidentUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidentUpdate.ProtoReflect.Descriptor instead.
+func (*IncidentUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *IncidentUpdate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *IncidentUpdate) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *IncidentUpdate) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type Incident struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// One of "investigating", "identified", "monitoring" or "resolved"
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// One of "none", "minor", "major" or "critical"
+	Impact string `protobuf:"bytes,4,opt,name=impact,proto3" json:"impact,omitempty"`
+	// Names of the affected status page components
+	Components []string `protobuf:"bytes,5,rep,name=components,proto3" json:"components,omitempty"`
+	CreatedAt  int64    `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  int64    `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Unix timestamp of the resolution, 0 while the incident is open
+	ResolvedAt int64 `protobuf:"varint,8,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	// Timeline of the incident, newest first
+	Updates       []*IncidentUpdate `protobuf:"bytes,9,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Incident) Reset() {
+	*x = Incident{}
+	mi := &file_proto_watchdog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Incident) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Incident) ProtoMessage() {}
+
+func (x *Incident) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Incident.ProtoReflect.Descriptor instead.
+func (*Incident) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Incident) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Incident) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Incident) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Incident) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+func (x *Incident) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Incident) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Incident) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Incident) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+func (x *Incident) GetUpdates() []*IncidentUpdate {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type CreateIncidentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Title string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Defaults to "investigating"
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Defaults to "minor"
+	Impact     string   `protobuf:"bytes,3,opt,name=impact,proto3" json:"impact,omitempty"`
+	Components []string `protobuf:"bytes,4,rep,name=components,proto3" json:"components,omitempty"`
+	// First timeline entry
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIncidentRequest) Reset() {
+	*x = CreateIncidentRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIncidentRequest) ProtoMessage() {}
+
+func (x *CreateIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIncidentRequest.ProtoReflect.Descriptor instead.
+func (*CreateIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateIncidentRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *CreateIncidentRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PostIncidentUpdateRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	IncidentId string                 `protobuf:"bytes,1,opt,name=incident_id,json=incidentId,proto3" json:"incident_id,omitempty"`
+	// New status of the incident, empty keeps the current one
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// New impact of the incident, empty keeps the current one
+	Impact        string `protobuf:"bytes,4,opt,name=impact,proto3" json:"impact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostIncidentUpdateRequest) Reset() {
+	*x = PostIncidentUpdateRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostIncidentUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostIncidentUpdateRequest) ProtoMessage() {}
+
+func (x *PostIncidentUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostIncidentUpdateRequest.ProtoReflect.Descriptor instead.
+func (*PostIncidentUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PostIncidentUpdateRequest) GetIncidentId() string {
+	if x != nil {
+		return x.IncidentId
+	}
+	return ""
+}
+
+func (x *PostIncidentUpdateRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PostIncidentUpdateRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PostIncidentUpdateRequest) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+type ListIncidentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unix timestamp, incidents opened before it are omitted unless still open, 0 means unbounded
+	Since int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	// Maximum number of incidents, newest first, defaults to 20
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsRequest) Reset() {
+	*x = ListIncidentsRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsRequest) ProtoMessage() {}
+
+func (x *ListIncidentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsRequest.ProtoReflect.Descriptor instead.
+func (*ListIncidentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListIncidentsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListIncidentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListIncidentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incidents     []*Incident            `protobuf:"bytes,1,rep,name=incidents,proto3" json:"incidents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsResponse) Reset() {
+	*x = ListIncidentsResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsResponse) ProtoMessage() {}
+
+func (x *ListIncidentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsResponse.ProtoReflect.Descriptor instead.
+func (*ListIncidentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListIncidentsResponse) GetIncidents() []*Incident {
+	if x != nil {
+		return x.Incidents
+	}
+	return nil
+}
+
+type ListShardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShardsRequest) Reset() {
+	*x = ListShardsRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShardsRequest) ProtoMessage() {}
+
+func (x *ListShardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShardsRequest.ProtoReflect.Descriptor instead.
+func (*ListShardsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{44}
+}
+
+type ShardMember struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Instance string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	// Unix timestamp of when the replica joined
+	JoinedAt int64 `protobuf:"varint,2,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	// Unix timestamp of the latest heartbeat of the replica
+	SeenAt int64 `protobuf:"varint,3,opt,name=seen_at,json=seenAt,proto3" json:"seen_at,omitempty"`
+	// Services the replica checks
+	ServiceIds    []string `protobuf:"bytes,4,rep,name=service_ids,json=serviceIds,proto3" json:"service_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShardMember) Reset() {
+	*x = ShardMember{}
+	mi := &file_proto_watchdog_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShardMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardMember) ProtoMessage() {}
+
+func (x *ShardMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardMember.ProtoReflect.Descriptor instead.
+func (*ShardMember) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ShardMember) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *ShardMember) GetJoinedAt() int64 {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return 0
+}
+
+func (x *ShardMember) GetSeenAt() int64 {
+	if x != nil {
+		return x.SeenAt
+	}
+	return 0
+}
+
+func (x *ShardMember) GetServiceIds() []string {
+	if x != nil {
+		return x.ServiceIds
+	}
+	return nil
+}
+
+type ListShardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*ShardMember         `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShardsResponse) Reset() {
+	*x = ListShardsResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShardsResponse) ProtoMessage() {}
+
+func (x *ListShardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShardsResponse.ProtoReflect.Descriptor instead.
+func (*ListShardsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListShardsResponse) GetMembers() []*ShardMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ListFederatedServicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only list the services of this cluster, empty lists every cluster
+	Cluster       string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFederatedServicesRequest) Reset() {
+	*x = ListFederatedServicesRequest{}
+	mi := &file_proto_watchdog_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFederatedServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFederatedServicesRequest) ProtoMessage() {}
+
+func (x *ListFederatedServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFederatedServicesRequest.ProtoReflect.Descriptor instead.
+func (*ListFederatedServicesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListFederatedServicesRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+type ClusterSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// gRPC address of the child, empty for this watchdog
+	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Reachable bool   `protobuf:"varint,3,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// Why the latest poll failed, empty when reachable
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Unix timestamp of the latest successful poll, 0 if never
+	SyncedAt  int64 `protobuf:"varint,5,opt,name=synced_at,json=syncedAt,proto3" json:"synced_at,omitempty"`
+	Services  int32 `protobuf:"varint,6,opt,name=services,proto3" json:"services,omitempty"`
+	Healthy   int32 `protobuf:"varint,7,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Unhealthy int32 `protobuf:"varint,8,opt,name=unhealthy,proto3" json:"unhealthy,omitempty"`
+	// Services never checked yet or in an unreachable cluster
+	Unknown       int32 `protobuf:"varint,9,opt,name=unknown,proto3" json:"unknown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterSummary) Reset() {
+	*x = ClusterSummary{}
+	mi := &file_proto_watchdog_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterSummary) ProtoMessage() {}
+
+func (x *ClusterSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterSummary.ProtoReflect.Descriptor instead.
+func (*ClusterSummary) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ClusterSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClusterSummary) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ClusterSummary) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *ClusterSummary) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ClusterSummary) GetSyncedAt() int64 {
+	if x != nil {
+		return x.SyncedAt
+	}
+	return 0
+}
+
+func (x *ClusterSummary) GetServices() int32 {
+	if x != nil {
+		return x.Services
+	}
+	return 0
+}
+
+func (x *ClusterSummary) GetHealthy() int32 {
+	if x != nil {
+		return x.Healthy
+	}
+	return 0
+}
+
+func (x *ClusterSummary) GetUnhealthy() int32 {
+	if x != nil {
+		return x.Unhealthy
+	}
+	return 0
+}
+
+func (x *ClusterSummary) GetUnknown() int32 {
+	if x != nil {
+		return x.Unknown
+	}
+	return 0
+}
+
+type ListFederatedServicesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Clusters []*ClusterSummary      `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	// Services of an unreachable cluster keep their last known details with
+	// the status "unknown"
+	Services      []*ServiceInfo `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFederatedServicesResponse) Reset() {
+	*x = ListFederatedServicesResponse{}
+	mi := &file_proto_watchdog_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFederatedServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFederatedServicesResponse) ProtoMessage() {}
+
+func (x *ListFederatedServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_watchdog_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFederatedServicesResponse.ProtoReflect.Descriptor instead.
+func (*ListFederatedServicesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_watchdog_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListFederatedServicesResponse) GetClusters() []*ClusterSummary {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+func (x *ListFederatedServicesResponse) GetServices() []*ServiceInfo {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+var File_proto_watchdog_proto protoreflect.FileDescriptor
+
+const file_proto_watchdog_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/watchdog.proto\x12\bwatchdog\"X\n" +
+	"\x19CheckServiceHealthRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"-\n" +
+	"\rHealthRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"Z\n" +
+	"\x0eHealthResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06leader\x18\x03 \x01(\tR\x06leader\"\x88\x04\n" +
+	"\vServiceInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0elast_heartbeat\x18\x05 \x01(\x03R\rlastHeartbeat\x12)\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x15.watchdog.ServiceTypeR\x04type\x12\x1c\n" +
+	"\tnamespace\x18\a \x01(\tR\tnamespace\x124\n" +
+	"\x16check_interval_seconds\x18\b \x01(\x05R\x14checkIntervalSeconds\x129\n" +
+	"\x06labels\x18\t \x03(\v2!.watchdog.ServiceInfo.LabelsEntryR\x06labels\x12&\n" +
+	"\x0flast_checked_at\x18\n" +
+	" \x01(\x03R\rlastCheckedAt\x12*\n" +
+	"\x11last_check_status\x18\v \x01(\tR\x0flastCheckStatus\x121\n" +
+	"\x15last_check_latency_ms\x18\f \x01(\x03R\x12lastCheckLatencyMs\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x02\n" +
+	"\x16RegisterServiceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.watchdog.ServiceTypeR\x04type\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x124\n" +
+	"\x16check_interval_seconds\x18\x05 \x01(\x05R\x14checkIntervalSeconds\x12D\n" +
+	"\x06labels\x18\x06 \x03(\v2,.watchdog.RegisterServiceRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"R\n" +
+	"\x17RegisterServiceResponse\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"W\n" +
+	"\x18UnregisterServiceRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"5\n" +
+	"\x19UnregisterServiceResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"Z\n" +
+	"\x13ListServicesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12%\n" +
+	"\x0eall_namespaces\x18\x02 \x01(\bR\rallNamespaces\"I\n" +
+	"\x14ListServicesResponse\x121\n" +
+	"\bservices\x18\x01 \x03(\v2\x15.watchdog.ServiceInfoR\bservices\"o\n" +
+	"\x14WatchServicesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12%\n" +
+	"\x0eall_namespaces\x18\x02 \x01(\bR\rallNamespaces\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\x9e\x03\n" +
+	"\x14UpdateServiceRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x15.watchdog.ServiceTypeR\x04type\x12\x1a\n" +
+	"\bendpoint\x18\x05 \x01(\tR\bendpoint\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x124\n" +
+	"\x16check_interval_seconds\x18\a \x01(\x05R\x14checkIntervalSeconds\x12B\n" +
+	"\x06labels\x18\b \x03(\v2*.watchdog.UpdateServiceRequest.LabelsEntryR\x06labels\x12!\n" +
+	"\fclear_labels\x18\t \x01(\bR\vclearLabels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"1\n" +
+	"\x15UpdateServiceResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"e\n" +
+	"\x10HeartbeatRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05ready\x18\x03 \x01(\bR\x05ready\"+\n" +
+	"\x11HeartbeatResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"}\n" +
+	"\fAgentMessage\x12,\n" +
+	"\x05hello\x18\x01 \x01(\v2\x14.watchdog.AgentHelloH\x00R\x05hello\x124\n" +
+	"\x06result\x18\x02 \x01(\v2\x1a.watchdog.AgentCheckResultH\x00R\x06resultB\t\n" +
+	"\amessage\"V\n" +
+	"\n" +
+	"AgentHello\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"X\n" +
+	"\n" +
+	"AgentCheck\x12\x19\n" +
+	"\bcheck_id\x18\x01 \x01(\tR\acheckId\x12/\n" +
+	"\aservice\x18\x02 \x01(\v2\x15.watchdog.ServiceInfoR\aservice\"r\n" +
+	"\x10AgentCheckResult\x12\x19\n" +
+	"\bcheck_id\x18\x01 \x01(\tR\acheckId\x12-\n" +
+	"\x06result\x18\x02 \x01(\v2\x15.watchdog.CheckResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"P\n" +
+	"\x11GetServiceRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xb5\x01\n" +
+	"\vCheckResult\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x03 \x01(\x03R\tlatencyMs\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\x03R\tcheckedAt\x126\n" +
+	"\tlocations\x18\x05 \x03(\v2\x18.watchdog.LocationResultR\tlocations\"}\n" +
+	"\x0eLocationResult\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\"\x82\x01\n" +
+	"\x17ListCheckResultsRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"K\n" +
+	"\x18ListCheckResultsResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.watchdog.CheckResultR\aresults\"\xca\x01\n" +
+	"\rNamespaceInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12!\n" +
+	"\fmax_services\x18\x03 \x01(\x05R\vmaxServices\x12;\n" +
+	"\x1amin_check_interval_seconds\x18\x04 \x01(\x05R\x17minCheckIntervalSeconds\x12#\n" +
+	"\rservice_count\x18\x05 \x01(\x05R\fserviceCount\"\xae\x01\n" +
+	"\x16CreateNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12!\n" +
+	"\fmax_services\x18\x03 \x01(\x05R\vmaxServices\x12;\n" +
+	"\x1amin_check_interval_seconds\x18\x04 \x01(\x05R\x17minCheckIntervalSeconds\"3\n" +
+	"\x17CreateNamespaceResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x17\n" +
+	"\x15ListNamespacesRequest\"Q\n" +
+	"\x16ListNamespacesResponse\x127\n" +
+	"\n" +
+	"namespaces\x18\x01 \x03(\v2\x17.watchdog.NamespaceInfoR\n" +
+	"namespaces\"\xae\x01\n" +
+	"\x16UpdateNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12!\n" +
+	"\fmax_services\x18\x03 \x01(\x05R\vmaxServices\x12;\n" +
+	"\x1amin_check_interval_seconds\x18\x04 \x01(\x05R\x17minCheckIntervalSeconds\"3\n" +
+	"\x17UpdateNamespaceResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\",\n" +
+	"\x16DeleteNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"3\n" +
+	"\x17DeleteNamespaceResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xa2\x03\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tR\tserviceId\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04peer\x18\x06 \x01(\tR\x04peer\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x1f\n" +
+	"\vbefore_json\x18\b \x01(\tR\n" +
+	"beforeJson\x12\x1d\n" +
+	"\n" +
+	"after_json\x18\t \x01(\tR\tafterJson\x12!\n" +
+	"\fchanges_json\x18\n" +
+	" \x01(\tR\vchangesJson\x12\x1b\n" +
+	"\tprev_hash\x18\v \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\f \x01(\tR\x04hash\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\x03R\tcreatedAt\x12\x16\n" +
+	"\x06entity\x18\x0e \x01(\tR\x06entity\x12\x1b\n" +
+	"\tentity_id\x18\x0f \x01(\tR\bentityId\"\xd4\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12%\n" +
+	"\x0eall_namespaces\x18\x02 \x01(\bR\rallNamespaces\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x03 \x01(\tR\tserviceId\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x06 \x01(\x03R\x05until\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"G\n" +
+	"\x17ListAuditEventsResponse\x12,\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.watchdog.AuditEventR\x06events\"\x17\n" +
+	"\x15VerifyAuditLogRequest\"\x99\x01\n" +
+	"\x16VerifyAuditLogResponse\x12\x16\n" +
+	"\x06intact\x18\x01 \x01(\bR\x06intact\x12%\n" +
+	"\x0echecked_events\x18\x02 \x01(\x03R\rcheckedEvents\x12&\n" +
+	"\x0ffirst_broken_id\x18\x03 \x01(\tR\rfirstBrokenId\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"a\n" +
+	"\x0eIncidentUpdate\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"\x93\x02\n" +
+	"\bIncident\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06impact\x18\x04 \x01(\tR\x06impact\x12\x1e\n" +
+	"\n" +
+	"components\x18\x05 \x03(\tR\n" +
+	"components\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x1f\n" +
+	"\vresolved_at\x18\b \x01(\x03R\n" +
+	"resolvedAt\x122\n" +
+	"\aupdates\x18\t \x03(\v2\x18.watchdog.IncidentUpdateR\aupdates\"\x97\x01\n" +
+	"\x15CreateIncidentRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06impact\x18\x03 \x01(\tR\x06impact\x12\x1e\n" +
+	"\n" +
+	"components\x18\x04 \x03(\tR\n" +
+	"components\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\x86\x01\n" +
+	"\x19PostIncidentUpdateRequest\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06impact\x18\x04 \x01(\tR\x06impact\"B\n" +
+	"\x14ListIncidentsRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"I\n" +
+	"\x15ListIncidentsResponse\x120\n" +
+	"\tincidents\x18\x01 \x03(\v2\x12.watchdog.IncidentR\tincidents\"\x13\n" +
+	"\x11ListShardsRequest\"\x80\x01\n" +
+	"\vShardMember\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12\x1b\n" +
+	"\tjoined_at\x18\x02 \x01(\x03R\bjoinedAt\x12\x17\n" +
+	"\aseen_at\x18\x03 \x01(\x03R\x06seenAt\x12\x1f\n" +
+	"\vservice_ids\x18\x04 \x03(\tR\n" +
+	"serviceIds\"E\n" +
+	"\x12ListShardsResponse\x12/\n" +
+	"\amembers\x18\x01 \x03(\v2\x15.watchdog.ShardMemberR\amembers\"8\n" +
+	"\x1cListFederatedServicesRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"\xfd\x01\n" +
+	"\x0eClusterSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1c\n" +
+	"\treachable\x18\x03 \x01(\bR\treachable\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1b\n" +
+	"\tsynced_at\x18\x05 \x01(\x03R\bsyncedAt\x12\x1a\n" +
+	"\bservices\x18\x06 \x01(\x05R\bservices\x12\x18\n" +
+	"\ahealthy\x18\a \x01(\x05R\ahealthy\x12\x1c\n" +
+	"\tunhealthy\x18\b \x01(\x05R\tunhealthy\x12\x18\n" +
+	"\aunknown\x18\t \x01(\x05R\aunknown\"\x88\x01\n" +
+	"\x1dListFederatedServicesResponse\x124\n" +
+	"\bclusters\x18\x01 \x03(\v2\x18.watchdog.ClusterSummaryR\bclusters\x121\n" +
+	"\bservices\x18\x02 \x03(\v2\x15.watchdog.ServiceInfoR\bservices*\xae\x02\n" +
+	"\vServiceType\x12\x1c\n" +
+	"\x18SERVICE_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11SERVICE_TYPE_HTTP\x10\x01\x12\x15\n" +
+	"\x11SERVICE_TYPE_GRPC\x10\x02\x12\x19\n" +
+	"\x15SERVICE_TYPE_DATABASE\x10\x03\x12\x16\n" +
+	"\x12SERVICE_TYPE_CACHE\x10\x04\x12\x16\n" +
+	"\x12SERVICE_TYPE_QUEUE\x10\x05\x12\x18\n" +
+	"\x14SERVICE_TYPE_STORAGE\x10\x06\x12\x1d\n" +
+	"\x19SERVICE_TYPE_EXTERNAL_API\x10\a\x12\x1d\n" +
+	"\x19SERVICE_TYPE_MICROSERVICE\x10\b\x12\x16\n" +
+	"\x12SERVICE_TYPE_OTHER\x10\t\x12\x18\n" +
+	"\x14SERVICE_TYPE_SYSTEMD\x10\n" +
+	"2\x9a\x0e\n" +
+	"\x0fWatchdogService\x12>\n" +
+	"\tGetHealth\x12\x17.watchdog.HealthRequest\x1a\x18.watchdog.HealthResponse\x12V\n" +
+	"\x0fRegisterService\x12 .watchdog.RegisterServiceRequest\x1a!.watchdog.RegisterServiceResponse\x12\\\n" +
+	"\x11UnregisterService\x12\".watchdog.UnregisterServiceRequest\x1a#.watchdog.UnregisterServiceResponse\x12M\n" +
+	"\fListServices\x12\x1d.watchdog.ListServicesRequest\x1a\x1e.watchdog.ListServicesResponse\x12P\n" +
+	"\rUpdateService\x12\x1e.watchdog.UpdateServiceRequest\x1a\x1f.watchdog.UpdateServiceResponse\x12S\n" +
+	"\x12CheckServiceHealth\x12#.watchdog.CheckServiceHealthRequest\x1a\x18.watchdog.HealthResponse\x12@\n" +
+	"\n" +
+	"GetService\x12\x1b.watchdog.GetServiceRequest\x1a\x15.watchdog.ServiceInfo\x12Y\n" +
+	"\x10ListCheckResults\x12!.watchdog.ListCheckResultsRequest\x1a\".watchdog.ListCheckResultsResponse\x12Q\n" +
+	"\rWatchServices\x12\x1e.watchdog.WatchServicesRequest\x1a\x1e.watchdog.ListServicesResponse0\x01\x12D\n" +
+	"\tHeartbeat\x12\x1a.watchdog.HeartbeatRequest\x1a\x1b.watchdog.HeartbeatResponse\x12@\n" +
+	"\fAgentConnect\x12\x16.watchdog.AgentMessage\x1a\x14.watchdog.AgentCheck(\x010\x01\x12V\n" +
+	"\x0fCreateNamespace\x12 .watchdog.CreateNamespaceRequest\x1a!.watchdog.CreateNamespaceResponse\x12S\n" +
+	"\x0eListNamespaces\x12\x1f.watchdog.ListNamespacesRequest\x1a .watchdog.ListNamespacesResponse\x12V\n" +
+	"\x0fUpdateNamespace\x12 .watchdog.UpdateNamespaceRequest\x1a!.watchdog.UpdateNamespaceResponse\x12V\n" +
+	"\x0fDeleteNamespace\x12 .watchdog.DeleteNamespaceRequest\x1a!.watchdog.DeleteNamespaceResponse\x12V\n" +
+	"\x0fListAuditEvents\x12 .watchdog.ListAuditEventsRequest\x1a!.watchdog.ListAuditEventsResponse\x12S\n" +
+	"\x0eVerifyAuditLog\x12\x1f.watchdog.VerifyAuditLogRequest\x1a .watchdog.VerifyAuditLogResponse\x12E\n" +
+	"\x0eCreateIncident\x12\x1f.watchdog.CreateIncidentRequest\x1a\x12.watchdog.Incident\x12M\n" +
+	"\x12PostIncidentUpdate\x12#.watchdog.PostIncidentUpdateRequest\x1a\x12.watchdog.Incident\x12P\n" +
+	"\rListIncidents\x12\x1e.watchdog.ListIncidentsRequest\x1a\x1f.watchdog.ListIncidentsResponse\x12G\n" +
+	"\n" +
+	"ListShards\x12\x1b.watchdog.ListShardsRequest\x1a\x1c.watchdog.ListShardsResponse\x12h\n" +
+	"\x15ListFederatedServices\x12&.watchdog.ListFederatedServicesRequest\x1a'.watchdog.ListFederatedServicesResponseB\x0eZ\fwatchdog/apib\x06proto3"
+
+var (
+	file_proto_watchdog_proto_rawDescOnce sync.Once
+	file_proto_watchdog_proto_rawDescData []byte
+)
+
+func file_proto_watchdog_proto_rawDescGZIP() []byte {
+	file_proto_watchdog_proto_rawDescOnce.Do(func() {
+		file_proto_watchdog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_watchdog_proto_rawDesc), len(file_proto_watchdog_proto_rawDesc)))
+	})
+	return file_proto_watchdog_proto_rawDescData
+}
+
+var file_proto_watchdog_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_watchdog_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
+var file_proto_watchdog_proto_goTypes = []any{
+	(ServiceType)(0),                      // 0: watchdog.ServiceType
+	(*CheckServiceHealthRequest)(nil),     // 1: watchdog.CheckServiceHealthRequest
+	(*HealthRequest)(nil),                 // 2: watchdog.HealthRequest
+	(*HealthResponse)(nil),                // 3: watchdog.HealthResponse
+	(*ServiceInfo)(nil),                   // 4: watchdog.ServiceInfo
+	(*RegisterServiceRequest)(nil),        // 5: watchdog.RegisterServiceRequest
+	(*RegisterServiceResponse)(nil),       // 6: watchdog.RegisterServiceResponse
+	(*UnregisterServiceRequest)(nil),      // 7: watchdog.UnregisterServiceRequest
+	(*UnregisterServiceResponse)(nil),     // 8: watchdog.UnregisterServiceResponse
+	(*ListServicesRequest)(nil),           // 9: watchdog.ListServicesRequest
+	(*ListServicesResponse)(nil),          // 10: watchdog.ListServicesResponse
+	(*WatchServicesRequest)(nil),          // 11: watchdog.WatchServicesRequest
+	(*UpdateServiceRequest)(nil),          // 12: watchdog.UpdateServiceRequest
+	(*UpdateServiceResponse)(nil),         // 13: watchdog.UpdateServiceResponse
+	(*HeartbeatRequest)(nil),              // 14: watchdog.HeartbeatRequest
+	(*HeartbeatResponse)(nil),             // 15: watchdog.HeartbeatResponse
+	(*AgentMessage)(nil),                  // 16: watchdog.AgentMessage
+	(*AgentHello)(nil),                    // 17: watchdog.AgentHello
+	(*AgentCheck)(nil),                    // 18: watchdog.AgentCheck
+	(*AgentCheckResult)(nil),              // 19: watchdog.AgentCheckResult
+	(*GetServiceRequest)(nil),             // 20: watchdog.GetServiceRequest
+	(*CheckResult)(nil),                   // 21: watchdog.CheckResult
+	(*LocationResult)(nil),                // 22: watchdog.LocationResult
+	(*ListCheckResultsRequest)(nil),       // 23: watchdog.ListCheckResultsRequest
+	(*ListCheckResultsResponse)(nil),      // 24: watchdog.ListCheckResultsResponse
+	(*NamespaceInfo)(nil),                 // 25: watchdog.NamespaceInfo
+	(*CreateNamespaceRequest)(nil),        // 26: watchdog.CreateNamespaceRequest
+	(*CreateNamespaceResponse)(nil),       // 27: watchdog.CreateNamespaceResponse
+	(*ListNamespacesRequest)(nil),         // 28: watchdog.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil),        // 29: watchdog.ListNamespacesResponse
+	(*UpdateNamespaceRequest)(nil),        // 30: watchdog.UpdateNamespaceRequest
+	(*UpdateNamespaceResponse)(nil),       // 31: watchdog.UpdateNamespaceResponse
+	(*DeleteNamespaceRequest)(nil),        // 32: watchdog.DeleteNamespaceRequest
+	(*DeleteNamespaceResponse)(nil),       // 33: watchdog.DeleteNamespaceResponse
+	(*AuditEvent)(nil),                    // 34: watchdog.AuditEvent
+	(*ListAuditEventsRequest)(nil),        // 35: watchdog.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),       // 36: watchdog.ListAuditEventsResponse
+	(*VerifyAuditLogRequest)(nil),         // 37: watchdog.VerifyAuditLogRequest
+	(*VerifyAuditLogResponse)(nil),        // 38: watchdog.VerifyAuditLogResponse
+	(*IncidentUpdate)(nil),                // 39: watchdog.IncidentUpdate
+	(*Incident)(nil),                      // 40: watchdog.Incident
+	(*CreateIncidentRequest)(nil),         // 41: watchdog.CreateIncidentRequest
+	(*PostIncidentUpdateRequest)(nil),     // 42: watchdog.PostIncidentUpdateRequest
+	(*ListIncidentsRequest)(nil),          // 43: watchdog.ListIncidentsRequest
+	(*ListIncidentsResponse)(nil),         // 44: watchdog.ListIncidentsResponse
+	(*ListShardsRequest)(nil),             // 45: watchdog.ListShardsRequest
+	(*ShardMember)(nil),                   // 46: watchdog.ShardMember
+	(*ListShardsResponse)(nil),            // 47: watchdog.ListShardsResponse
+	(*ListFederatedServicesRequest)(nil),  // 48: watchdog.ListFederatedServicesRequest
+	(*ClusterSummary)(nil),                // 49: watchdog.ClusterSummary
+	(*ListFederatedServicesResponse)(nil), // 50: watchdog.ListFederatedServicesResponse
+	nil,                                   // 51: watchdog.ServiceInfo.LabelsEntry
+	nil,                                   // 52: watchdog.RegisterServiceRequest.LabelsEntry
+	nil,                                   // 53: watchdog.UpdateServiceRequest.LabelsEntry
+}
+var file_proto_watchdog_proto_depIdxs = []int32{
+	0,  // 0: watchdog.ServiceInfo.type:type_name -> watchdog.ServiceType
+	51, // 1: watchdog.ServiceInfo.labels:type_name -> watchdog.ServiceInfo.LabelsEntry
+	0,  // 2: watchdog.RegisterServiceRequest.type:type_name -> watchdog.ServiceType
+	52, // 3: watchdog.RegisterServiceRequest.labels:type_name -> watchdog.RegisterServiceRequest.LabelsEntry
+	4,  // 4: watchdog.ListServicesResponse.services:type_name -> watchdog.ServiceInfo
+	0,  // 5: watchdog.UpdateServiceRequest.type:type_name -> watchdog.ServiceType
+	53, // 6: watchdog.UpdateServiceRequest.labels:type_name -> watchdog.UpdateServiceRequest.LabelsEntry
+	17, // 7: watchdog.AgentMessage.hello:type_name -> watchdog.AgentHello
+	19, // 8: watchdog.AgentMessage.result:type_name -> watchdog.AgentCheckResult
+	4,  // 9: watchdog.AgentCheck.service:type_name -> watchdog.ServiceInfo
+	21, // 10: watchdog.AgentCheckResult.result:type_name -> watchdog.CheckResult
+	22, // 11: watchdog.CheckResult.locations:type_name -> watchdog.LocationResult
+	21, // 12: watchdog.ListCheckResultsResponse.results:type_name -> watchdog.CheckResult
+	25, // 13: watchdog.ListNamespacesResponse.namespaces:type_name -> watchdog.NamespaceInfo
+	34, // 14: watchdog.ListAuditEventsResponse.events:type_name -> watchdog.AuditEvent
+	39, // 15: watchdog.Incident.updates:type_name -> watchdog.IncidentUpdate
+	40, // 16: watchdog.ListIncidentsResponse.incidents:type_name -> watchdog.Incident
+	46, // 17: watchdog.ListShardsResponse.members:type_name -> watchdog.ShardMember
+	49, // 18: watchdog.ListFederatedServicesResponse.clusters:type_name -> watchdog.ClusterSummary
+	4,  // 19: watchdog.ListFederatedServicesResponse.services:type_name -> watchdog.ServiceInfo
+	2,  // 20: watchdog.WatchdogService.GetHealth:input_type -> watchdog.HealthRequest
+	5,  // 21: watchdog.WatchdogService.RegisterService:input_type -> watchdog.RegisterServiceRequest
+	7,  // 22: watchdog.WatchdogService.UnregisterService:input_type -> watchdog.UnregisterServiceRequest
+	9,  // 23: watchdog.WatchdogService.ListServices:input_type -> watchdog.ListServicesRequest
+	12, // 24: watchdog.WatchdogService.UpdateService:input_type -> watchdog.UpdateServiceRequest
+	1,  // 25: watchdog.WatchdogService.CheckServiceHealth:input_type -> watchdog.CheckServiceHealthRequest
+	20, // 26: watchdog.WatchdogService.GetService:input_type -> watchdog.GetServiceRequest
+	23, // 27: watchdog.WatchdogService.ListCheckResults:input_type -> watchdog.ListCheckResultsRequest
+	11, // 28: watchdog.WatchdogService.WatchServices:input_type -> watchdog.WatchServicesRequest
+	14, // 29: watchdog.WatchdogService.Heartbeat:input_type -> watchdog.HeartbeatRequest
+	16, // 30: watchdog.WatchdogService.AgentConnect:input_type -> watchdog.AgentMessage
+	26, // 31: watchdog.WatchdogService.CreateNamespace:input_type -> watchdog.CreateNamespaceRequest
+	28, // 32: watchdog.WatchdogService.ListNamespaces:input_type -> watchdog.ListNamespacesRequest
+	30, // 33: watchdog.WatchdogService.UpdateNamespace:input_type -> watchdog.UpdateNamespaceRequest
+	32, // 34: watchdog.WatchdogService.DeleteNamespace:input_type -> watchdog.DeleteNamespaceRequest
+	35, // 35: watchdog.WatchdogService.ListAuditEvents:input_type -> watchdog.ListAuditEventsRequest
+	37, // 36: watchdog.WatchdogService.VerifyAuditLog:input_type -> watchdog.VerifyAuditLogRequest
+	41, // 37: watchdog.WatchdogService.CreateIncident:input_type -> watchdog.CreateIncidentRequest
+	42, // 38: watchdog.WatchdogService.PostIncidentUpdate:input_type -> watchdog.PostIncidentUpdateRequest
+	43, // 39: watchdog.WatchdogService.ListIncidents:input_type -> watchdog.ListIncidentsRequest
+	45, // 40: watchdog.WatchdogService.ListShards:input_type -> watchdog.ListShardsRequest
+	48, // 41: watchdog.WatchdogService.ListFederatedServices:input_type -> watchdog.ListFederatedServicesRequest
+	3,  // 42: watchdog.WatchdogService.GetHealth:output_type -> watchdog.HealthResponse
+	6,  // 43: watchdog.WatchdogService.RegisterService:output_type -> watchdog.RegisterServiceResponse
+	8,  // 44: watchdog.WatchdogService.UnregisterService:output_type -> watchdog.UnregisterServiceResponse
+	10, // 45: watchdog.WatchdogService.ListServices:output_type -> watchdog.ListServicesResponse
+	13, // 46: watchdog.WatchdogService.UpdateService:output_type -> watchdog.UpdateServiceResponse
+	3,  // 47: watchdog.WatchdogService.CheckServiceHealth:output_type -> watchdog.HealthResponse
+	4,  // 48: watchdog.WatchdogService.GetService:output_type -> watchdog.ServiceInfo
+	24, // 49: watchdog.WatchdogService.ListCheckResults:output_type -> watchdog.ListCheckResultsResponse
+	10, // 50: watchdog.WatchdogService.WatchServices:output_type -> watchdog.ListServicesResponse
+	15, // 51: watchdog.WatchdogService.Heartbeat:output_type -> watchdog.HeartbeatResponse
+	18, // 52: watchdog.WatchdogService.AgentConnect:output_type -> watchdog.AgentCheck
+	27, // 53: watchdog.WatchdogService.CreateNamespace:output_type -> watchdog.CreateNamespaceResponse
+	29, // 54: watchdog.WatchdogService.ListNamespaces:output_type -> watchdog.ListNamespacesResponse
+	31, // 55: watchdog.WatchdogService.UpdateNamespace:output_type -> watchdog.UpdateNamespaceResponse
+	33, // 56: watchdog.WatchdogService.DeleteNamespace:output_type -> watchdog.DeleteNamespaceResponse
+	36, // 57: watchdog.WatchdogService.ListAuditEvents:output_type -> watchdog.ListAuditEventsResponse
+	38, // 58: watchdog.WatchdogService.VerifyAuditLog:output_type -> watchdog.VerifyAuditLogResponse
+	40, // 59: watchdog.WatchdogService.CreateIncident:output_type -> watchdog.Incident
+	40, // 60: watchdog.WatchdogService.PostIncidentUpdate:output_type -> watchdog.Incident
+	44, // 61: watchdog.WatchdogService.ListIncidents:output_type -> watchdog.ListIncidentsResponse
+	47, // 62: watchdog.WatchdogService.ListShards:output_type -> watchdog.ListShardsResponse
+	50, // 63: watchdog.WatchdogService.ListFederatedServices:output_type -> watchdog.ListFederatedServicesResponse
+	42, // [42:64] is the sub-list for method output_type
+	20, // [20:42] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_proto_watchdog_proto_init() }
+func file_proto_watchdog_proto_init() {
+	if File_proto_watchdog_proto != nil {
+		return
+	}
+	file_proto_watchdog_proto_msgTypes[15].OneofWrappers = []any{
+		(*AgentMessage_Hello)(nil),
+		(*AgentMessage_Result)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -839,7 +3725,7 @@ func file_proto_watchdog_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_watchdog_proto_rawDesc), len(file_proto_watchdog_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
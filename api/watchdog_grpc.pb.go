@@ -19,12 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WatchdogService_GetHealth_FullMethodName          = "/watchdog.WatchdogService/GetHealth"
-	WatchdogService_RegisterService_FullMethodName    = "/watchdog.WatchdogService/RegisterService"
-	WatchdogService_UnregisterService_FullMethodName  = "/watchdog.WatchdogService/UnregisterService"
-	WatchdogService_ListServices_FullMethodName       = "/watchdog.WatchdogService/ListServices"
-	WatchdogService_UpdateService_FullMethodName      = "/watchdog.WatchdogService/UpdateService"
-	WatchdogService_CheckServiceHealth_FullMethodName = "/watchdog.WatchdogService/CheckServiceHealth"
+	WatchdogService_GetHealth_FullMethodName             = "/watchdog.WatchdogService/GetHealth"
+	WatchdogService_RegisterService_FullMethodName       = "/watchdog.WatchdogService/RegisterService"
+	WatchdogService_UnregisterService_FullMethodName     = "/watchdog.WatchdogService/UnregisterService"
+	WatchdogService_ListServices_FullMethodName          = "/watchdog.WatchdogService/ListServices"
+	WatchdogService_UpdateService_FullMethodName         = "/watchdog.WatchdogService/UpdateService"
+	WatchdogService_CheckServiceHealth_FullMethodName    = "/watchdog.WatchdogService/CheckServiceHealth"
+	WatchdogService_GetService_FullMethodName            = "/watchdog.WatchdogService/GetService"
+	WatchdogService_ListCheckResults_FullMethodName      = "/watchdog.WatchdogService/ListCheckResults"
+	WatchdogService_WatchServices_FullMethodName         = "/watchdog.WatchdogService/WatchServices"
+	WatchdogService_Heartbeat_FullMethodName             = "/watchdog.WatchdogService/Heartbeat"
+	WatchdogService_AgentConnect_FullMethodName          = "/watchdog.WatchdogService/AgentConnect"
+	WatchdogService_CreateNamespace_FullMethodName       = "/watchdog.WatchdogService/CreateNamespace"
+	WatchdogService_ListNamespaces_FullMethodName        = "/watchdog.WatchdogService/ListNamespaces"
+	WatchdogService_UpdateNamespace_FullMethodName       = "/watchdog.WatchdogService/UpdateNamespace"
+	WatchdogService_DeleteNamespace_FullMethodName       = "/watchdog.WatchdogService/DeleteNamespace"
+	WatchdogService_ListAuditEvents_FullMethodName       = "/watchdog.WatchdogService/ListAuditEvents"
+	WatchdogService_VerifyAuditLog_FullMethodName        = "/watchdog.WatchdogService/VerifyAuditLog"
+	WatchdogService_CreateIncident_FullMethodName        = "/watchdog.WatchdogService/CreateIncident"
+	WatchdogService_PostIncidentUpdate_FullMethodName    = "/watchdog.WatchdogService/PostIncidentUpdate"
+	WatchdogService_ListIncidents_FullMethodName         = "/watchdog.WatchdogService/ListIncidents"
+	WatchdogService_ListShards_FullMethodName            = "/watchdog.WatchdogService/ListShards"
+	WatchdogService_ListFederatedServices_FullMethodName = "/watchdog.WatchdogService/ListFederatedServices"
 )
 
 // WatchdogServiceClient is the client API for WatchdogService service.
@@ -37,6 +53,37 @@ type WatchdogServiceClient interface {
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
 	UpdateService(ctx context.Context, in *UpdateServiceRequest, opts ...grpc.CallOption) (*UpdateServiceResponse, error)
 	CheckServiceHealth(ctx context.Context, in *CheckServiceHealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*ServiceInfo, error)
+	ListCheckResults(ctx context.Context, in *ListCheckResultsRequest, opts ...grpc.CallOption) (*ListCheckResultsResponse, error)
+	// Streams the service list, once on subscribe and again whenever a
+	// service or its latest check changes
+	WatchServices(ctx context.Context, in *WatchServicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListServicesResponse], error)
+	// Records that a self-registered service is alive and whether it is ready
+	// to serve. Sent periodically by the service itself.
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	// Remote probe agents connect outbound, announce themselves and run the
+	// checks of services labeled agent=<agent name>. Requires the agent's token
+	// from AGENT_TOKENS, bound to its name, or the admin token.
+	AgentConnect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AgentMessage, AgentCheck], error)
+	// Namespace management. Create, update and delete require an admin token.
+	CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error)
+	ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	UpdateNamespace(ctx context.Context, in *UpdateNamespaceRequest, opts ...grpc.CallOption) (*UpdateNamespaceResponse, error)
+	DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error)
+	// Audit log of service mutations. Verification requires an admin token.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error)
+	// Incidents published on the status page. Creating and posting updates
+	// requires an admin token.
+	CreateIncident(ctx context.Context, in *CreateIncidentRequest, opts ...grpc.CallOption) (*Incident, error)
+	PostIncidentUpdate(ctx context.Context, in *PostIncidentUpdateRequest, opts ...grpc.CallOption) (*Incident, error)
+	ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error)
+	// Live replicas and the services each one checks when sharding is
+	// enabled. Requires an admin token.
+	ListShards(ctx context.Context, in *ListShardsRequest, opts ...grpc.CallOption) (*ListShardsResponse, error)
+	// Services of this watchdog and of its federated child clusters, with
+	// IDs prefixed by the cluster name. Requires an admin token.
+	ListFederatedServices(ctx context.Context, in *ListFederatedServicesRequest, opts ...grpc.CallOption) (*ListFederatedServicesResponse, error)
 }
 
 type watchdogServiceClient struct {
@@ -107,6 +154,178 @@ func (c *watchdogServiceClient) CheckServiceHealth(ctx context.Context, in *Chec
 	return out, nil
 }
 
+func (c *watchdogServiceClient) GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*ServiceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceInfo)
+	err := c.cc.Invoke(ctx, WatchdogService_GetService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) ListCheckResults(ctx context.Context, in *ListCheckResultsRequest, opts ...grpc.CallOption) (*ListCheckResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCheckResultsResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_ListCheckResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) WatchServices(ctx context.Context, in *WatchServicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListServicesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WatchdogService_ServiceDesc.Streams[0], WatchdogService_WatchServices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchServicesRequest, ListServicesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WatchdogService_WatchServicesClient = grpc.ServerStreamingClient[ListServicesResponse]
+
+func (c *watchdogServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) AgentConnect(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AgentMessage, AgentCheck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WatchdogService_ServiceDesc.Streams[1], WatchdogService_AgentConnect_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AgentMessage, AgentCheck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WatchdogService_AgentConnectClient = grpc.BidiStreamingClient[AgentMessage, AgentCheck]
+
+func (c *watchdogServiceClient) CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateNamespaceResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_CreateNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_ListNamespaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) UpdateNamespace(ctx context.Context, in *UpdateNamespaceRequest, opts ...grpc.CallOption) (*UpdateNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNamespaceResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_UpdateNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*DeleteNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNamespaceResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_DeleteNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAuditLogResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_VerifyAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) CreateIncident(ctx context.Context, in *CreateIncidentRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, WatchdogService_CreateIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) PostIncidentUpdate(ctx context.Context, in *PostIncidentUpdateRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, WatchdogService_PostIncidentUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncidentsResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_ListIncidents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) ListShards(ctx context.Context, in *ListShardsRequest, opts ...grpc.CallOption) (*ListShardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShardsResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_ListShards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchdogServiceClient) ListFederatedServices(ctx context.Context, in *ListFederatedServicesRequest, opts ...grpc.CallOption) (*ListFederatedServicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFederatedServicesResponse)
+	err := c.cc.Invoke(ctx, WatchdogService_ListFederatedServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WatchdogServiceServer is the server API for WatchdogService service.
 // All implementations must embed UnimplementedWatchdogServiceServer
 // for forward compatibility.
@@ -117,6 +336,37 @@ type WatchdogServiceServer interface {
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
 	UpdateService(context.Context, *UpdateServiceRequest) (*UpdateServiceResponse, error)
 	CheckServiceHealth(context.Context, *CheckServiceHealthRequest) (*HealthResponse, error)
+	GetService(context.Context, *GetServiceRequest) (*ServiceInfo, error)
+	ListCheckResults(context.Context, *ListCheckResultsRequest) (*ListCheckResultsResponse, error)
+	// Streams the service list, once on subscribe and again whenever a
+	// service or its latest check changes
+	WatchServices(*WatchServicesRequest, grpc.ServerStreamingServer[ListServicesResponse]) error
+	// Records that a self-registered service is alive and whether it is ready
+	// to serve. Sent periodically by the service itself.
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// Remote probe agents connect outbound, announce themselves and run the
+	// checks of services labeled agent=<agent name>. Requires the agent's token
+	// from AGENT_TOKENS, bound to its name, or the admin token.
+	AgentConnect(grpc.BidiStreamingServer[AgentMessage, AgentCheck]) error
+	// Namespace management. Create, update and delete require an admin token.
+	CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error)
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	UpdateNamespace(context.Context, *UpdateNamespaceRequest) (*UpdateNamespaceResponse, error)
+	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*DeleteNamespaceResponse, error)
+	// Audit log of service mutations. Verification requires an admin token.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error)
+	// Incidents published on the status page. Creating and posting updates
+	// requires an admin token.
+	CreateIncident(context.Context, *CreateIncidentRequest) (*Incident, error)
+	PostIncidentUpdate(context.Context, *PostIncidentUpdateRequest) (*Incident, error)
+	ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error)
+	// Live replicas and the services each one checks when sharding is
+	// enabled. Requires an admin token.
+	ListShards(context.Context, *ListShardsRequest) (*ListShardsResponse, error)
+	// Services of this watchdog and of its federated child clusters, with
+	// IDs prefixed by the cluster name. Requires an admin token.
+	ListFederatedServices(context.Context, *ListFederatedServicesRequest) (*ListFederatedServicesResponse, error)
 	mustEmbedUnimplementedWatchdogServiceServer()
 }
 
@@ -145,6 +395,54 @@ func (UnimplementedWatchdogServiceServer) UpdateService(context.Context, *Update
 func (UnimplementedWatchdogServiceServer) CheckServiceHealth(context.Context, *CheckServiceHealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckServiceHealth not implemented")
 }
+func (UnimplementedWatchdogServiceServer) GetService(context.Context, *GetServiceRequest) (*ServiceInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetService not implemented")
+}
+func (UnimplementedWatchdogServiceServer) ListCheckResults(context.Context, *ListCheckResultsRequest) (*ListCheckResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCheckResults not implemented")
+}
+func (UnimplementedWatchdogServiceServer) WatchServices(*WatchServicesRequest, grpc.ServerStreamingServer[ListServicesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchServices not implemented")
+}
+func (UnimplementedWatchdogServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedWatchdogServiceServer) AgentConnect(grpc.BidiStreamingServer[AgentMessage, AgentCheck]) error {
+	return status.Errorf(codes.Unimplemented, "method AgentConnect not implemented")
+}
+func (UnimplementedWatchdogServiceServer) CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNamespace not implemented")
+}
+func (UnimplementedWatchdogServiceServer) ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNamespaces not implemented")
+}
+func (UnimplementedWatchdogServiceServer) UpdateNamespace(context.Context, *UpdateNamespaceRequest) (*UpdateNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNamespace not implemented")
+}
+func (UnimplementedWatchdogServiceServer) DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*DeleteNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNamespace not implemented")
+}
+func (UnimplementedWatchdogServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedWatchdogServiceServer) VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditLog not implemented")
+}
+func (UnimplementedWatchdogServiceServer) CreateIncident(context.Context, *CreateIncidentRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIncident not implemented")
+}
+func (UnimplementedWatchdogServiceServer) PostIncidentUpdate(context.Context, *PostIncidentUpdateRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostIncidentUpdate not implemented")
+}
+func (UnimplementedWatchdogServiceServer) ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncidents not implemented")
+}
+func (UnimplementedWatchdogServiceServer) ListShards(context.Context, *ListShardsRequest) (*ListShardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShards not implemented")
+}
+func (UnimplementedWatchdogServiceServer) ListFederatedServices(context.Context, *ListFederatedServicesRequest) (*ListFederatedServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederatedServices not implemented")
+}
 func (UnimplementedWatchdogServiceServer) mustEmbedUnimplementedWatchdogServiceServer() {}
 func (UnimplementedWatchdogServiceServer) testEmbeddedByValue()                         {}
 
@@ -274,6 +572,276 @@ func _WatchdogService_CheckServiceHealth_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WatchdogService_GetService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).GetService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_GetService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).GetService(ctx, req.(*GetServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_ListCheckResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCheckResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).ListCheckResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_ListCheckResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).ListCheckResults(ctx, req.(*ListCheckResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_WatchServices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchServicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WatchdogServiceServer).WatchServices(m, &grpc.GenericServerStream[WatchServicesRequest, ListServicesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WatchdogService_WatchServicesServer = grpc.ServerStreamingServer[ListServicesResponse]
+
+func _WatchdogService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_AgentConnect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WatchdogServiceServer).AgentConnect(&grpc.GenericServerStream[AgentMessage, AgentCheck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WatchdogService_AgentConnectServer = grpc.BidiStreamingServer[AgentMessage, AgentCheck]
+
+func _WatchdogService_CreateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).CreateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_CreateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).CreateNamespace(ctx, req.(*CreateNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_ListNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_UpdateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).UpdateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_UpdateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).UpdateNamespace(ctx, req.(*UpdateNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_DeleteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).DeleteNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_DeleteNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).DeleteNamespace(ctx, req.(*DeleteNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_VerifyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).VerifyAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_VerifyAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).VerifyAuditLog(ctx, req.(*VerifyAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_CreateIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).CreateIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_CreateIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).CreateIncident(ctx, req.(*CreateIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_PostIncidentUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostIncidentUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).PostIncidentUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_PostIncidentUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).PostIncidentUpdate(ctx, req.(*PostIncidentUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_ListIncidents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncidentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).ListIncidents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_ListIncidents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).ListIncidents(ctx, req.(*ListIncidentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_ListShards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).ListShards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_ListShards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).ListShards(ctx, req.(*ListShardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchdogService_ListFederatedServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederatedServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchdogServiceServer).ListFederatedServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchdogService_ListFederatedServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchdogServiceServer).ListFederatedServices(ctx, req.(*ListFederatedServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WatchdogService_ServiceDesc is the grpc.ServiceDesc for WatchdogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,7 +873,75 @@ var WatchdogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckServiceHealth",
 			Handler:    _WatchdogService_CheckServiceHealth_Handler,
 		},
+		{
+			MethodName: "GetService",
+			Handler:    _WatchdogService_GetService_Handler,
+		},
+		{
+			MethodName: "ListCheckResults",
+			Handler:    _WatchdogService_ListCheckResults_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _WatchdogService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "CreateNamespace",
+			Handler:    _WatchdogService_CreateNamespace_Handler,
+		},
+		{
+			MethodName: "ListNamespaces",
+			Handler:    _WatchdogService_ListNamespaces_Handler,
+		},
+		{
+			MethodName: "UpdateNamespace",
+			Handler:    _WatchdogService_UpdateNamespace_Handler,
+		},
+		{
+			MethodName: "DeleteNamespace",
+			Handler:    _WatchdogService_DeleteNamespace_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _WatchdogService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "VerifyAuditLog",
+			Handler:    _WatchdogService_VerifyAuditLog_Handler,
+		},
+		{
+			MethodName: "CreateIncident",
+			Handler:    _WatchdogService_CreateIncident_Handler,
+		},
+		{
+			MethodName: "PostIncidentUpdate",
+			Handler:    _WatchdogService_PostIncidentUpdate_Handler,
+		},
+		{
+			MethodName: "ListIncidents",
+			Handler:    _WatchdogService_ListIncidents_Handler,
+		},
+		{
+			MethodName: "ListShards",
+			Handler:    _WatchdogService_ListShards_Handler,
+		},
+		{
+			MethodName: "ListFederatedServices",
+			Handler:    _WatchdogService_ListFederatedServices_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchServices",
+			Handler:       _WatchdogService_WatchServices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AgentConnect",
+			Handler:       _WatchdogService_AgentConnect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/watchdog.proto",
 }
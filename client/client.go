@@ -0,0 +1,278 @@
+// Package client is a Go client for the watchdog service. It wraps the
+// generated gRPC client with plain Go types, bearer tokens, default
+// timeouts and retries of idempotent calls on Unavailable.
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+)
+
+// Client calls a watchdog server
+type Client struct {
+	conn      *grpc.ClientConn
+	api       api.WatchdogServiceClient
+	namespace string
+}
+
+// New creates a client for the server at address, such as
+// "watchdog.internal:50051". The connection is established lazily.
+func New(address string, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	creds := insecure.NewCredentials()
+	if o.tls != nil {
+		creds = credentials.NewTLS(o.tls)
+	}
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(timeoutInterceptor(o.timeout), retryInterceptor(o.retry)),
+	}
+	if o.token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(tokenCredentials{token: o.token, plaintext: o.plaintextToken}))
+	}
+	dialOptions = append(dialOptions, o.dialOptions...)
+
+	conn, err := grpc.NewClient(address, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, api: api.NewWatchdogServiceClient(conn), namespace: o.namespace}, nil
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// API returns the generated client for the calls not wrapped here, such as
+// namespaces, audit events and incidents
+func (c *Client) API() api.WatchdogServiceClient {
+	return c.api
+}
+
+// Health returns the health of the server
+func (c *Client) Health(ctx context.Context) (Health, error) {
+	resp, err := c.api.GetHealth(ctx, &api.HealthRequest{})
+	if err != nil {
+		return Health{}, convertError(err)
+	}
+	return Health{Status: resp.Status, Message: resp.Message, Leader: resp.Leader}, nil
+}
+
+// Register registers a service and returns its ID
+func (c *Client) Register(ctx context.Context, r Registration) (string, error) {
+	serviceType, err := r.Type.proto()
+	if err != nil {
+		return "", err
+	}
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	resp, err := c.api.RegisterService(ctx, &api.RegisterServiceRequest{
+		Name:                 r.Name,
+		Endpoint:             r.Endpoint,
+		Type:                 serviceType,
+		Namespace:            namespace,
+		CheckIntervalSeconds: int32(r.CheckInterval / time.Second),
+		Labels:               r.Labels,
+	})
+	if err != nil {
+		return "", convertError(err)
+	}
+	return resp.ServiceId, nil
+}
+
+// Unregister removes a service
+func (c *Client) Unregister(ctx context.Context, serviceID string) error {
+	_, err := c.api.UnregisterService(ctx, &api.UnregisterServiceRequest{
+		ServiceId: serviceID,
+		Namespace: c.namespace,
+	})
+	return convertError(err)
+}
+
+// List returns the services of the namespace of the client
+func (c *Client) List(ctx context.Context) ([]Service, error) {
+	return c.list(ctx, c.namespace, false)
+}
+
+// ListAll returns the services of every namespace, it needs the admin token
+func (c *Client) ListAll(ctx context.Context) ([]Service, error) {
+	return c.list(ctx, "", true)
+}
+
+func (c *Client) list(ctx context.Context, namespace string, all bool) ([]Service, error) {
+	resp, err := c.api.ListServices(ctx, &api.ListServicesRequest{
+		Namespace:     namespace,
+		AllNamespaces: all,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	services := make([]Service, 0, len(resp.Services))
+	for _, svc := range resp.Services {
+		services = append(services, FromProto(svc))
+	}
+	return services, nil
+}
+
+// Get returns a service
+func (c *Client) Get(ctx context.Context, serviceID string) (Service, error) {
+	resp, err := c.api.GetService(ctx, &api.GetServiceRequest{
+		ServiceId: serviceID,
+		Namespace: c.namespace,
+	})
+	if err != nil {
+		return Service{}, convertError(err)
+	}
+	return FromProto(resp), nil
+}
+
+// Update changes the fields of a service set in u
+func (c *Client) Update(ctx context.Context, serviceID string, u Update) error {
+	serviceType, err := u.Type.proto()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.api.UpdateService(ctx, &api.UpdateServiceRequest{
+		ServiceId:            serviceID,
+		Namespace:            c.namespace,
+		Status:               u.Status,
+		Name:                 u.Name,
+		Endpoint:             u.Endpoint,
+		Type:                 serviceType,
+		CheckIntervalSeconds: int32(u.CheckInterval / time.Second),
+		Labels:               u.Labels,
+		ClearLabels:          u.ClearLabels,
+	})
+	return convertError(err)
+}
+
+// Check runs the health check of a service now
+func (c *Client) Check(ctx context.Context, serviceID string) (Health, error) {
+	resp, err := c.api.CheckServiceHealth(ctx, &api.CheckServiceHealthRequest{
+		ServiceId: serviceID,
+		Namespace: c.namespace,
+	})
+	if err != nil {
+		return Health{}, convertError(err)
+	}
+	return Health{Status: resp.Status, Message: resp.Message, Leader: resp.Leader}, nil
+}
+
+// Heartbeat reports that a service is alive and whether it is ready, and
+// returns the status of the service afterwards
+func (c *Client) Heartbeat(ctx context.Context, serviceID string, ready bool) (string, error) {
+	resp, err := c.api.Heartbeat(ctx, &api.HeartbeatRequest{
+		ServiceId: serviceID,
+		Namespace: c.namespace,
+		Ready:     ready,
+	})
+	if err != nil {
+		return "", convertError(err)
+	}
+	return resp.Status, nil
+}
+
+// History returns the check results of a service, newest first
+func (c *Client) History(ctx context.Context, serviceID string, opts HistoryOptions) ([]CheckResult, error) {
+	req := &api.ListCheckResultsRequest{
+		ServiceId: serviceID,
+		Namespace: c.namespace,
+		Limit:     int32(opts.Limit),
+	}
+	if !opts.Since.IsZero() {
+		req.Since = opts.Since.Unix()
+	}
+
+	resp, err := c.api.ListCheckResults(ctx, req)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	results := make([]CheckResult, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		results = append(results, CheckResult{
+			Status:    result.Status,
+			Message:   result.Message,
+			Latency:   time.Duration(result.LatencyMs) * time.Millisecond,
+			CheckedAt: unixTime(result.CheckedAt),
+		})
+	}
+	return results, nil
+}
+
+// timeoutInterceptor applies the default deadline to calls without one
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// idempotentMethods can be repeated without changing the outcome. A call
+// failing with Unavailable may still have been applied, so only these are
+// retried: a repeated registration would register the service twice.
+var idempotentMethods = map[string]bool{
+	api.WatchdogService_GetHealth_FullMethodName:             true,
+	api.WatchdogService_ListServices_FullMethodName:          true,
+	api.WatchdogService_UpdateService_FullMethodName:         true,
+	api.WatchdogService_GetService_FullMethodName:            true,
+	api.WatchdogService_ListCheckResults_FullMethodName:      true,
+	api.WatchdogService_Heartbeat_FullMethodName:             true,
+	api.WatchdogService_ListNamespaces_FullMethodName:        true,
+	api.WatchdogService_UpdateNamespace_FullMethodName:       true,
+	api.WatchdogService_ListAuditEvents_FullMethodName:       true,
+	api.WatchdogService_VerifyAuditLog_FullMethodName:        true,
+	api.WatchdogService_ListIncidents_FullMethodName:         true,
+	api.WatchdogService_ListShards_FullMethodName:            true,
+	api.WatchdogService_ListFederatedServices_FullMethodName: true,
+}
+
+// retryInterceptor retries idempotent calls failing with Unavailable with
+// exponential backoff and jitter
+func retryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !idempotentMethods[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		backoff := policy.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= policy.MaxAttempts {
+				return err
+			}
+
+			// Full jitter spreads the retries of many clients after an outage
+			delay := time.Duration(rand.Int64N(int64(backoff) + 1))
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+			backoff = min(backoff*2, policy.MaxBackoff)
+		}
+	}
+}
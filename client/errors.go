@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound matches errors for services or namespaces that do not exist
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists matches errors for registrations that already exist
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+// Error is returned by every call that failed on the server. It matches
+// ErrNotFound and ErrAlreadyExists with errors.Is.
+type Error struct {
+	Code    codes.Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Code.String() + ": " + e.Message
+}
+
+// Is reports whether the error has the code of a sentinel error
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == codes.NotFound
+	case ErrAlreadyExists:
+		return e.Code == codes.AlreadyExists
+	}
+	return false
+}
+
+// GRPCStatus keeps status.Code working on client errors
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// convertError turns a gRPC status into an Error, other errors such as
+// context cancellation are returned as they are
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &Error{Code: s.Code(), Message: s.Message()}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// EnsureRegistered registers a service unless one with the same name and
+// endpoint exists in the namespace, in which case its type, check interval
+// and labels are updated to match. It is meant to be called on every start.
+func (c *Client) EnsureRegistered(ctx context.Context, r Registration) (Service, error) {
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = c.namespace
+	}
+	scoped := *c
+	scoped.namespace = namespace
+
+	existing, err := scoped.find(ctx, r.Name, r.Endpoint)
+	if err != nil {
+		return Service{}, err
+	}
+	if existing == nil {
+		id, err := scoped.Register(ctx, r)
+		if err == nil {
+			return scoped.Get(ctx, id)
+		}
+		if !errors.Is(err, ErrAlreadyExists) {
+			return Service{}, err
+		}
+
+		// Registered concurrently by another instance
+		if existing, err = scoped.find(ctx, r.Name, r.Endpoint); err != nil {
+			return Service{}, err
+		}
+		if existing == nil {
+			return Service{}, fmt.Errorf("service %q was registered concurrently but cannot be found", r.Name)
+		}
+	}
+
+	var update Update
+	changed := false
+	if r.Type != "" && r.Type != existing.Type {
+		update.Type, changed = r.Type, true
+	}
+	if r.CheckInterval > 0 && r.CheckInterval != existing.CheckInterval {
+		update.CheckInterval, changed = r.CheckInterval, true
+	}
+	if !maps.Equal(r.Labels, existing.Labels) {
+		update.Labels, update.ClearLabels, changed = r.Labels, len(r.Labels) == 0, true
+	}
+	if !changed {
+		return *existing, nil
+	}
+
+	if err := scoped.Update(ctx, existing.ID, update); err != nil {
+		return Service{}, err
+	}
+	return scoped.Get(ctx, existing.ID)
+}
+
+// find returns the service with a name and endpoint, nil when there is none
+func (c *Client) find(ctx context.Context, name, endpoint string) (*Service, error) {
+	services, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		if svc.Name == name && svc.Endpoint == endpoint {
+			return &svc, nil
+		}
+	}
+	return nil, nil
+}
+
+// WaitUntilHealthy runs the health check of a service every interval until
+// it passes or ctx is done. The last health is returned with the error.
+func (c *Client) WaitUntilHealthy(ctx context.Context, serviceID string, interval time.Duration) (Health, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		health, err := c.Check(ctx, serviceID)
+		if err != nil {
+			return health, err
+		}
+		if health.Healthy() {
+			return health, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return health, fmt.Errorf("service %s is not healthy: %s: %w", serviceID, health.Message, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
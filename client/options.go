@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Option configures a Client
+type Option func(*options)
+
+type options struct {
+	tls            *tls.Config
+	token          string
+	plaintextToken bool
+	namespace      string
+	timeout        time.Duration
+	retry          RetryPolicy
+	dialOptions    []grpc.DialOption
+}
+
+// RetryPolicy controls how idempotent calls failing with Unavailable are
+// retried. The delay starts at InitialBackoff and doubles up to MaxBackoff.
+// Zero fields take their value from DefaultRetryPolicy.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy makes up to 5 attempts over roughly 3 seconds
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// withDefaults fills the zero fields of the policy from DefaultRetryPolicy,
+// a zero backoff would retry without any delay
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryPolicy.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	return p
+}
+
+func defaultOptions() options {
+	return options{
+		timeout: 10 * time.Second,
+		retry:   DefaultRetryPolicy,
+	}
+}
+
+// WithTLS connects over TLS, the connection is plaintext otherwise
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		if config == nil {
+			config = &tls.Config{}
+		}
+		o.tls = config
+	}
+}
+
+// WithToken sends a token with every call, an API token or the admin token.
+// Calls fail unless the connection uses TLS or WithPlaintextToken is set.
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithPlaintextToken allows sending the token over a connection without
+// TLS, e.g. to a server on localhost or behind a service mesh
+func WithPlaintextToken() Option {
+	return func(o *options) { o.plaintextToken = true }
+}
+
+// WithNamespace sets the namespace services are registered and looked up in
+func WithNamespace(namespace string) Option {
+	return func(o *options) { o.namespace = namespace }
+}
+
+// WithTimeout sets the deadline of calls whose context has none, 0 disables it
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetry replaces the retry policy, MaxAttempts of 1 disables retries
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) { o.retry = policy.withDefaults() }
+}
+
+// WithDialOptions passes extra options to grpc.NewClient
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+// tokenCredentials attaches the token as a bearer token. Transport security
+// is required unless the caller allowed plaintext.
+type tokenCredentials struct {
+	token     string
+	plaintext bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return !t.plaintext
+}
+
+var _ credentials.PerRPCCredentials = tokenCredentials{}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithRetryDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   RetryPolicy
+	}{
+		{name: "zero", policy: RetryPolicy{}, want: DefaultRetryPolicy},
+		{
+			name:   "attempts only",
+			policy: RetryPolicy{MaxAttempts: 3},
+			want:   RetryPolicy{MaxAttempts: 3, InitialBackoff: DefaultRetryPolicy.InitialBackoff, MaxBackoff: DefaultRetryPolicy.MaxBackoff},
+		},
+		{
+			name:   "no retries",
+			policy: RetryPolicy{MaxAttempts: 1},
+			want:   RetryPolicy{MaxAttempts: 1, InitialBackoff: DefaultRetryPolicy.InitialBackoff, MaxBackoff: DefaultRetryPolicy.MaxBackoff},
+		},
+		{
+			name:   "negative backoff",
+			policy: RetryPolicy{MaxAttempts: 2, InitialBackoff: -time.Second, MaxBackoff: time.Second},
+			want:   RetryPolicy{MaxAttempts: 2, InitialBackoff: DefaultRetryPolicy.InitialBackoff, MaxBackoff: time.Second},
+		},
+		{
+			name:   "complete",
+			policy: RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+			want:   RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := defaultOptions()
+			WithRetry(tt.policy)(&o)
+			if o.retry != tt.want {
+				t.Errorf("retry policy = %+v, want %+v", o.retry, tt.want)
+			}
+		})
+	}
+}
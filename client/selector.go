@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selector matches services on name, namespace, type, status and labels
+type Selector []requirement
+
+type requirement struct {
+	key    string
+	value  string
+	negate bool
+}
+
+// ParseSelector parses comma-separated key=value and key!=value terms, e.g.
+// "type=http,env=prod" or "env!=dev". Keys other than name, namespace,
+// type and status are labels.
+func ParseSelector(text string) (Selector, error) {
+	var sel Selector
+	for _, term := range strings.Split(text, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		r := requirement{}
+		key, value, found := strings.Cut(term, "!=")
+		if found {
+			r.negate = true
+		} else if key, value, found = strings.Cut(term, "="); !found {
+			return nil, fmt.Errorf("invalid selector term %q, expected key=value or key!=value", term)
+		}
+		r.key, r.value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if r.key == "" {
+			return nil, fmt.Errorf("invalid selector term %q, empty key", term)
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// Matches reports whether a service satisfies every term
+func (sel Selector) Matches(svc Service) bool {
+	for _, r := range sel {
+		var actual string
+		switch r.key {
+		case "name":
+			actual = svc.Name
+		case "namespace":
+			actual = svc.Namespace
+		case "type":
+			actual = string(svc.Type)
+		case "status":
+			actual = svc.Status
+		default:
+			actual = svc.Labels[r.key]
+		}
+		if (actual == r.value) == r.negate {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package client
+
+import "testing"
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		text string
+		want Selector
+		err  string
+	}{
+		{text: "", want: nil},
+		{text: "type=http", want: Selector{{key: "type", value: "http"}}},
+		{text: " env != dev ", want: Selector{{key: "env", value: "dev", negate: true}}},
+		{
+			text: "type=http,,env=prod",
+			want: Selector{{key: "type", value: "http"}, {key: "env", value: "prod"}},
+		},
+		{text: "tier=", want: Selector{{key: "tier", value: ""}}},
+		{text: "prod", err: `invalid selector term "prod", expected key=value or key!=value`},
+		{text: "=prod", err: `invalid selector term "=prod", empty key`},
+		{text: "env=prod,!=dev", err: `invalid selector term "!=dev", empty key`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseSelector(tt.text)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("ParseSelector(%q) error = %v, want %q", tt.text, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) error = %v", tt.text, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseSelector(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseSelector(%q) = %+v, want %+v", tt.text, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	svc := Service{
+		Name:      "api",
+		Namespace: "payments",
+		Type:      TypeHTTP,
+		Status:    "active",
+		Labels:    map[string]string{"env": "prod", "tier": "web"},
+	}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "name=api", want: true},
+		{selector: "name=web", want: false},
+		{selector: "namespace=payments", want: true},
+		{selector: "type=http", want: true},
+		{selector: "type=grpc", want: false},
+		{selector: "status=active", want: true},
+		{selector: "status!=draining", want: true},
+		{selector: "env=prod,tier=web", want: true},
+		{selector: "env=prod,tier=db", want: false},
+		{selector: "env!=dev", want: true},
+		{selector: "env!=prod", want: false},
+		{selector: "region=eu", want: false},
+		{selector: "region!=eu", want: true},
+		{selector: "region=", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sel.Matches(svc); got != tt.want {
+				t.Errorf("%q matches = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"watchdog/api"
+	"watchdog/servicestatus"
+)
+
+// ServiceType is the kind of a service, such as "http" or "systemd"
+type ServiceType string
+
+const (
+	TypeHTTP         ServiceType = "http"
+	TypeGRPC         ServiceType = "grpc"
+	TypeDatabase     ServiceType = "database"
+	TypeCache        ServiceType = "cache"
+	TypeQueue        ServiceType = "queue"
+	TypeStorage      ServiceType = "storage"
+	TypeExternalAPI  ServiceType = "external_api"
+	TypeMicroservice ServiceType = "microservice"
+	TypeOther        ServiceType = "other"
+	TypeSystemd      ServiceType = "systemd"
+)
+
+func (t ServiceType) proto() (api.ServiceType, error) {
+	if t == "" {
+		return api.ServiceType_SERVICE_TYPE_UNSPECIFIED, nil
+	}
+	value, ok := api.ServiceType_value["SERVICE_TYPE_"+strings.ToUpper(string(t))]
+	if !ok {
+		return 0, fmt.Errorf("unknown service type %q", t)
+	}
+	return api.ServiceType(value), nil
+}
+
+func serviceType(t api.ServiceType) ServiceType {
+	if t == api.ServiceType_SERVICE_TYPE_UNSPECIFIED {
+		return ""
+	}
+	return ServiceType(strings.ToLower(strings.TrimPrefix(t.String(), "SERVICE_TYPE_")))
+}
+
+// Statuses of a service, see the servicestatus package
+const (
+	StatusActive   = servicestatus.Active
+	StatusNotReady = servicestatus.NotReady
+	StatusDraining = servicestatus.Draining
+)
+
+// Service is a registered service
+type Service struct {
+	ID        string
+	Name      string
+	Endpoint  string
+	Type      ServiceType
+	Namespace string
+	// Status is the administrative status, "active" unless changed
+	Status        string
+	Labels        map[string]string
+	CheckInterval time.Duration
+	LastHeartbeat time.Time
+	// LastCheck is the most recent health check, nil when never checked
+	LastCheck *CheckResult
+}
+
+// Healthy reports whether the service should get traffic: it is active and
+// its latest check passed or it was not checked yet
+func (s Service) Healthy() bool {
+	return Healthy(s.Status, s.LastCheck)
+}
+
+// Healthy applies the rule of Service.Healthy to a service status and its
+// latest check, nil when it was never checked
+func Healthy(status string, lastCheck *CheckResult) bool {
+	if !servicestatus.Routable(status) {
+		return false
+	}
+	return lastCheck == nil || lastCheck.Healthy()
+}
+
+// CheckResult is the outcome of one health check
+type CheckResult struct {
+	Status    string
+	Message   string
+	Latency   time.Duration
+	CheckedAt time.Time
+}
+
+// Healthy reports whether the check passed
+func (r CheckResult) Healthy() bool {
+	return r.Status == "healthy"
+}
+
+// Health is the health of the server or of one service
+type Health struct {
+	Status  string
+	Message string
+	// Leader is the replica running the scheduler, empty without leader
+	// election
+	Leader string
+}
+
+// Healthy reports whether the status is healthy
+func (h Health) Healthy() bool {
+	return h.Status == "healthy"
+}
+
+// Registration describes a service to register
+type Registration struct {
+	Name     string
+	Endpoint string
+	Type     ServiceType
+	// Namespace overrides the namespace of the client
+	Namespace string
+	// CheckInterval of 0 uses the server default
+	CheckInterval time.Duration
+	Labels        map[string]string
+}
+
+// Update changes the fields of a service, zero values are left unchanged
+type Update struct {
+	Status        string
+	Name          string
+	Endpoint      string
+	Type          ServiceType
+	CheckInterval time.Duration
+	// Labels replaces all labels when not empty
+	Labels map[string]string
+	// ClearLabels removes all labels
+	ClearLabels bool
+}
+
+// HistoryOptions limits the check results returned by History
+type HistoryOptions struct {
+	// Since only returns results at or after it, zero means unbounded
+	Since time.Time
+	// Limit of 0 uses the server default of 100
+	Limit int
+}
+
+// FromProto converts a service returned by the generated client, e.g. from
+// WatchServices
+func FromProto(svc *api.ServiceInfo) Service {
+	s := Service{
+		ID:            svc.Id,
+		Name:          svc.Name,
+		Endpoint:      svc.Endpoint,
+		Type:          serviceType(svc.Type),
+		Namespace:     svc.Namespace,
+		Status:        svc.Status,
+		Labels:        svc.Labels,
+		CheckInterval: time.Duration(svc.CheckIntervalSeconds) * time.Second,
+		LastHeartbeat: unixTime(svc.LastHeartbeat),
+	}
+	if svc.LastCheckedAt != 0 {
+		s.LastCheck = &CheckResult{
+			Status:    svc.LastCheckStatus,
+			Latency:   time.Duration(svc.LastCheckLatencyMs) * time.Millisecond,
+			CheckedAt: unixTime(svc.LastCheckedAt),
+		}
+	}
+	return s
+}
+
+func unixTime(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
+
+// HostPort extracts the host and port clients connect to from a service
+// endpoint, e.g. "10.0.0.5:9090", "dns:///api:9090" or
+// "https://api.example.com/healthz"
+func HostPort(endpoint string) (string, error) {
+	hostPort := endpoint
+	if scheme, rest, found := strings.Cut(endpoint, "://"); found {
+		switch scheme {
+		case "http", "https":
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				return "", err
+			}
+			hostPort = u.Host
+			if u.Port() == "" {
+				port := "80"
+				if scheme == "https" {
+					port = "443"
+				}
+				hostPort = net.JoinHostPort(u.Hostname(), port)
+			}
+		default:
+			// gRPC targets such as dns:///host:port
+			hostPort = strings.TrimLeft(rest, "/")
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(hostPort); err != nil {
+		return "", err
+	}
+	return hostPort, nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import "testing"
+
+func TestHealthy(t *testing.T) {
+	passed := &CheckResult{Status: "healthy"}
+	failed := &CheckResult{Status: "unhealthy"}
+
+	tests := []struct {
+		status    string
+		lastCheck *CheckResult
+		want      bool
+	}{
+		{status: StatusActive, lastCheck: nil, want: true},
+		{status: StatusActive, lastCheck: passed, want: true},
+		{status: StatusActive, lastCheck: failed, want: false},
+		{status: StatusActive, lastCheck: &CheckResult{}, want: false},
+		{status: StatusNotReady, lastCheck: passed, want: false},
+		{status: StatusNotReady, lastCheck: nil, want: false},
+		{status: StatusDraining, lastCheck: passed, want: false},
+		{status: "maintenance", lastCheck: passed, want: false},
+		{status: "maintenance", lastCheck: nil, want: false},
+		{status: "", lastCheck: nil, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := Healthy(tt.status, tt.lastCheck); got != tt.want {
+			t.Errorf("Healthy(%q, %+v) = %v, want %v", tt.status, tt.lastCheck, got, tt.want)
+		}
+		if got := (Service{Status: tt.status, LastCheck: tt.lastCheck}).Healthy(); got != tt.want {
+			t.Errorf("Service{Status: %q}.Healthy() = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// XDSCredentials are the channel credentials gRPC xDS clients use to reach
+// the control plane, which requires the admin token or an API token.
+// Register them with bootstrap.RegisterCredentials from
+// google.golang.org/grpc/xds/bootstrap and select them in the bootstrap
+// file with "channel_creds": [{"type": "watchdog"}].
+type XDSCredentials struct {
+	// Token is sent as a bearer token on every discovery stream
+	Token string
+	// TLS connects over TLS, the connection is plaintext when nil
+	TLS *tls.Config
+	// PlaintextToken allows sending the token without TLS
+	PlaintextToken bool
+}
+
+// Name is the channel_creds type of the credentials
+func (c XDSCredentials) Name() string {
+	return "watchdog"
+}
+
+// Build returns the credentials of a connection to the control plane
+func (c XDSCredentials) Build(json.RawMessage) (credentials.Bundle, func(), error) {
+	transport := insecure.NewCredentials()
+	if c.TLS != nil {
+		transport = credentials.NewTLS(c.TLS)
+	}
+
+	bundle := xdsBundle{
+		transport: transport,
+		token:     tokenCredentials{token: c.Token, plaintext: c.PlaintextToken},
+	}
+	return bundle, func() {}, nil
+}
+
+// xdsBundle pairs the transport credentials with the bearer token
+type xdsBundle struct {
+	transport credentials.TransportCredentials
+	token     tokenCredentials
+}
+
+func (b xdsBundle) TransportCredentials() credentials.TransportCredentials {
+	return b.transport
+}
+
+func (b xdsBundle) PerRPCCredentials() credentials.PerRPCCredentials {
+	return b.token
+}
+
+func (b xdsBundle) NewWithMode(mode string) (credentials.Bundle, error) {
+	return nil, errors.New("credential modes are not supported")
+}
@@ -1,74 +1,346 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"watchdog/agent"
 	"watchdog/api"
 	"watchdog/config"
+	"watchdog/dashboard"
+	"watchdog/dnsserver"
+	"watchdog/federation"
+	"watchdog/gateway"
+	"watchdog/health"
+	"watchdog/leader"
+	"watchdog/logging"
+	"watchdog/metrics"
+	"watchdog/promsd"
+	"watchdog/scheduler"
 	"watchdog/server"
+	"watchdog/shard"
+	"watchdog/statuspage"
+	"watchdog/systemd"
+	"watchdog/tracing"
+	"watchdog/xds"
 )
 
+var logger = logging.For("main")
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		runRender(os.Args[2:])
+		return
+	}
+
 	cfg, db, err := config.LoadWithEntClient()
 	if err != nil {
-		log.Fatalf("Failed to load config and connect to database: %v", err)
+		fatal("failed to load config and connect to database", err)
 	}
 	defer func() {
 		if err := db.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
+			logger.Error("failed to close database connection", "error", err)
+		}
+	}()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
 		}
 	}()
 
-	port := fmt.Sprintf(":%d", cfg.Server.Port)
-	lis, err := net.Listen("tcp", port)
+	// Sockets passed by systemd socket activation replace the configured ports
+	sockets, err := systemd.Listeners()
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		fatal("failed to use sockets passed by systemd", err)
 	}
+	passed := systemd.Assign(sockets, "grpc", "http", "status", "dns")
+	passedPackets := systemd.AssignPackets(sockets, "dns")
 
-	s := grpc.NewServer()
+	lis, err := listen(passed, "grpc", cfg.Server.Port)
+	if err != nil {
+		fatal("failed to listen", err)
+	}
+
+	// HTTP calls to the RPC handlers run through the same unary interceptors
+	unaryInterceptors := []grpc.UnaryServerInterceptor{logging.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()}
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), metrics.StreamServerInterceptor()),
+	)
 
-	watchdogServer := server.NewWatchdogServer(db)
+	// Checks of services labeled agent=<name> or locations=<names> run on
+	// remote agents
+	agents := agent.NewHub(cfg.Server.ProbeLocation, cfg.Server.ProbeQuorum)
+
+	// Child watchdogs merged into one view
+	var fed *federation.Federation
+	if len(cfg.Federation.Clusters) > 0 {
+		fed, err = federation.New(federation.Config{
+			Clusters:       cfg.Federation.Clusters,
+			Token:          cfg.Federation.Token,
+			TLS:            cfg.Federation.TLS,
+			PlaintextToken: cfg.Federation.PlaintextToken,
+			PollInterval:   time.Duration(cfg.Federation.PollInterval) * time.Second,
+		})
+		if err != nil {
+			fatal("failed to set up federation", err)
+		}
+		defer fed.Close()
+	}
+
+	watchdogServer := server.NewWatchdogServer(db, cfg.Server, agents, fed, cfg.Federation.Name)
 	api.RegisterWatchdogServiceServer(s, watchdogServer)
 
+	// Periodic and background work stops on shutdown
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Healthy instances of gRPC and HTTP services for xDS clients
+	if cfg.Server.XDSEnabled {
+		control := xds.New(backgroundCtx, db, cfg.Server.XDSGroupLabel, watchdogServer.Authenticated)
+		control.Register(s)
+		go control.Run(backgroundCtx)
+	}
+
+	// Readiness of the process itself for probes and load balancers
+	checker := health.New(db)
+	checker.Register(s)
+
 	reflection.Register(s)
 
+	// Periodic health checks of every service
+	if cfg.Server.SchedulerEnabled {
+		sched := scheduler.New(db, agents.Probe, cfg.Server.SchedulerWorkers)
+		sched.KeepResults(time.Duration(cfg.Server.CheckResultRetention) * time.Hour)
+		checker.WatchScheduler(sched)
+		switch {
+		case cfg.Server.ShardingEnabled:
+			// Replicas sharing the database split the services between them
+			members := shard.NewMembership(db, cfg.Server.InstanceName, time.Duration(cfg.Server.MemberTimeout)*time.Second)
+			sched.UseShard(members)
+			go members.Run(backgroundCtx)
+			go sched.Run(backgroundCtx)
+		case cfg.Server.LeaderElection:
+			// Replicas sharing the database take turns, one runs the scheduler
+			elector := leader.New(db, cfg.Server.InstanceName, time.Duration(cfg.Server.LeaseDuration)*time.Second)
+			go elector.Run(backgroundCtx, sched.Run)
+		default:
+			go sched.Run(backgroundCtx)
+		}
+	}
+	go checker.Run(backgroundCtx)
+	if fed != nil {
+		go fed.Run(backgroundCtx)
+	}
+
+	// HTTP/JSON gateway onto the same RPC handlers
+	var httpServer *http.Server
+	if cfg.Server.HTTPPort != 0 || passed["http"] != nil {
+		caller := server.NewHTTPCaller(unaryInterceptors...)
+		gw := gateway.New(watchdogServer, caller)
+		mux := http.NewServeMux()
+		mux.Handle("/v1/", gw)
+		mux.Handle(gateway.OpenAPIPath, gw)
+		mux.HandleFunc(health.LivezPath, checker.ServeLivez)
+		mux.HandleFunc(health.ReadyzPath, checker.ServeReadyz)
+
+		if cfg.Server.MetricsEnabled {
+			mux.Handle(metrics.Path, metrics.Handler(metrics.NewRegistry(db)))
+		}
+
+		if cfg.Server.PromSDEnabled {
+			mux.Handle(promsd.Path, promsd.Handler(watchdogServer, caller))
+		}
+
+		if cfg.Server.DashboardEnabled {
+			ui, err := dashboard.New(watchdogServer, caller)
+			if err != nil {
+				fatal("failed to create dashboard", err)
+			}
+			mux.Handle(dashboard.Prefix, ui)
+			mux.Handle("/{$}", http.RedirectHandler(dashboard.Prefix, http.StatusFound))
+		}
+
+		// gRPC-Web calls are served by the native gRPC server
+		var handler http.Handler = mux
+		if cfg.Server.GRPCWebEnabled {
+			handler = gateway.GRPCWeb(s, cfg.Server.GRPCWebAllowedOrigins, mux)
+		}
+
+		httpListener, err := listen(passed, "http", cfg.Server.HTTPPort)
+		if err != nil {
+			fatal("failed to listen for HTTP", err)
+		}
+
+		httpServer = &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			logger.Info("HTTP gateway listening", "address", httpListener.Addr().String())
+			if err := httpServer.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fatal("failed to serve HTTP", err)
+			}
+		}()
+	}
+
+	// Public status page on its own listener, sharing nothing with the API
+	var statusServer *http.Server
+	if cfg.StatusPage.Port != 0 || passed["status"] != nil {
+		page, err := statuspage.New(db, cfg.StatusPage)
+		if err != nil {
+			fatal("failed to create status page", err)
+		}
+
+		statusListener, err := listen(passed, "status", cfg.StatusPage.Port)
+		if err != nil {
+			fatal("failed to listen for the status page", err)
+		}
+
+		statusServer = &http.Server{
+			Handler:           page,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			logger.Info("status page listening", "address", statusListener.Addr().String())
+			if err := statusServer.Serve(statusListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fatal("failed to serve status page", err)
+			}
+		}()
+	}
+
+	// DNS answers with the instances of services, for applications without
+	// a client library
+	if cfg.DNS.Port != 0 || passed["dns"] != nil || passedPackets["dns"] != nil {
+		forwardNetworks, err := dnsserver.ParseNetworks(cfg.DNS.ForwardNetworks)
+		if err != nil {
+			fatal("invalid DNS_FORWARD_NETWORKS", err)
+		}
+		dnsServer := dnsserver.New(db, dnsserver.Config{
+			Domain:           cfg.DNS.Domain,
+			TTL:              time.Duration(cfg.DNS.TTL) * time.Second,
+			NegativeTTL:      time.Duration(cfg.DNS.NegativeTTL) * time.Second,
+			IncludeUnhealthy: cfg.DNS.IncludeUnhealthy,
+			Upstream:         cfg.DNS.Upstream,
+			ForwardNetworks:  forwardNetworks,
+		})
+
+		address := net.JoinHostPort(cfg.DNS.Address, strconv.Itoa(cfg.DNS.Port))
+		udp := passedPackets["dns"]
+		if udp == nil {
+			udp, err = net.ListenPacket("udp", address)
+			if err != nil {
+				fatal("failed to listen for DNS over UDP", err)
+			}
+		}
+		tcp := passed["dns"]
+		if tcp == nil {
+			tcp, err = net.Listen("tcp", address)
+			if err != nil {
+				fatal("failed to listen for DNS over TCP", err)
+			}
+		}
+
+		go dnsServer.Run(backgroundCtx)
+		go func() {
+			logger.Info("DNS server listening", "address", tcp.Addr().String(), "domain", cfg.DNS.Domain)
+			if err := dnsServer.Serve(backgroundCtx, udp, tcp); err != nil {
+				fatal("failed to serve DNS", err)
+			}
+		}()
+	}
+
 	// Check if running in service mode (non-interactive)
 	isService := os.Getenv("WATCHDOG_SERVICE_MODE") == "1" || !isTerminal()
 
 	go func() {
-		fmt.Printf("gRPC server listening at %v\n", lis.Addr())
-		fmt.Println("Database configuration:")
-		fmt.Printf("  Host: %s:%d\n", cfg.Database.Host, cfg.Database.Port)
-		fmt.Printf("  Database: %s\n", cfg.Database.Database)
-		fmt.Printf("  Username: %s\n", cfg.Database.Username)
+		logger.Info("gRPC server listening",
+			"address", lis.Addr().String(),
+			"db_host", fmt.Sprintf("%s:%d", cfg.Database.Host, cfg.Database.Port),
+			"db_name", cfg.Database.Database,
+			"db_user", cfg.Database.Username)
 
 		if err := s.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve: %v", err)
+			fatal("failed to serve gRPC", err)
 		}
 	}()
 
 	if isService {
-		// Service mode: run indefinitely
-		fmt.Println("Running in service mode - server will continue running")
-		select {} // Block forever
-	} else {
-		// Interactive mode: wait for signals
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-		<-c
-		fmt.Println("\nShutting down gRPC server...")
-		s.GracefulStop()
-		fmt.Println("Server stopped")
+		logger.Info("running in service mode")
 	}
+
+	// Every listener is open and migrations ran while loading the config
+	if sent, err := systemd.Notify(systemd.Ready); err != nil {
+		logger.Warn("failed to notify systemd", "error", err)
+	} else if sent {
+		logger.Info("notified systemd of readiness")
+	}
+	go systemd.RunWatchdog(backgroundCtx, checker.Alive)
+
+	// Wait for signals
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+
+	<-c
+	logger.Info("shutting down")
+	if _, err := systemd.Notify(systemd.Stopping); err != nil {
+		logger.Warn("failed to notify systemd", "error", err)
+	}
+	checker.Shutdown()
+	if cfg.Server.ShutdownDelay > 0 {
+		time.Sleep(time.Duration(cfg.Server.ShutdownDelay) * time.Second)
+	}
+	stopBackground()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if httpServer != nil {
+		if err := httpServer.Shutdown(ctx); err != nil {
+			logger.Error("failed to shut down HTTP server", "error", err)
+		}
+	}
+	if statusServer != nil {
+		if err := statusServer.Shutdown(ctx); err != nil {
+			logger.Error("failed to shut down status page", "error", err)
+		}
+	}
+	cancel()
+	s.GracefulStop()
+	logger.Info("server stopped")
+}
+
+// listen uses the socket systemd passed for role, or listens on port
+func listen(passed map[string]net.Listener, role string, port int) (net.Listener, error) {
+	if listener := passed[role]; listener != nil {
+		return listener, nil
+	}
+	return net.Listen("tcp", fmt.Sprintf(":%d", port))
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
 }
 
 // isTerminal checks if the process is running in a terminal
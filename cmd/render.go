@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"watchdog/api"
+	"watchdog/client"
+	"watchdog/logging"
+	"watchdog/render"
+)
+
+const (
+	// renderRetryBackoff is the first delay before watching again after the
+	// stream failed, it doubles up to renderMaxBackoff
+	renderRetryBackoff = time.Second
+	renderMaxBackoff   = 30 * time.Second
+)
+
+// runRender runs watchdog render, which keeps config files rendered from
+// templates in sync with the registry of a watchdog server
+func runRender(args []string) {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: watchdog render --template SOURCE --destination FILE [--command CMD] ... [flags]")
+		flags.PrintDefaults()
+	}
+
+	var templates []render.Template
+	last := func(name string) (*render.Template, error) {
+		if len(templates) == 0 {
+			return nil, fmt.Errorf("--%s must follow a --template", name)
+		}
+		return &templates[len(templates)-1], nil
+	}
+	flags.Func("template", "template file to render, repeatable", func(value string) error {
+		templates = append(templates, render.Template{Source: value})
+		return nil
+	})
+	flags.Func("destination", "file the last --template renders to", func(value string) error {
+		t, err := last("destination")
+		if err != nil {
+			return err
+		}
+		t.Destination = value
+		return nil
+	})
+	flags.Func("command", "command run through sh -c after the destination of the last --template changed", func(value string) error {
+		t, err := last("command")
+		if err != nil {
+			return err
+		}
+		t.Command = value
+		return nil
+	})
+
+	var (
+		address, token, namespace string
+		useTLS, plaintextToken    bool
+		all, once                 bool
+		wait, maxWait             time.Duration
+		logCfg                    logging.Config
+	)
+	flags.StringVar(&address, "address", envOr("WATCHDOG_ADDRESS", "localhost:50051"), "watchdog server gRPC address")
+	flags.StringVar(&token, "token", os.Getenv("WATCHDOG_TOKEN"), "API token, defaults to $WATCHDOG_TOKEN")
+	flags.StringVar(&namespace, "namespace", os.Getenv("WATCHDOG_NAMESPACE"), "namespace of the services, defaults to $WATCHDOG_NAMESPACE")
+	flags.BoolVar(&all, "all-namespaces", false, "render services of every namespace, requires an admin token")
+	flags.BoolVar(&useTLS, "tls", envBool("WATCHDOG_TLS"), "connect over TLS")
+	flags.BoolVar(&plaintextToken, "plaintext-token", envBool("WATCHDOG_PLAINTEXT_TOKEN"), "send the token without TLS")
+	flags.BoolVar(&once, "once", false, "render once and exit instead of watching")
+	flags.DurationVar(&wait, "wait", 2*time.Second, "quiet period after a change before rendering")
+	flags.DurationVar(&maxWait, "max-wait", 10*time.Second, "longest delay between a change and the render")
+	flags.StringVar(&logCfg.Level, "log-level", envOr("LOG_LEVEL", "info"), "debug, info, warn or error")
+	flags.StringVar(&logCfg.Format, "log-format", envOr("LOG_FORMAT", "text"), "text or json")
+	flags.Parse(args)
+
+	if err := logging.Setup(os.Stderr, logCfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err := validateTemplates(templates, wait, maxWait); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	renderer, err := render.New(templates)
+	if err != nil {
+		fatal("failed to parse templates", err)
+	}
+
+	opts := []client.Option{client.WithToken(token), client.WithNamespace(namespace)}
+	if useTLS {
+		opts = append(opts, client.WithTLS(&tls.Config{}))
+	}
+	if plaintextToken {
+		opts = append(opts, client.WithPlaintextToken())
+	}
+	c, err := client.New(address, opts...)
+	if err != nil {
+		fatal("failed to create client", err)
+	}
+	defer c.Close()
+
+	if once {
+		services, err := listRendered(context.Background(), c, all)
+		if err != nil {
+			fatal("failed to list services", err)
+		}
+		if err := renderer.Render(context.Background(), services); err != nil {
+			fatal("failed to render templates", err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	updates := make(chan []client.Service)
+	go followServices(ctx, c, namespace, all, updates)
+	renderer.Run(ctx, updates, wait, maxWait)
+	logger.Info("render stopped")
+}
+
+// validateTemplates checks the templates and delays given to watchdog render
+func validateTemplates(templates []render.Template, wait, maxWait time.Duration) error {
+	if len(templates) == 0 {
+		return errors.New("at least one --template is required")
+	}
+	for _, t := range templates {
+		if t.Destination == "" {
+			return fmt.Errorf("--template %s has no --destination", t.Source)
+		}
+	}
+	if maxWait < wait {
+		return errors.New("--max-wait must not be shorter than --wait")
+	}
+	return nil
+}
+
+func listRendered(ctx context.Context, c *client.Client, all bool) ([]client.Service, error) {
+	if all {
+		return c.ListAll(ctx)
+	}
+	return c.List(ctx)
+}
+
+// followServices sends the services on every update of WatchServices until
+// ctx is cancelled, watching again with backoff when the stream fails. The
+// rendered files are kept meanwhile.
+func followServices(ctx context.Context, c *client.Client, namespace string, all bool, updates chan<- []client.Service) {
+	backoff := renderRetryBackoff
+	for {
+		received, err := watchServices(ctx, c, namespace, all, updates)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			backoff = renderRetryBackoff
+		}
+		logger.Warn("service watch failed, keeping the rendered files", "error", err, "retry_in", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, renderMaxBackoff)
+	}
+}
+
+// watchServices streams the services once, it reports whether any update
+// was received
+func watchServices(ctx context.Context, c *client.Client, namespace string, all bool, updates chan<- []client.Service) (bool, error) {
+	stream, err := c.API().WatchServices(ctx, &api.WatchServicesRequest{Namespace: namespace, AllNamespaces: all})
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		services := make([]client.Service, len(resp.Services))
+		for i, svc := range resp.Services {
+			services[i] = client.FromProto(svc)
+		}
+		select {
+		case updates <- services:
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envBool(key string) bool {
+	value, _ := strconv.ParseBool(os.Getenv(key))
+	return value
+}
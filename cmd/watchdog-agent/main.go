@@ -0,0 +1,81 @@
+// watchdog-agent runs the health checks of services labeled
+// agent=<name> on the host it runs on, for units and endpoints the watchdog
+// server cannot reach itself. It connects outbound to the server.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"watchdog/agent"
+	"watchdog/logging"
+)
+
+var logger = logging.For("main")
+
+func main() {
+	cfg := agent.Config{}
+	var useTLS bool
+	var logCfg logging.Config
+
+	flag.StringVar(&cfg.Address, "address", envOr("WATCHDOG_ADDRESS", "localhost:50051"), "watchdog server gRPC address")
+	flag.StringVar(&cfg.Token, "token", os.Getenv("WATCHDOG_TOKEN"), "token of this agent from AGENT_TOKENS or the admin token, defaults to $WATCHDOG_TOKEN")
+	flag.StringVar(&cfg.Name, "name", os.Getenv("WATCHDOG_AGENT_NAME"), "agent name matched against the agent label, defaults to the host name")
+	flag.IntVar(&cfg.Concurrency, "concurrency", envInt("WATCHDOG_AGENT_CONCURRENCY", 4), "number of checks run at once")
+	flag.BoolVar(&useTLS, "tls", envBool("WATCHDOG_TLS"), "connect over TLS")
+	flag.BoolVar(&cfg.PlaintextToken, "plaintext-token", envBool("WATCHDOG_PLAINTEXT_TOKEN"), "send the token without TLS")
+	flag.StringVar(&logCfg.Level, "log-level", envOr("LOG_LEVEL", "info"), "debug, info, warn or error")
+	flag.StringVar(&logCfg.Format, "log-format", envOr("LOG_FORMAT", "text"), "text or json")
+	flag.Parse()
+
+	if err := logging.Setup(os.Stderr, logCfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if useTLS {
+		cfg.TLS = &tls.Config{}
+	}
+
+	a, err := agent.New(cfg)
+	if err != nil {
+		fatal("failed to create agent", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := a.Run(ctx); err != nil {
+		fatal("agent stopped", err)
+	}
+	logger.Info("agent stopped")
+}
+
+func fatal(msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envInt(key string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return fallback
+}
+
+func envBool(key string) bool {
+	value, _ := strconv.ParseBool(os.Getenv(key))
+	return value
+}
@@ -0,0 +1,435 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"watchdog/api"
+)
+
+// subcommand picks the action of a command with actions, e.g. "namespace list"
+func subcommand(args []string, actions map[string]func([]string) error) error {
+	if len(args) == 0 || actions[args[0]] == nil {
+		names := make([]string, 0, len(actions))
+		for name := range actions {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return usageError("expected one of: %s", strings.Join(names, ", "))
+	}
+	return actions[args[0]](args[1:])
+}
+
+func runNamespace(env *cmdEnv, args []string) error {
+	return subcommand(args, map[string]func([]string) error{
+		"create": env.namespaceCreate,
+		"list":   env.namespaceList,
+		"update": env.namespaceUpdate,
+		"delete": env.namespaceDelete,
+	})
+}
+
+func (env *cmdEnv) namespaceCreate(args []string) error {
+	description := env.flags.String("description", "", "description")
+	maxServices := env.flags.Int("max-services", 0, "maximum number of services, 0 is unlimited")
+	minInterval := env.flags.Int("min-interval", 0, "smallest check interval in seconds, 0 is no limit")
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.CreateNamespace(ctx, &api.CreateNamespaceRequest{
+		Name:                    rest[0],
+		Description:             *description,
+		MaxServices:             int32(*maxServices),
+		MinCheckIntervalSeconds: int32(*minInterval),
+	})
+	if err != nil {
+		return err
+	}
+	return env.printMessage(resp, resp.Message)
+}
+
+func (env *cmdEnv) namespaceList(args []string) error {
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListNamespaces(ctx, &api.ListNamespacesRequest{})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "NAME\tSERVICES\tMAX SERVICES\tMIN INTERVAL\tDESCRIPTION")
+		for _, ns := range resp.Namespaces {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", ns.Name, ns.ServiceCount,
+				limit(ns.MaxServices, ""), limit(ns.MinCheckIntervalSeconds, "s"), orDash(ns.Description))
+		}
+	})
+}
+
+// limit renders a namespace limit, "-" when unset
+func limit(value int32, unit string) string {
+	if value == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%s", value, unit)
+}
+
+func (env *cmdEnv) namespaceUpdate(args []string) error {
+	description := env.flags.String("description", "", "description")
+	maxServices := env.flags.Int("max-services", 0, "maximum number of services, 0 is unlimited")
+	minInterval := env.flags.Int("min-interval", 0, "smallest check interval in seconds, 0 is no limit")
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+
+	// The update replaces every field, so start from the current values
+	list, err := env.client.ListNamespaces(ctx, &api.ListNamespacesRequest{})
+	if err != nil {
+		return err
+	}
+	var req *api.UpdateNamespaceRequest
+	for _, ns := range list.Namespaces {
+		if ns.Name == rest[0] {
+			req = &api.UpdateNamespaceRequest{
+				Name:                    ns.Name,
+				Description:             ns.Description,
+				MaxServices:             ns.MaxServices,
+				MinCheckIntervalSeconds: ns.MinCheckIntervalSeconds,
+			}
+		}
+	}
+	if req == nil {
+		return &exitErr{code: exitNotFound, err: fmt.Errorf("namespace %s not found", rest[0])}
+	}
+	env.flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "description":
+			req.Description = *description
+		case "max-services":
+			req.MaxServices = int32(*maxServices)
+		case "min-interval":
+			req.MinCheckIntervalSeconds = int32(*minInterval)
+		}
+	})
+
+	resp, err := env.client.UpdateNamespace(ctx, req)
+	if err != nil {
+		return err
+	}
+	return env.printMessage(resp, resp.Message)
+}
+
+func (env *cmdEnv) namespaceDelete(args []string) error {
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.DeleteNamespace(ctx, &api.DeleteNamespaceRequest{Name: rest[0]})
+	if err != nil {
+		return err
+	}
+	return env.printMessage(resp, resp.Message)
+}
+
+func runAudit(env *cmdEnv, args []string) error {
+	return subcommand(args, map[string]func([]string) error{
+		"list":   env.auditList,
+		"verify": env.auditVerify,
+	})
+}
+
+func (env *cmdEnv) auditList(args []string) error {
+	all := env.flags.Bool("all-namespaces", false, "list events of every namespace, requires an admin token")
+	env.flags.BoolVar(all, "A", false, "shorthand for --all-namespaces")
+	serviceID := env.flags.String("service", "", "only events of this service ID")
+	actor := env.flags.String("actor", "", "only events by this actor")
+	since := env.flags.String("since", "", "only events after this duration ago (24h) or RFC 3339 time")
+	until := env.flags.String("until", "", "only events before this duration ago or RFC 3339 time")
+	limit := env.flags.Int("limit", 0, "maximum number of events, 0 uses the server default")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	sinceUnix, err := parseSince(*since)
+	if err != nil {
+		return err
+	}
+	untilUnix, err := parseSince(*until)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListAuditEvents(ctx, &api.ListAuditEventsRequest{
+		Namespace:     env.settings.Namespace,
+		AllNamespaces: *all,
+		ServiceId:     *serviceID,
+		Actor:         *actor,
+		Since:         sinceUnix,
+		Until:         untilUnix,
+		Limit:         int32(*limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "ID\tTIME\tACTION\tENTITY\tNAMESPACE\tACTOR\tCHANGES")
+		for _, event := range resp.Events {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", event.Id, formatTime(event.CreatedAt),
+				event.Action, auditEntity(event), orDash(event.Namespace), event.Actor, orDash(event.ChangesJson))
+		}
+	})
+}
+
+// auditEntity names the entity of an event, e.g. "service 42"
+func auditEntity(event *api.AuditEvent) string {
+	if event.ServiceId != "" {
+		return "service " + event.ServiceId
+	}
+	return strings.ReplaceAll(event.Entity, "_", " ") + " " + event.EntityId
+}
+
+func (env *cmdEnv) auditVerify(args []string) error {
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.VerifyAuditLog(ctx, &api.VerifyAuditLogRequest{})
+	if err != nil {
+		return err
+	}
+
+	err = env.out.print(resp, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "INTACT\tCHECKED\tFIRST BROKEN\tMESSAGE")
+		fmt.Fprintf(w, "%t\t%d\t%s\t%s\n", resp.Intact, resp.CheckedEvents, orDash(resp.FirstBrokenId), resp.Message)
+	})
+	if err == nil && !resp.Intact {
+		return errUnhealthy
+	}
+	return err
+}
+
+// componentsFlag collects repeated --component flags
+type componentsFlag []string
+
+func (c *componentsFlag) String() string { return strings.Join(*c, ",") }
+
+func (c *componentsFlag) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
+func runIncident(env *cmdEnv, args []string) error {
+	return subcommand(args, map[string]func([]string) error{
+		"create": env.incidentCreate,
+		"update": env.incidentUpdate,
+		"list":   env.incidentList,
+	})
+}
+
+func (env *cmdEnv) incidentCreate(args []string) error {
+	title := env.flags.String("title", "", "incident title (required)")
+	status := env.flags.String("status", "", "investigating, identified, monitoring or resolved")
+	impact := env.flags.String("impact", "", "none, minor, major or critical")
+	message := env.flags.String("message", "", "first timeline entry (required)")
+	var components componentsFlag
+	env.flags.Var(&components, "component", "affected status page component, repeatable")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if *title == "" || *message == "" {
+		return usageError("--title and --message are required")
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	incident, err := env.client.CreateIncident(ctx, &api.CreateIncidentRequest{
+		Title:      *title,
+		Status:     *status,
+		Impact:     *impact,
+		Components: components,
+		Message:    *message,
+	})
+	if err != nil {
+		return err
+	}
+	return env.printIncident(incident)
+}
+
+func (env *cmdEnv) incidentUpdate(args []string) error {
+	status := env.flags.String("status", "", "new status, empty keeps the current one")
+	impact := env.flags.String("impact", "", "new impact, empty keeps the current one")
+	message := env.flags.String("message", "", "timeline entry (required)")
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if *message == "" {
+		return usageError("--message is required")
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	incident, err := env.client.PostIncidentUpdate(ctx, &api.PostIncidentUpdateRequest{
+		IncidentId: rest[0],
+		Status:     *status,
+		Impact:     *impact,
+		Message:    *message,
+	})
+	if err != nil {
+		return err
+	}
+	return env.printIncident(incident)
+}
+
+func (env *cmdEnv) incidentList(args []string) error {
+	since := env.flags.String("since", "", "only incidents opened after this duration ago (24h) or RFC 3339 time, open ones are always listed")
+	limit := env.flags.Int("limit", 0, "maximum number of incidents, 0 uses the server default")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	sinceUnix, err := parseSince(*since)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListIncidents(ctx, &api.ListIncidentsRequest{
+		Since: sinceUnix,
+		Limit: int32(*limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		incidentTable(w, resp.Incidents...)
+	})
+}
+
+func (env *cmdEnv) printIncident(incident *api.Incident) error {
+	return env.out.print(incident, func(w *tabwriter.Writer) {
+		incidentTable(w, incident)
+	})
+}
+
+func incidentTable(w *tabwriter.Writer, incidents ...*api.Incident) {
+	fmt.Fprintln(w, "ID\tSTATUS\tIMPACT\tOPENED\tRESOLVED\tCOMPONENTS\tTITLE")
+	for _, incident := range incidents {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", incident.Id, incident.Status, incident.Impact,
+			formatTime(incident.CreatedAt), formatTime(incident.ResolvedAt),
+			orDash(strings.Join(incident.Components, ",")), incident.Title)
+	}
+}
+
+func runShards(env *cmdEnv, args []string) error {
+	showServices := env.flags.Bool("services", false, "list the IDs of the services of each replica")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListShards(ctx, &api.ListShardsRequest{})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		if *showServices {
+			fmt.Fprintln(w, "INSTANCE\tJOINED\tLAST SEEN\tSERVICES\tSERVICE IDS")
+		} else {
+			fmt.Fprintln(w, "INSTANCE\tJOINED\tLAST SEEN\tSERVICES")
+		}
+		for _, member := range resp.Members {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d", member.Instance, formatTime(member.JoinedAt),
+				formatTime(member.SeenAt), len(member.ServiceIds))
+			if *showServices {
+				fmt.Fprintf(w, "\t%s", orDash(strings.Join(member.ServiceIds, ",")))
+			}
+			fmt.Fprintln(w)
+		}
+	})
+}
+
+func runClusters(env *cmdEnv, args []string) error {
+	cluster := env.flags.String("cluster", "", "only show this cluster")
+	showServices := env.flags.Bool("services", false, "list the services of the clusters")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListFederatedServices(ctx, &api.ListFederatedServicesRequest{Cluster: *cluster})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		if *showServices {
+			serviceTable(w, resp.Services)
+			return
+		}
+		fmt.Fprintln(w, "CLUSTER\tREACHABLE\tSERVICES\tHEALTHY\tUNHEALTHY\tUNKNOWN\tSYNCED\tERROR")
+		for _, c := range resp.Clusters {
+			fmt.Fprintf(w, "%s\t%t\t%d\t%d\t%d\t%d\t%s\t%s\n", c.Name, c.Reachable, c.Services,
+				c.Healthy, c.Unhealthy, c.Unknown, formatTime(c.SyncedAt), orDash(c.Error))
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v3"
+)
+
+// settings are the connection settings shared by every command. They are
+// read from the config file, then the environment, then flags.
+type settings struct {
+	Address   string        `yaml:"address"`
+	Token     string        `yaml:"token"`
+	Namespace string        `yaml:"namespace"`
+	Output    string        `yaml:"output"`
+	Timeout   time.Duration `yaml:"timeout"`
+	// TLS connects over TLS, implied by CAFile and Insecure
+	TLS bool `yaml:"tls"`
+	// CAFile verifies the server certificate against these PEM certificates
+	// instead of the system roots
+	CAFile string `yaml:"ca_file"`
+	// Insecure skips the verification of the server certificate
+	Insecure bool `yaml:"insecure"`
+}
+
+func defaultSettings() settings {
+	return settings{
+		Address: "localhost:50051",
+		Output:  "table",
+		Timeout: 10 * time.Second,
+	}
+}
+
+// defaultConfigPath is ~/.config/watchdog/watchdogctl.yaml or the platform equivalent
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "watchdog", "watchdogctl.yaml")
+}
+
+// loadSettings reads the config file and the WATCHDOG_* environment over
+// the defaults. A missing default config file is not an error, a missing
+// explicit one is.
+func loadSettings(path string) (settings, error) {
+	s := defaultSettings()
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("WATCHDOG_CONFIG")
+		explicit = path != ""
+	}
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &s); err != nil {
+				return s, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case errors.Is(err, os.ErrNotExist) && !explicit:
+		default:
+			return s, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if value := os.Getenv("WATCHDOG_ADDRESS"); value != "" {
+		s.Address = value
+	}
+	if value := os.Getenv("WATCHDOG_TOKEN"); value != "" {
+		s.Token = value
+	}
+	if value := os.Getenv("WATCHDOG_NAMESPACE"); value != "" {
+		s.Namespace = value
+	}
+	if value := os.Getenv("WATCHDOG_OUTPUT"); value != "" {
+		s.Output = value
+	}
+	if value := os.Getenv("WATCHDOG_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return s, fmt.Errorf("invalid WATCHDOG_TIMEOUT: %w", err)
+		}
+		s.Timeout = timeout
+	}
+	for _, env := range []struct {
+		key   string
+		value *bool
+	}{
+		{"WATCHDOG_TLS", &s.TLS},
+		{"WATCHDOG_INSECURE", &s.Insecure},
+	} {
+		if value := os.Getenv(env.key); value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return s, fmt.Errorf("invalid %s: %w", env.key, err)
+			}
+			*env.value = enabled
+		}
+	}
+	if value := os.Getenv("WATCHDOG_CA_FILE"); value != "" {
+		s.CAFile = value
+	}
+
+	return s, nil
+}
+
+// transportCredentials are the credentials to dial the server with:
+// plaintext unless TLS, a CA file or Insecure is configured
+func (s settings) transportCredentials() (credentials.TransportCredentials, error) {
+	if !s.TLS && s.CAFile == "" && !s.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: s.Insecure}
+	if s.CAFile != "" {
+		pem, err := os.ReadFile(s.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", s.CAFile)
+		}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// configFlag finds --config in the arguments before flags are parsed, since
+// the config file provides the flag defaults
+func configFlag(args []string) string {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,266 @@
+// Command watchdogctl manages services on a watchdog server from the command line.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+)
+
+// Exit codes for scripting
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitUnhealthy   = 3
+	exitNotFound    = 4
+	exitDenied      = 5
+	exitUnavailable = 6
+)
+
+// exitErr carries the exit code of a failed command
+type exitErr struct {
+	code int
+	err  error
+}
+
+func (e *exitErr) Error() string { return e.err.Error() }
+
+func (e *exitErr) Unwrap() error { return e.err }
+
+// usageError reports invalid arguments
+func usageError(format string, args ...any) error {
+	return &exitErr{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// errUnhealthy is returned when a checked service or the server is unhealthy
+var errUnhealthy = &exitErr{code: exitUnhealthy, err: errors.New("unhealthy")}
+
+// command is a watchdogctl subcommand
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(env *cmdEnv, args []string) error
+}
+
+// cmdEnv is what a command runs with: its flags, the resolved settings and,
+// once connected, the API client
+type cmdEnv struct {
+	stdout   io.Writer
+	flags    *flag.FlagSet
+	settings settings
+	out      printer
+	client   api.WatchdogServiceClient
+	conn     *grpc.ClientConn
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"health", "health", "Show the health of the watchdog server", runHealth},
+		{"list", "list [--all-namespaces]", "List services", runList},
+		{"get", "get SERVICE_ID", "Show a service", runGet},
+		{"register", "register --name NAME --endpoint URL --type TYPE", "Register a service", runRegister},
+		{"update", "update SERVICE_ID [--status S] [--name N] ...", "Update a service", runUpdate},
+		{"unregister", "unregister SERVICE_ID", "Unregister a service", runUnregister},
+		{"check", "check SERVICE_ID", "Run the health check of a service now", runCheck},
+		{"history", "history SERVICE_ID [--since 24h]", "List the check results of a service", runHistory},
+		{"namespace", "namespace create|list|update|delete ...", "Manage namespaces", runNamespace},
+		{"audit", "audit list|verify ...", "Read and verify the audit log", runAudit},
+		{"incident", "incident create|update|list ...", "Manage status page incidents", runIncident},
+		{"shards", "shards [--services]", "List the replicas sharing the checks", runShards},
+		{"clusters", "clusters [--cluster NAME] [--services]", "Show the federated clusters", runClusters},
+		{"prometheus-sd", "prometheus-sd --file FILE [--selector S] [--healthy] [--once]", "Write Prometheus file_sd targets from the registry", runPrometheusSD},
+		{"top", "top [--all-namespaces] [--group-by type|label:KEY]", "Show a live view of the services", runTop},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(stderr, "watchdogctl: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	s, err := loadSettings(configFlag(args[1:]))
+	if err != nil {
+		fmt.Fprintf(stderr, "watchdogctl: %v\n", err)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("watchdogctl "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: watchdogctl %s\n\n%s\n\nFlags:\n", cmd.usage, cmd.summary)
+		fs.PrintDefaults()
+	}
+	env := &cmdEnv{stdout: stdout, flags: fs, settings: s}
+	fs.String("config", "", "config file (default $WATCHDOG_CONFIG or "+defaultConfigPath()+")")
+	fs.StringVar(&env.settings.Address, "address", s.Address, "server address ($WATCHDOG_ADDRESS)")
+	// No default shown, it would print the configured token
+	fs.Func("token", "bearer token ($WATCHDOG_TOKEN)", func(value string) error {
+		env.settings.Token = value
+		return nil
+	})
+	fs.StringVar(&env.settings.Namespace, "namespace", s.Namespace, "namespace ($WATCHDOG_NAMESPACE)")
+	fs.StringVar(&env.settings.Namespace, "n", s.Namespace, "shorthand for --namespace")
+	fs.StringVar(&env.settings.Output, "output", s.Output, "output format: table, json or yaml ($WATCHDOG_OUTPUT)")
+	fs.StringVar(&env.settings.Output, "o", s.Output, "shorthand for --output")
+	fs.DurationVar(&env.settings.Timeout, "timeout", s.Timeout, "timeout of each call ($WATCHDOG_TIMEOUT)")
+	fs.BoolVar(&env.settings.TLS, "tls", s.TLS, "connect over TLS ($WATCHDOG_TLS)")
+	fs.StringVar(&env.settings.CAFile, "ca-file", s.CAFile, "verify the server certificate against this PEM file, implies --tls ($WATCHDOG_CA_FILE)")
+	fs.BoolVar(&env.settings.Insecure, "insecure", s.Insecure, "skip the verification of the server certificate, implies --tls ($WATCHDOG_INSECURE)")
+	err = cmd.run(env, args[1:])
+	if env.conn != nil {
+		env.conn.Close()
+	}
+	return exitCode(err, stderr)
+}
+
+// exitCode reports err and maps it to an exit code
+func exitCode(err error, stderr io.Writer) int {
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	var exit *exitErr
+	if errors.As(err, &exit) {
+		if exit != errUnhealthy {
+			fmt.Fprintf(stderr, "watchdogctl: %v\n", exit.err)
+		}
+		return exit.code
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		fmt.Fprintf(stderr, "watchdogctl: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(stderr, "watchdogctl: %s\n", st.Message())
+	switch st.Code() {
+	case codes.NotFound:
+		return exitNotFound
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return exitDenied
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return exitUnavailable
+	case codes.InvalidArgument:
+		return exitUsage
+	default:
+		return exitError
+	}
+}
+
+// parse parses flags interleaved with positional arguments, which the flag
+// package stops at, and checks the number of positional arguments
+func (env *cmdEnv) parse(args []string, positional int) ([]string, error) {
+	var rest []string
+	for {
+		if err := env.flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &exitErr{code: exitUsage, err: err}
+		}
+		if env.flags.NArg() == 0 {
+			break
+		}
+		rest = append(rest, env.flags.Arg(0))
+		args = env.flags.Args()[1:]
+	}
+
+	if len(rest) != positional {
+		env.flags.Usage()
+		return nil, &exitErr{code: exitUsage, err: fmt.Errorf("expected %d argument(s), got %d", positional, len(rest))}
+	}
+	if !validFormat(env.settings.Output) {
+		return nil, usageError("unknown output format %q", env.settings.Output)
+	}
+	env.out = printer{w: env.stdout, format: env.settings.Output}
+	return rest, nil
+}
+
+// connect dials the server. The connection is lazy, so errors surface on
+// the first call.
+func (env *cmdEnv) connect() error {
+	creds, err := env.settings.transportCredentials()
+	if err != nil {
+		return usageError("%v", err)
+	}
+	conn, err := grpc.NewClient(env.settings.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return usageError("invalid address %q: %v", env.settings.Address, err)
+	}
+	env.conn = conn
+	env.client = api.NewWatchdogServiceClient(conn)
+	return nil
+}
+
+// callContext bounds a call by the timeout and attaches the token
+func (env *cmdEnv) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, env.settings.Timeout)
+	return env.withToken(ctx), cancel
+}
+
+// withToken attaches the bearer token, for streams that outlive the timeout
+func (env *cmdEnv) withToken(ctx context.Context) context.Context {
+	if env.settings.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+env.settings.Token)
+	}
+	return ctx
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "watchdogctl manages services on a watchdog server.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage: watchdogctl COMMAND [ARGS] [FLAGS]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	summaries := make(map[string]string, len(commands))
+	for _, cmd := range commands {
+		names = append(names, cmd.name)
+		summaries[cmd.name] = cmd.summary
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-14s %s\n", name, summaries[name])
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'watchdogctl COMMAND -h' for the flags of a command.")
+	fmt.Fprintln(w, "Exit codes: 0 ok, 1 error, 2 usage, 3 unhealthy, 4 not found, 5 permission denied, 6 server unavailable.")
+}
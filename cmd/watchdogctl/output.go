@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames:   true,
+	EmitUnpopulated: true,
+}
+
+// printer writes responses in the selected output format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// print writes msg as JSON or YAML, or calls table for the table format
+func (p printer) print(msg proto.Message, table func(w *tabwriter.Writer)) error {
+	switch p.format {
+	case "json":
+		data, err := marshalOptions.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		// protojson randomizes whitespace, indent it stably for scripts
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err = buf.WriteTo(p.w)
+		return err
+	case "yaml":
+		data, err := toYAML(msg)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(data)
+		return err
+	default:
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	}
+}
+
+// toYAML converts the JSON encoding of msg, keeping the field order
+func toYAML(msg proto.Message) ([]byte, error) {
+	data, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), enc.Close()
+}
+
+// blockStyle drops the JSON flow style so the document reads as plain YAML
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func validFormat(format string) bool {
+	return format == "table" || format == "json" || format == "yaml"
+}
+
+// formatTime renders a Unix timestamp, "-" for zero
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+	return time.Unix(unix, 0).Local().Format(time.DateTime)
+}
+
+// formatLabels renders labels as k=v pairs
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/client"
+	"watchdog/promsd"
+	"watchdog/render"
+)
+
+func runPrometheusSD(env *cmdEnv, args []string) error {
+	file := env.flags.String("file", "", "file_sd file to write the targets to")
+	selector := env.flags.String("selector", "", "only services matching key=value,key!=value terms")
+	healthy := env.flags.Bool("healthy", false, "only active services that are healthy or not checked yet")
+	all := env.flags.Bool("all-namespaces", false, "services of every namespace, requires an admin token")
+	env.flags.BoolVar(all, "A", false, "shorthand for --all-namespaces")
+	once := env.flags.Bool("once", false, "write once and exit instead of watching")
+	interval := env.flags.Duration("poll-interval", 5*time.Second, "refresh interval when the server cannot stream")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageError("--file is required")
+	}
+	sel, err := client.ParseSelector(*selector)
+	if err != nil {
+		return usageError("%v", err)
+	}
+	opts := promsd.Options{Selector: sel, HealthyOnly: *healthy}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	if *once {
+		ctx, cancel := env.callContext(context.Background())
+		defer cancel()
+		resp, err := env.client.ListServices(ctx, &api.ListServicesRequest{
+			Namespace:     env.settings.Namespace,
+			AllNamespaces: *all,
+		})
+		if err != nil {
+			return err
+		}
+		return writeTargets(*file, services(resp.Services), opts)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	env.feed(ctx, func(msg tea.Msg) {
+		switch msg := msg.(type) {
+		case servicesMsg:
+			if err := writeTargets(*file, services(msg.services), opts); err != nil {
+				fmt.Fprintf(os.Stderr, "watchdogctl: %v\n", err)
+			}
+		case feedErrMsg:
+			fmt.Fprintf(os.Stderr, "watchdogctl: watch failed, keeping the targets: %s\n", status.Convert(msg.err).Message())
+		}
+	}, *all, *interval)
+	return nil
+}
+
+// writeTargets replaces the file_sd file when the targets changed
+func writeTargets(file string, services []client.Service, opts promsd.Options) error {
+	data, err := json.MarshalIndent(promsd.Targets(services, opts), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = render.WriteFile(file, append(data, '\n'))
+	return err
+}
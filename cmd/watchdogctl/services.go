@@ -0,0 +1,453 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"watchdog/api"
+	"watchdog/client"
+)
+
+// labelsFlag collects repeated --label key=value flags
+type labelsFlag map[string]string
+
+func (l labelsFlag) String() string { return formatLabels(l) }
+
+func (l labelsFlag) Set(value string) error {
+	key, val, found := strings.Cut(value, "=")
+	if !found || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	l[key] = val
+	return nil
+}
+
+// parseServiceType accepts "http", "HTTP" or "SERVICE_TYPE_HTTP"
+func parseServiceType(name string) (api.ServiceType, error) {
+	upper := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	if !strings.HasPrefix(upper, "SERVICE_TYPE_") {
+		upper = "SERVICE_TYPE_" + upper
+	}
+	value, ok := api.ServiceType_value[upper]
+	if !ok || value == 0 {
+		return 0, usageError("unknown service type %q", name)
+	}
+	return api.ServiceType(value), nil
+}
+
+// services converts the listed services for the client helpers
+func services(infos []*api.ServiceInfo) []client.Service {
+	services := make([]client.Service, len(infos))
+	for i, svc := range infos {
+		services[i] = client.FromProto(svc)
+	}
+	return services
+}
+
+// typeName renders SERVICE_TYPE_EXTERNAL_API as external_api
+func typeName(t api.ServiceType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "SERVICE_TYPE_"))
+}
+
+// parseSince accepts a duration before now such as 24h, or an RFC 3339 time
+func parseSince(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d).Unix(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, usageError("invalid time %q, use a duration such as 24h or an RFC 3339 time", value)
+	}
+	return t.Unix(), nil
+}
+
+// waitFlags configure --wait-healthy polling
+type waitFlags struct {
+	enabled  bool
+	timeout  time.Duration
+	interval time.Duration
+}
+
+func addWaitFlags(env *cmdEnv) *waitFlags {
+	w := &waitFlags{}
+	env.flags.BoolVar(&w.enabled, "wait-healthy", false, "poll the health check until the service is healthy")
+	env.flags.DurationVar(&w.timeout, "wait-timeout", 5*time.Minute, "how long --wait-healthy polls")
+	env.flags.DurationVar(&w.interval, "wait-interval", 5*time.Second, "time between --wait-healthy checks")
+	return w
+}
+
+// waitHealthy runs the health check of a service until it is healthy or
+// the wait times out
+func (env *cmdEnv) waitHealthy(serviceID string, w *waitFlags) (*api.HealthResponse, error) {
+	deadline := time.Now().Add(w.timeout)
+	for {
+		ctx, cancel := env.callContext(context.Background())
+		resp, err := env.client.CheckServiceHealth(ctx, &api.CheckServiceHealthRequest{
+			ServiceId: serviceID,
+			Namespace: env.settings.Namespace,
+		})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Status == "healthy" {
+			return resp, nil
+		}
+
+		if time.Now().Add(w.interval).After(deadline) {
+			return resp, &exitErr{
+				code: exitUnhealthy,
+				err:  fmt.Errorf("service %s is not healthy after %s: %s", serviceID, w.timeout, resp.Message),
+			}
+		}
+		time.Sleep(w.interval)
+	}
+}
+
+func (env *cmdEnv) printHealth(resp *api.HealthResponse) error {
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		if resp.Leader != "" {
+			fmt.Fprintln(w, "STATUS\tLEADER\tMESSAGE")
+			fmt.Fprintf(w, "%s\t%s\t%s\n", resp.Status, resp.Leader, resp.Message)
+			return
+		}
+		fmt.Fprintln(w, "STATUS\tMESSAGE")
+		fmt.Fprintf(w, "%s\t%s\n", resp.Status, resp.Message)
+	})
+}
+
+func runHealth(env *cmdEnv, args []string) error {
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.GetHealth(ctx, &api.HealthRequest{Namespace: env.settings.Namespace})
+	if err != nil {
+		return err
+	}
+
+	if err := env.printHealth(resp); err != nil {
+		return err
+	}
+	if resp.Status != "healthy" {
+		return errUnhealthy
+	}
+	return nil
+}
+
+func runList(env *cmdEnv, args []string) error {
+	all := env.flags.Bool("all-namespaces", false, "list services of every namespace, requires an admin token")
+	env.flags.BoolVar(all, "A", false, "shorthand for --all-namespaces")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListServices(ctx, &api.ListServicesRequest{
+		Namespace:     env.settings.Namespace,
+		AllNamespaces: *all,
+	})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		serviceTable(w, resp.Services)
+	})
+}
+
+func serviceTable(w *tabwriter.Writer, services []*api.ServiceInfo) {
+	fmt.Fprintln(w, "ID\tNAMESPACE\tNAME\tTYPE\tSTATUS\tLAST CHECK\tLATENCY\tENDPOINT")
+	for _, svc := range services {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			svc.Id, svc.Namespace, svc.Name, typeName(svc.Type), svc.Status,
+			orDash(svc.LastCheckStatus), latency(svc), svc.Endpoint)
+	}
+}
+
+func latency(svc *api.ServiceInfo) string {
+	if svc.LastCheckedAt == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(svc.LastCheckLatencyMs, 10) + "ms"
+}
+
+func runGet(env *cmdEnv, args []string) error {
+	wait := addWaitFlags(env)
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	var waitErr error
+	if wait.enabled {
+		if _, waitErr = env.waitHealthy(rest[0], wait); waitErr != nil && !isUnhealthy(waitErr) {
+			return waitErr
+		}
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	svc, err := env.client.GetService(ctx, &api.GetServiceRequest{
+		ServiceId: rest[0],
+		Namespace: env.settings.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := env.printService(svc); err != nil {
+		return err
+	}
+	return waitErr
+}
+
+func (env *cmdEnv) printService(svc *api.ServiceInfo) error {
+	return env.out.print(svc, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", svc.Id)
+		fmt.Fprintf(w, "Namespace:\t%s\n", svc.Namespace)
+		fmt.Fprintf(w, "Name:\t%s\n", svc.Name)
+		fmt.Fprintf(w, "Type:\t%s\n", typeName(svc.Type))
+		fmt.Fprintf(w, "Endpoint:\t%s\n", svc.Endpoint)
+		fmt.Fprintf(w, "Status:\t%s\n", svc.Status)
+		fmt.Fprintf(w, "Check interval:\t%ds\n", svc.CheckIntervalSeconds)
+		fmt.Fprintf(w, "Labels:\t%s\n", formatLabels(svc.Labels))
+		fmt.Fprintf(w, "Last heartbeat:\t%s\n", formatTime(svc.LastHeartbeat))
+		fmt.Fprintf(w, "Last check:\t%s %s (%s)\n", orDash(svc.LastCheckStatus), formatTime(svc.LastCheckedAt), latency(svc))
+	})
+}
+
+func runRegister(env *cmdEnv, args []string) error {
+	name := env.flags.String("name", "", "service name (required)")
+	endpoint := env.flags.String("endpoint", "", "endpoint to check (required)")
+	typ := env.flags.String("type", "http", "service type, e.g. http, grpc, systemd, database")
+	interval := env.flags.Int("interval", 0, "seconds between health checks, 0 uses the server default")
+	labels := labelsFlag{}
+	env.flags.Var(labels, "label", "label as key=value, repeatable")
+	wait := addWaitFlags(env)
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if *name == "" || *endpoint == "" {
+		return usageError("--name and --endpoint are required")
+	}
+	serviceType, err := parseServiceType(*typ)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.RegisterService(ctx, &api.RegisterServiceRequest{
+		Name:                 *name,
+		Endpoint:             *endpoint,
+		Type:                 serviceType,
+		Namespace:            env.settings.Namespace,
+		CheckIntervalSeconds: int32(*interval),
+		Labels:               labels,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = env.out.print(resp, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "ID\tMESSAGE")
+		fmt.Fprintf(w, "%s\t%s\n", resp.ServiceId, resp.Message)
+	})
+	if err != nil || !wait.enabled {
+		return err
+	}
+	_, err = env.waitHealthy(resp.ServiceId, wait)
+	return err
+}
+
+func runUpdate(env *cmdEnv, args []string) error {
+	status := env.flags.String("status", "", "new status")
+	name := env.flags.String("name", "", "new name")
+	typ := env.flags.String("type", "", "new service type")
+	endpoint := env.flags.String("endpoint", "", "new endpoint")
+	interval := env.flags.Int("interval", 0, "new seconds between health checks")
+	labels := labelsFlag{}
+	env.flags.Var(labels, "label", "label as key=value, repeatable, replaces all labels")
+	clearLabels := env.flags.Bool("clear-labels", false, "remove all labels")
+	wait := addWaitFlags(env)
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+
+	req := &api.UpdateServiceRequest{
+		ServiceId:            rest[0],
+		Namespace:            env.settings.Namespace,
+		Status:               *status,
+		Name:                 *name,
+		Endpoint:             *endpoint,
+		CheckIntervalSeconds: int32(*interval),
+		Labels:               labels,
+		ClearLabels:          *clearLabels,
+	}
+	if *typ != "" {
+		if req.Type, err = parseServiceType(*typ); err != nil {
+			return err
+		}
+	}
+	changed := false
+	env.flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "status", "name", "type", "endpoint", "interval", "label", "clear-labels":
+			changed = true
+		}
+	})
+	if !changed {
+		return usageError("nothing to update")
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.UpdateService(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if err := env.printMessage(resp, resp.Message); err != nil || !wait.enabled {
+		return err
+	}
+	_, err = env.waitHealthy(rest[0], wait)
+	return err
+}
+
+// printMessage prints responses that only carry a message
+func (env *cmdEnv) printMessage(resp proto.Message, message string) error {
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, message)
+	})
+}
+
+func runUnregister(env *cmdEnv, args []string) error {
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.UnregisterService(ctx, &api.UnregisterServiceRequest{
+		ServiceId: rest[0],
+		Namespace: env.settings.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+	return env.printMessage(resp, resp.Message)
+}
+
+func runCheck(env *cmdEnv, args []string) error {
+	wait := addWaitFlags(env)
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	var resp *api.HealthResponse
+	if wait.enabled {
+		resp, err = env.waitHealthy(rest[0], wait)
+		if resp == nil {
+			return err
+		}
+	} else {
+		ctx, cancel := env.callContext(context.Background())
+		defer cancel()
+		resp, err = env.client.CheckServiceHealth(ctx, &api.CheckServiceHealthRequest{
+			ServiceId: rest[0],
+			Namespace: env.settings.Namespace,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Status != "healthy" {
+			err = errUnhealthy
+		}
+	}
+
+	if printErr := env.printHealth(resp); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+func runHistory(env *cmdEnv, args []string) error {
+	since := env.flags.String("since", "", "only results after this duration ago (24h) or RFC 3339 time")
+	limit := env.flags.Int("limit", 0, "maximum number of results, 0 uses the server default")
+	rest, err := env.parse(args, 1)
+	if err != nil {
+		return err
+	}
+	sinceUnix, err := parseSince(*since)
+	if err != nil {
+		return err
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := env.callContext(context.Background())
+	defer cancel()
+	resp, err := env.client.ListCheckResults(ctx, &api.ListCheckResultsRequest{
+		ServiceId: rest[0],
+		Namespace: env.settings.Namespace,
+		Since:     sinceUnix,
+		Limit:     int32(*limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	return env.out.print(resp, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "CHECKED AT\tSTATUS\tLATENCY\tMESSAGE")
+		for _, result := range resp.Results {
+			fmt.Fprintf(w, "%s\t%s\t%dms\t%s\n", formatTime(result.CheckedAt), result.Status, result.LatencyMs, result.Message)
+			for _, location := range result.Locations {
+				fmt.Fprintf(w, "  @%s\t%s\t%dms\t%s\n", location.Location, location.Status, location.LatencyMs, location.Message)
+			}
+		}
+	})
+}
+
+func isUnhealthy(err error) bool {
+	exit, ok := err.(*exitErr)
+	return ok && exit.code == exitUnhealthy
+}
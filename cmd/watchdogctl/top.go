@@ -0,0 +1,585 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/servicestatus"
+)
+
+// retryInterval is how long the live view waits before reconnecting a
+// broken stream
+const retryInterval = 3 * time.Second
+
+var (
+	titleStyle     = lipgloss.NewStyle().Bold(true)
+	groupStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	headerStyle    = lipgloss.NewStyle().Faint(true)
+	selectedStyle  = lipgloss.NewStyle().Reverse(true)
+	healthyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	unhealthyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	unknownStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+// Messages delivered to the live view
+type (
+	servicesMsg struct {
+		services []*api.ServiceInfo
+		source   string
+	}
+	feedErrMsg struct{ err error }
+	tickMsg    time.Time
+	checkedMsg struct {
+		service *api.ServiceInfo
+		resp    *api.HealthResponse
+		err     error
+	}
+	historyMsg struct {
+		serviceID string
+		results   []*api.CheckResult
+		err       error
+	}
+)
+
+func runTop(env *cmdEnv, args []string) error {
+	all := env.flags.Bool("all-namespaces", false, "show services of every namespace, requires an admin token")
+	env.flags.BoolVar(all, "A", false, "shorthand for --all-namespaces")
+	groupBy := env.flags.String("group-by", "type", "group services by \"type\" or \"label:KEY\"")
+	interval := env.flags.Duration("poll-interval", 5*time.Second, "refresh interval when the server cannot stream")
+	if _, err := env.parse(args, 0); err != nil {
+		return err
+	}
+	if *groupBy != "type" && !strings.HasPrefix(*groupBy, "label:") {
+		return usageError("--group-by must be \"type\" or \"label:KEY\"")
+	}
+	if err := env.connect(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &topModel{env: env, groupBy: *groupBy, now: time.Now(), source: "connecting"}
+	program := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx))
+	go env.feed(ctx, program.Send, *all, *interval)
+
+	_, err := program.Run()
+	if err != nil && ctx.Err() == nil && !strings.Contains(err.Error(), "context canceled") {
+		return err
+	}
+	return nil
+}
+
+// feed delivers the service list to the live view, streamed through
+// WatchServices or, from servers without it, polled through ListServices
+func (env *cmdEnv) feed(ctx context.Context, send func(tea.Msg), all bool, interval time.Duration) {
+	for ctx.Err() == nil {
+		err := env.stream(ctx, send, all)
+		if status.Code(err) == codes.Unimplemented {
+			env.poll(ctx, send, all, interval)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		send(feedErrMsg{err})
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func (env *cmdEnv) stream(ctx context.Context, send func(tea.Msg), all bool) error {
+	// The stream has no deadline, only the token is attached
+	ctx, cancel := context.WithCancel(env.withToken(ctx))
+	defer cancel()
+
+	stream, err := env.client.WatchServices(ctx, &api.WatchServicesRequest{
+		Namespace:     env.settings.Namespace,
+		AllNamespaces: all,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		send(servicesMsg{services: resp.Services, source: "streaming"})
+	}
+}
+
+func (env *cmdEnv) poll(ctx context.Context, send func(tea.Msg), all bool, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		callCtx, cancel := env.callContext(ctx)
+		resp, err := env.client.ListServices(callCtx, &api.ListServicesRequest{
+			Namespace:     env.settings.Namespace,
+			AllNamespaces: all,
+		})
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			send(feedErrMsg{err})
+		} else {
+			send(servicesMsg{services: resp.Services, source: fmt.Sprintf("polling every %s", interval)})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// topModel is the state of the live view
+type topModel struct {
+	env     *cmdEnv
+	groupBy string
+	now     time.Time
+	width   int
+	height  int
+
+	services []*api.ServiceInfo
+	source   string
+	feedErr  error
+	notice   string
+
+	filter    string
+	filtering bool
+	cursor    int
+	offset    int
+
+	// history is the drill-down into one service, nil on the list
+	history        *api.ServiceInfo
+	historyResults []*api.CheckResult
+	historyErr     error
+}
+
+func (m *topModel) Init() tea.Cmd {
+	return tick()
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (m *topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tickMsg:
+		m.now = time.Time(msg)
+		return m, tick()
+	case servicesMsg:
+		m.services, m.source, m.feedErr = msg.services, msg.source, nil
+		m.clampCursor()
+	case feedErrMsg:
+		m.feedErr = msg.err
+	case checkedMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("check of %s failed: %s", msg.service.Name, status.Convert(msg.err).Message())
+		} else {
+			m.notice = fmt.Sprintf("checked %s: %s %s", msg.service.Name, msg.resp.Status, msg.resp.Message)
+		}
+		if m.history != nil && m.history.Id == msg.service.Id {
+			return m, m.loadHistory(m.history)
+		}
+	case historyMsg:
+		if m.history != nil && m.history.Id == msg.serviceID {
+			m.historyResults, m.historyErr = msg.results, msg.err
+		}
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *topModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.filtering {
+		switch key.Type {
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyEsc:
+			m.filtering, m.filter = false, ""
+		case tea.KeyBackspace:
+			if runes := []rune(m.filter); len(runes) > 0 {
+				m.filter = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(key.Runes)
+		}
+		m.clampCursor()
+		return m, nil
+	}
+
+	if m.history != nil {
+		switch key.String() {
+		case "q":
+			return m, tea.Quit
+		case "esc", "backspace", "left", "h":
+			m.history, m.historyResults, m.historyErr = nil, nil, nil
+		case "c":
+			return m, m.check(m.history)
+		case "r":
+			return m, m.loadHistory(m.history)
+		}
+		return m, nil
+	}
+
+	visible := m.visible()
+	switch key.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "home":
+		m.cursor = 0
+	case "end":
+		m.cursor = len(visible) - 1
+	case "/":
+		m.filtering = true
+	case "esc":
+		m.filter = ""
+	case "g":
+		m.groupBy = m.nextGroup()
+	case "c":
+		if m.cursor < len(visible) {
+			return m, m.check(visible[m.cursor])
+		}
+	case "enter", "right", "l":
+		if m.cursor < len(visible) {
+			m.history = visible[m.cursor]
+			m.historyResults, m.historyErr = nil, nil
+			return m, m.loadHistory(m.history)
+		}
+	}
+	m.clampCursor()
+	return m, nil
+}
+
+// check runs the health check of a service in the background
+func (m *topModel) check(svc *api.ServiceInfo) tea.Cmd {
+	m.notice = "checking " + svc.Name + "..."
+	env := m.env
+	return func() tea.Msg {
+		ctx, cancel := env.callContext(context.Background())
+		defer cancel()
+		resp, err := env.client.CheckServiceHealth(ctx, &api.CheckServiceHealthRequest{
+			ServiceId: svc.Id,
+			Namespace: svc.Namespace,
+		})
+		return checkedMsg{service: svc, resp: resp, err: err}
+	}
+}
+
+// loadHistory fetches the recent check results of a service
+func (m *topModel) loadHistory(svc *api.ServiceInfo) tea.Cmd {
+	env, limit := m.env, max(m.height-6, 10)
+	return func() tea.Msg {
+		ctx, cancel := env.callContext(context.Background())
+		defer cancel()
+		resp, err := env.client.ListCheckResults(ctx, &api.ListCheckResultsRequest{
+			ServiceId: svc.Id,
+			Namespace: svc.Namespace,
+			Limit:     int32(limit),
+		})
+		if err != nil {
+			return historyMsg{serviceID: svc.Id, err: err}
+		}
+		return historyMsg{serviceID: svc.Id, results: resp.Results}
+	}
+}
+
+// groupKey is the group a service is listed under
+func (m *topModel) groupKey(svc *api.ServiceInfo) string {
+	if key, ok := strings.CutPrefix(m.groupBy, "label:"); ok {
+		if value := svc.Labels[key]; value != "" {
+			return key + "=" + value
+		}
+		return "no " + key + " label"
+	}
+	return typeName(svc.Type)
+}
+
+// nextGroup cycles through grouping by type and by every known label key
+func (m *topModel) nextGroup() string {
+	options := []string{"type"}
+	keys := make(map[string]struct{})
+	for _, svc := range m.services {
+		for key := range svc.Labels {
+			keys[key] = struct{}{}
+		}
+	}
+	for key := range keys {
+		options = append(options, "label:"+key)
+	}
+	slices.Sort(options[1:])
+
+	next := slices.Index(options, m.groupBy) + 1
+	return options[next%len(options)]
+}
+
+// visible returns the services matching the filter in display order
+func (m *topModel) visible() []*api.ServiceInfo {
+	filter := strings.ToLower(m.filter)
+	services := make([]*api.ServiceInfo, 0, len(m.services))
+	for _, svc := range m.services {
+		if filter == "" || strings.Contains(strings.ToLower(svc.Name), filter) {
+			services = append(services, svc)
+		}
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		gi, gj := m.groupKey(services[i]), m.groupKey(services[j])
+		if gi != gj {
+			return gi < gj
+		}
+		if services[i].Name != services[j].Name {
+			return services[i].Name < services[j].Name
+		}
+		return services[i].Namespace < services[j].Namespace
+	})
+	return services
+}
+
+func (m *topModel) clampCursor() {
+	m.cursor = min(m.cursor, len(m.visible())-1)
+	m.cursor = max(m.cursor, 0)
+}
+
+func (m *topModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	if m.history != nil {
+		return m.historyView()
+	}
+
+	var b strings.Builder
+	visible := m.visible()
+	b.WriteString(m.title(visible) + "\n")
+	b.WriteString(m.statusLine() + "\n")
+	b.WriteString(headerStyle.Render(fit(fmt.Sprintf("  %-24s %-12s %-10s %9s %8s %9s  %s",
+		"NAME", "NAMESPACE", "STATUS", "HEARTBEAT", "LATENCY", "CHECKED", "ENDPOINT"), m.width)) + "\n")
+
+	// Lines are the group headers and service rows, the selected row is kept
+	// on screen by scrolling
+	type line struct {
+		text    string
+		service int
+	}
+	var lines []line
+	selected := 0
+	group := ""
+	for i, svc := range visible {
+		if key := m.groupKey(svc); i == 0 || key != group {
+			group = key
+			lines = append(lines, line{groupStyle.Render(fit(fmt.Sprintf("▸ %s (%d)", key, m.groupSize(visible, key)), m.width)), -1})
+		}
+		if i == m.cursor {
+			selected = len(lines)
+		}
+		lines = append(lines, line{m.row(svc, i == m.cursor), i})
+	}
+
+	rows := max(m.height-5, 1)
+	if selected < m.offset {
+		m.offset = selected
+	}
+	if selected >= m.offset+rows {
+		m.offset = selected - rows + 1
+	}
+	m.offset = max(min(m.offset, len(lines)-rows), 0)
+
+	for i := m.offset; i < len(lines) && i < m.offset+rows; i++ {
+		b.WriteString(lines[i].text + "\n")
+	}
+	if len(visible) == 0 {
+		b.WriteString(unknownStyle.Render("  no services") + "\n")
+	}
+	for i := len(lines); i < m.offset+rows-1; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.footer("↑/↓ select  enter history  c check  / filter  g group  q quit"))
+	return b.String()
+}
+
+func (m *topModel) groupSize(services []*api.ServiceInfo, key string) int {
+	n := 0
+	for _, svc := range services {
+		if m.groupKey(svc) == key {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *topModel) title(visible []*api.ServiceInfo) string {
+	healthy, unhealthy := 0, 0
+	for _, svc := range visible {
+		switch svc.LastCheckStatus {
+		case "healthy":
+			healthy++
+		case "unhealthy":
+			unhealthy++
+		}
+	}
+
+	scope := "namespace " + orDash(m.env.settings.Namespace)
+	title := fmt.Sprintf("watchdog top - %s - %s - %d services, ", m.env.settings.Address, scope, len(visible))
+	return titleStyle.Render(title) +
+		healthyStyle.Render(fmt.Sprintf("%d healthy", healthy)) + ", " +
+		unhealthyStyle.Render(fmt.Sprintf("%d unhealthy", unhealthy))
+}
+
+func (m *topModel) statusLine() string {
+	var parts []string
+	parts = append(parts, m.source, "grouped by "+m.groupBy)
+	if m.filter != "" || m.filtering {
+		filter := "filter: " + m.filter
+		if m.filtering {
+			filter += "_"
+		}
+		parts = append(parts, filter)
+	}
+	line := headerStyle.Render(fit(strings.Join(parts, " | "), m.width))
+	if m.feedErr != nil {
+		line += "  " + errorStyle.Render(fit(status.Convert(m.feedErr).Message(), max(m.width/2, 10)))
+	}
+	return line
+}
+
+func (m *topModel) footer(keys string) string {
+	if m.notice != "" {
+		return fit(m.notice, m.width) + "\n" + headerStyle.Render(fit(keys, m.width))
+	}
+	return "\n" + headerStyle.Render(fit(keys, m.width))
+}
+
+// row renders a service, colored by its most recent check
+func (m *topModel) row(svc *api.ServiceInfo, selected bool) string {
+	statusText := orDash(svc.LastCheckStatus)
+	if !servicestatus.Routable(svc.Status) {
+		statusText = svc.Status
+	}
+
+	prefix := fmt.Sprintf("  %-24s %-12s ", fit(svc.Name, 24), fit(svc.Namespace, 12))
+	cell := fmt.Sprintf("%-10s", fit(statusText, 10))
+	suffix := fmt.Sprintf(" %9s %8s %9s  %s", m.age(svc.LastHeartbeat), latency(svc), m.age(svc.LastCheckedAt), svc.Endpoint)
+
+	if selected {
+		return selectedStyle.Render(fit(prefix+cell+suffix, m.width))
+	}
+	room := max(m.width-len([]rune(prefix))-len([]rune(cell)), 0)
+	return prefix + statusStyle(svc.LastCheckStatus).Render(cell) + fit(suffix, room)
+}
+
+func (m *topModel) historyView() string {
+	svc := m.history
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fit(fmt.Sprintf("%s (%s/%s)", svc.Name, svc.Namespace, svc.Id), m.width)) + "\n")
+	interval := "default interval"
+	if svc.CheckIntervalSeconds > 0 {
+		interval = fmt.Sprintf("every %ds", svc.CheckIntervalSeconds)
+	}
+	b.WriteString(headerStyle.Render(fit(fmt.Sprintf("%s %s - %s - labels %s",
+		typeName(svc.Type), svc.Endpoint, interval, formatLabels(svc.Labels)), m.width)) + "\n")
+	b.WriteString(headerStyle.Render(fit(fmt.Sprintf("  %-19s %-10s %8s  %s", "CHECKED AT", "STATUS", "LATENCY", "MESSAGE"), m.width)) + "\n")
+
+	rows := max(m.height-5, 1)
+	switch {
+	case m.historyErr != nil:
+		b.WriteString(errorStyle.Render(fit("  "+status.Convert(m.historyErr).Message(), m.width)) + "\n")
+		rows--
+	case m.historyResults == nil:
+		b.WriteString(unknownStyle.Render("  loading...") + "\n")
+		rows--
+	case len(m.historyResults) == 0:
+		b.WriteString(unknownStyle.Render("  no check results") + "\n")
+		rows--
+	}
+	for i, result := range m.historyResults {
+		if i == rows {
+			break
+		}
+		prefix := fmt.Sprintf("  %-19s ", formatTime(result.CheckedAt))
+		cell := fmt.Sprintf("%-10s", fit(result.Status, 10))
+		suffix := fmt.Sprintf(" %6dms  %s", result.LatencyMs, result.Message)
+		room := max(m.width-len([]rune(prefix))-len([]rune(cell)), 0)
+		b.WriteString(prefix + statusStyle(result.Status).Render(cell) + fit(suffix, room) + "\n")
+		rows--
+	}
+	for ; rows > 0; rows-- {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.footer("esc back  c check  r reload  q quit"))
+	return b.String()
+}
+
+// age renders how long ago a Unix timestamp was, "-" for zero
+func (m *topModel) age(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+	d := m.now.Sub(time.Unix(unix, 0))
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func statusStyle(checkStatus string) lipgloss.Style {
+	switch checkStatus {
+	case "healthy":
+		return healthyStyle
+	case "unhealthy":
+		return unhealthyStyle
+	default:
+		return unknownStyle
+	}
+}
+
+// fit truncates a string to a number of columns
+func fit(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-1]) + "…"
+}
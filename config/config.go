@@ -2,31 +2,186 @@ package config
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"watchdog/database"
+	"watchdog/logging"
 
 	"github.com/joho/godotenv"
 )
 
+var logger = logging.For("config")
+
 type Config struct {
-	Server   ServerConfig
-	Database database.Config
+	Server     ServerConfig
+	Database   database.Config
+	StatusPage StatusPageConfig
+	Tracing    TracingConfig
+	Logging    logging.Config
+	Federation FederationConfig
+	DNS        DNSConfig
 }
 
 type ServerConfig struct {
 	Port int
+	// HTTPPort serves the HTTP/JSON gateway, 0 disables the HTTP listener
+	HTTPPort int
+	// GRPCWebEnabled serves gRPC-Web on the HTTP listener
+	GRPCWebEnabled bool
+	// GRPCWebAllowedOrigins lists the origins allowed to make cross-origin
+	// gRPC-Web calls, "*" allows any origin
+	GRPCWebAllowedOrigins []string
+	// DashboardEnabled serves the operator web dashboard under /ui/ on the HTTP listener
+	DashboardEnabled bool
+	// MetricsEnabled serves Prometheus metrics under /metrics on the HTTP listener
+	MetricsEnabled bool
+	// PromSDEnabled serves Prometheus http_sd targets under
+	// /prometheus/targets on the HTTP listener
+	PromSDEnabled bool
+	// SchedulerEnabled runs the health check of every service once per check interval
+	SchedulerEnabled bool
+	// SchedulerWorkers is the number of health checks run concurrently
+	SchedulerWorkers int
+	// CheckResultRetention is how many hours check results are kept, 0 keeps
+	// them forever
+	CheckResultRetention int
+	// LeaderElection runs the scheduler only on the replica holding the
+	// scheduler lease, for several replicas sharing a database
+	LeaderElection bool
+	// LeaseDuration is how many seconds the scheduler lease lasts without
+	// renewal, bounding how long a failover takes
+	LeaseDuration int
+	// ShardingEnabled splits the scheduled checks between all live replicas
+	// sharing a database instead of running them on one
+	ShardingEnabled bool
+	// MemberTimeout is how many seconds a replica may go without a heartbeat
+	// before its share of the checks moves to the others
+	MemberTimeout int
+	// InstanceName identifies this replica in the scheduler lease and the
+	// shard membership
+	InstanceName string
+	// ProbeLocation names the server in the locations label of services
+	ProbeLocation string
+	// ProbeQuorum is how many locations must report a service unhealthy,
+	// 0 means a majority of its locations
+	ProbeQuorum int
+	// XDSEnabled serves an xDS control plane on the gRPC port publishing the
+	// healthy instances of gRPC and HTTP services
+	XDSEnabled bool
+	// XDSGroupLabel names the label whose value groups services into one xDS
+	// cluster, services without it are grouped by name
+	XDSGroupLabel string
+	// ShutdownDelay is how many seconds the server keeps serving after
+	// reporting NOT_SERVING on shutdown, so load balancers can drain it
+	ShutdownDelay int
+	// AdminToken grants admin rights (namespace management, cross-namespace
+	// listing). When empty admin RPCs are refused.
+	AdminToken string
+	// APITokens maps bearer tokens to caller names recorded in the audit log
+	APITokens map[string]string
+	// AgentTokens maps bearer tokens to the names of the remote probe agents
+	// allowed to connect with them
+	AgentTokens map[string]string
+}
+
+// StatusPageConfig configures the public status page
+type StatusPageConfig struct {
+	// Port serves the status page on its own listener, 0 disables it
+	Port int
+	// Title is shown in the page header and the Atom feed
+	Title string
+	// ComponentsFile is the JSON file listing the public components
+	ComponentsFile string
+	// BaseURL is the public URL of the status page, used for feed links.
+	// When empty links are derived from the request.
+	BaseURL string
+}
+
+// TracingConfig configures OpenTelemetry trace export
+type TracingConfig struct {
+	// Endpoint is the OTLP/gRPC collector address, empty disables tracing
+	Endpoint string
+	// Insecure sends traces without TLS, e.g. to a local collector
+	Insecure bool
+	// ServiceName is reported as the service.name resource attribute
+	ServiceName string
+	// SampleRatio is the fraction of new traces that are sampled. Traces
+	// started by a caller follow the caller's sampling decision.
+	SampleRatio float64
+}
+
+// FederationConfig configures the aggregation of child watchdogs
+type FederationConfig struct {
+	// Name is the cluster name of this watchdog's own services in the
+	// merged view
+	Name string
+	// Clusters maps child cluster names to their gRPC addresses, empty
+	// disables federation
+	Clusters map[string]string
+	// Token is sent to the children, it must be an admin token there
+	Token string
+	// TLS connects to the children over TLS
+	TLS bool
+	// PlaintextToken allows sending the token to the children without TLS
+	PlaintextToken bool
+	// PollInterval is how many seconds pass between polls of a child
+	PollInterval int
+}
+
+// DNSConfig configures the DNS server for service discovery
+type DNSConfig struct {
+	// Port serves DNS over UDP and TCP, 0 disables it
+	Port int
+	// Address is the IP address DNS listens on, empty for every interface
+	Address string
+	// Domain is the zone answered, e.g. watchdog for api.service.watchdog
+	Domain string
+	// TTL is how many seconds resolvers may cache instance records
+	TTL int
+	// NegativeTTL is how many seconds resolvers may cache missing names
+	NegativeTTL int
+	// IncludeUnhealthy also returns instances that are not healthy
+	IncludeUnhealthy bool
+	// Upstream receives queries outside the zone, empty refuses them
+	Upstream string
+	// ForwardNetworks are the CIDRs of clients whose queries outside the
+	// zone are forwarded, empty for loopback and private addresses
+	ForwardNetworks []string
 }
 
 func Load() *Config {
 	loadEnvFile()
+	port := getIntEnv("PORT", 50051)
 
 	return &Config{
 		Server: ServerConfig{
-			Port: getIntEnv("PORT", 50051),
+			Port:                  port,
+			HTTPPort:              getIntEnv("HTTP_PORT", 8080),
+			GRPCWebEnabled:        getBoolEnv("GRPC_WEB_ENABLED", false),
+			GRPCWebAllowedOrigins: getListEnv("GRPC_WEB_ALLOWED_ORIGINS"),
+			DashboardEnabled:      getBoolEnv("DASHBOARD_ENABLED", false),
+			MetricsEnabled:        getBoolEnv("METRICS_ENABLED", true),
+			PromSDEnabled:         getBoolEnv("PROMSD_ENABLED", false),
+			SchedulerEnabled:      getBoolEnv("SCHEDULER_ENABLED", true),
+			SchedulerWorkers:      getIntEnv("SCHEDULER_WORKERS", 4),
+			CheckResultRetention:  getIntEnv("CHECK_RESULT_RETENTION", 0),
+			LeaderElection:        getBoolEnv("LEADER_ELECTION", false),
+			LeaseDuration:         getIntEnv("LEADER_LEASE_DURATION", 15),
+			ShardingEnabled:       getBoolEnv("SHARDING_ENABLED", false),
+			MemberTimeout:         getIntEnv("MEMBER_TIMEOUT", 15),
+			InstanceName:          getEnv("INSTANCE_NAME", defaultInstanceName(port)),
+			ProbeLocation:         getEnv("PROBE_LOCATION", "local"),
+			ProbeQuorum:           getIntEnv("PROBE_QUORUM", 0),
+			XDSEnabled:            getBoolEnv("XDS_ENABLED", false),
+			XDSGroupLabel:         getEnv("XDS_GROUP_LABEL", ""),
+			ShutdownDelay:         getIntEnv("SHUTDOWN_DELAY", 0),
+			AdminToken:            getEnv("ADMIN_TOKEN", ""),
+			APITokens:             getTokenMapEnv("API_TOKENS"),
+			AgentTokens:           getTokenMapEnv("AGENT_TOKENS"),
 		},
 		Database: database.Config{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -35,13 +190,53 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", "watchdog123"),
 			Database: getEnv("DB_DATABASE", "watchdog_db"),
 		},
+		StatusPage: StatusPageConfig{
+			Port:           getIntEnv("STATUS_PAGE_PORT", 0),
+			Title:          getEnv("STATUS_PAGE_TITLE", "Service Status"),
+			ComponentsFile: getEnv("STATUS_PAGE_COMPONENTS", "status-components.json"),
+			BaseURL:        strings.TrimSuffix(getEnv("STATUS_PAGE_URL", ""), "/"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			Insecure:    getBoolEnv("OTEL_EXPORTER_OTLP_INSECURE", false),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "watchdog"),
+			SampleRatio: getFloatEnv("TRACING_SAMPLE_RATIO", 1),
+		},
+		Logging: logging.Config{
+			Level:  getEnv("LOG_LEVEL", "info"),
+			Format: getEnv("LOG_FORMAT", "text"),
+			Levels: getKeyValueEnv("LOG_LEVELS"),
+		},
+		Federation: FederationConfig{
+			Name:           getEnv("FEDERATION_NAME", "local"),
+			Clusters:       getKeyValueEnv("FEDERATION_CLUSTERS"),
+			Token:          getEnv("FEDERATION_TOKEN", ""),
+			TLS:            getBoolEnv("FEDERATION_TLS", false),
+			PlaintextToken: getBoolEnv("FEDERATION_PLAINTEXT_TOKEN", false),
+			PollInterval:   getIntEnv("FEDERATION_POLL_INTERVAL", 15),
+		},
+		DNS: DNSConfig{
+			Port:             getIntEnv("DNS_PORT", 0),
+			Address:          getEnv("DNS_ADDRESS", ""),
+			Domain:           getEnv("DNS_DOMAIN", "watchdog"),
+			TTL:              getIntEnv("DNS_TTL", 5),
+			NegativeTTL:      getIntEnv("DNS_NEGATIVE_TTL", 5),
+			IncludeUnhealthy: getBoolEnv("DNS_INCLUDE_UNHEALTHY", false),
+			Upstream:         getEnv("DNS_UPSTREAM", ""),
+			ForwardNetworks:  getListEnv("DNS_FORWARD_NETWORKS"),
+		},
 	}
 }
 
-// LoadWithEntClient loads config and creates EntClient with auto-migration
+// LoadWithEntClient loads config, sets up logging and creates EntClient
+// with auto-migration
 func LoadWithEntClient() (*Config, *database.EntClient, error) {
 	config := Load()
 
+	if err := logging.Setup(os.Stderr, config.Logging); err != nil {
+		return nil, nil, fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
 	entClient, err := database.NewEntClient(config.Database)
 	if err != nil {
 		return nil, nil, err
@@ -57,6 +252,16 @@ func LoadWithEntClient() (*Config, *database.EntClient, error) {
 	return config, entClient, nil
 }
 
+// defaultInstanceName names the replica after its host and gRPC port, which
+// is unique among replicas unless they run in identical containers
+func defaultInstanceName(port int) string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	return fmt.Sprintf("%s:%d", hostname, port)
+}
+
 func loadEnvFile() {
 	envFiles := []string{
 		".env.local",
@@ -86,16 +291,16 @@ func loadEnvFile() {
 			if fileExists(fullPath) {
 				err := godotenv.Load(fullPath)
 				if err != nil {
-					log.Printf("Warning: Error loading %s file: %v", fullPath, err)
+					logger.Warn("failed to load env file", "path", fullPath, "error", err)
 				} else {
-					log.Printf("Loaded environment variables from %s", fullPath)
+					logger.Info("loaded env file", "path", fullPath)
 					return
 				}
 			}
 		}
 	}
 
-	log.Println("No .env file found, using environment variables and defaults")
+	logger.Info("no env file found, using environment variables and defaults")
 }
 
 func fileExists(filename string) bool {
@@ -118,3 +323,68 @@ func getIntEnv(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getListEnv parses a comma-separated list, skipping empty entries
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getTokenMapEnv parses a "name:token,name:token" list into a token to name map
+func getTokenMapEnv(key string) map[string]string {
+	tokens := make(map[string]string)
+
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		name, token, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found || name == "" || token == "" {
+			if entry != "" {
+				logger.Warn("ignoring malformed entry", "variable", key, "entry", entry)
+			}
+			continue
+		}
+		tokens[token] = name
+	}
+
+	return tokens
+}
+
+// getKeyValueEnv parses a "key=value,key=value" list
+func getKeyValueEnv(key string) map[string]string {
+	values := make(map[string]string)
+
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" || value == "" {
+			if entry != "" {
+				logger.Warn("ignoring malformed entry", "variable", key, "entry", entry)
+			}
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return values
+}
@@ -0,0 +1,433 @@
+package dashboard
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/gateway"
+	"watchdog/logging"
+	"watchdog/server"
+)
+
+var logger = logging.For("dashboard")
+
+// Prefix is the path the dashboard is mounted at
+const Prefix = "/ui/"
+
+const (
+	tokenCookie = "watchdog_token"
+	csrfCookie  = "watchdog_csrf"
+	csrfField   = "csrf_token"
+
+	historyLimit = 50
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// Dashboard is the operator web UI. Every page is rendered from the RPC
+// handlers of the watchdog server, called with the same credentials an API
+// client would send, so authorization and auditing match the gRPC API.
+type Dashboard struct {
+	srv    *server.WatchdogServer
+	caller *server.HTTPCaller
+	pages  map[string]*template.Template
+	mux    *http.ServeMux
+}
+
+// New creates the dashboard on top of the watchdog RPC handlers, called
+// through caller
+func New(srv *server.WatchdogServer, caller *server.HTTPCaller) (*Dashboard, error) {
+	d := &Dashboard{
+		srv:    srv,
+		caller: caller,
+		pages:  make(map[string]*template.Template),
+		mux:    http.NewServeMux(),
+	}
+
+	funcs := template.FuncMap{
+		"unixTime":    formatUnix,
+		"statusClass": statusClass,
+		"typeName":    typeName,
+		"labelString": labelString,
+	}
+	for _, page := range []string{"index", "service", "edit", "unregister", "login", "error"} {
+		tmpl, err := template.New("layout.html").Funcs(funcs).
+			ParseFS(templateFS, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", page, err)
+		}
+		d.pages[page] = tmpl
+	}
+
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	d.mux.Handle("GET /ui/static/", http.StripPrefix("/ui/static/", http.FileServerFS(static)))
+	d.mux.HandleFunc("GET /ui/{$}", d.handleIndex)
+	d.mux.HandleFunc("GET /ui/services/{id}", d.handleService)
+	d.mux.HandleFunc("POST /ui/services/{id}/check", d.handleCheck)
+	d.mux.HandleFunc("GET /ui/services/{id}/edit", d.handleEditForm)
+	d.mux.HandleFunc("POST /ui/services/{id}/edit", d.handleEdit)
+	d.mux.HandleFunc("GET /ui/services/{id}/unregister", d.handleUnregisterForm)
+	d.mux.HandleFunc("POST /ui/services/{id}/unregister", d.handleUnregister)
+	d.mux.HandleFunc("GET /ui/login", d.handleLoginForm)
+	d.mux.HandleFunc("POST /ui/login", d.handleLogin)
+	d.mux.HandleFunc("POST /ui/logout", d.handleLogout)
+
+	return d, nil
+}
+
+// ServeHTTP serves the dashboard pages
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'self'")
+
+	if r.Method == http.MethodPost && !validCSRF(r) {
+		http.Error(w, "invalid or missing CSRF token", http.StatusForbidden)
+		return
+	}
+
+	d.mux.ServeHTTP(w, r)
+}
+
+// call runs an RPC handler with the session cookie converted into the
+// bearer token the handlers authenticate, exactly as if the browser had
+// called the API
+func call[Req, Resp any](d *Dashboard, w http.ResponseWriter, r *http.Request, method string, handler func(context.Context, Req) (Resp, error), req Req) (Resp, error) {
+	if r.Header.Get("Authorization") == "" {
+		if cookie, err := r.Cookie(tokenCookie); err == nil && cookie.Value != "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+cookie.Value)
+		}
+	}
+	return server.CallHTTP(d.caller, w, r, method, handler, req)
+}
+
+// pageData is passed to every template
+type pageData struct {
+	Title     string
+	CSRF      string
+	LoggedIn  bool
+	Flash     string
+	Namespace string
+	Data      any
+}
+
+func (d *Dashboard) render(w http.ResponseWriter, r *http.Request, page, title string, data any) {
+	d.renderStatus(w, r, http.StatusOK, page, title, data)
+}
+
+func (d *Dashboard) renderStatus(w http.ResponseWriter, r *http.Request, code int, page, title string, data any) {
+	_, cookieErr := r.Cookie(tokenCookie)
+
+	var buf strings.Builder
+	err := d.pages[page].Execute(&buf, pageData{
+		Title:     title,
+		CSRF:      csrfToken(w, r),
+		LoggedIn:  cookieErr == nil,
+		Flash:     r.URL.Query().Get("flash"),
+		Namespace: r.URL.Query().Get("namespace"),
+		Data:      data,
+	})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "failed to render dashboard page", "page", page, "error", err)
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(buf.String()))
+}
+
+// renderError shows an RPC error with the HTTP status the gateway would use
+func (d *Dashboard) renderError(w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	d.renderStatus(w, r, gateway.HTTPStatusFromCode(st.Code()), "error", "Error", st.Message())
+}
+
+// redirect sends the browser to path, keeping the namespace and adding a flash message
+func redirect(w http.ResponseWriter, r *http.Request, path, flash string) {
+	query := url.Values{}
+	if namespace := r.FormValue("namespace"); namespace != "" {
+		query.Set("namespace", namespace)
+	}
+	if flash != "" {
+		query.Set("flash", flash)
+	}
+
+	target := path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+type indexData struct {
+	Services      []*api.ServiceInfo
+	Namespaces    []*api.NamespaceInfo
+	AllNamespaces bool
+}
+
+func (d *Dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
+	allNamespaces := r.URL.Query().Get("all") == "1"
+
+	resp, err := call(d, w, r, "ListServices", d.srv.ListServices, &api.ListServicesRequest{
+		Namespace:     r.URL.Query().Get("namespace"),
+		AllNamespaces: allNamespaces,
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	services := resp.Services
+	sort.SliceStable(services, func(i, j int) bool {
+		if services[i].Namespace != services[j].Namespace {
+			return services[i].Namespace < services[j].Namespace
+		}
+		return services[i].Name < services[j].Name
+	})
+
+	namespaces, err := call(d, w, r, "ListNamespaces", d.srv.ListNamespaces, &api.ListNamespacesRequest{})
+	if err != nil {
+		logger.ErrorContext(r.Context(), "failed to list namespaces", "error", err)
+		namespaces = &api.ListNamespacesResponse{}
+	}
+
+	d.render(w, r, "index", "Services", indexData{
+		Services:      services,
+		Namespaces:    namespaces.Namespaces,
+		AllNamespaces: allNamespaces,
+	})
+}
+
+type serviceData struct {
+	Service   *api.ServiceInfo
+	Results   []*api.CheckResult
+	Latency   latencyChart
+	Namespace string
+}
+
+func (d *Dashboard) handleService(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+
+	service, err := call(d, w, r, "GetService", d.srv.GetService, &api.GetServiceRequest{
+		ServiceId: r.PathValue("id"),
+		Namespace: namespace,
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	history, err := call(d, w, r, "ListCheckResults", d.srv.ListCheckResults, &api.ListCheckResultsRequest{
+		ServiceId: service.Id,
+		Namespace: namespace,
+		Limit:     historyLimit,
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	d.render(w, r, "service", service.Name, serviceData{
+		Service:   service,
+		Results:   history.Results,
+		Latency:   newLatencyChart(history.Results),
+		Namespace: service.Namespace,
+	})
+}
+
+func (d *Dashboard) handleCheck(w http.ResponseWriter, r *http.Request) {
+	resp, err := call(d, w, r, "CheckServiceHealth", d.srv.CheckServiceHealth, &api.CheckServiceHealthRequest{
+		ServiceId: r.PathValue("id"),
+		Namespace: r.FormValue("namespace"),
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	redirect(w, r, Prefix+"services/"+url.PathEscape(r.PathValue("id")),
+		fmt.Sprintf("Check finished: %s (%s)", resp.Status, resp.Message))
+}
+
+type editData struct {
+	Service *api.ServiceInfo
+	Types   []string
+	Labels  string
+}
+
+func (d *Dashboard) handleEditForm(w http.ResponseWriter, r *http.Request) {
+	service, err := call(d, w, r, "GetService", d.srv.GetService, &api.GetServiceRequest{
+		ServiceId: r.PathValue("id"),
+		Namespace: r.URL.Query().Get("namespace"),
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	d.render(w, r, "edit", "Edit "+service.Name, editData{
+		Service: service,
+		Types:   serviceTypeNames(),
+		Labels:  labelString(service.Labels),
+	})
+}
+
+func (d *Dashboard) handleEdit(w http.ResponseWriter, r *http.Request) {
+	checkInterval, err := strconv.Atoi(strings.TrimSpace(r.FormValue("check_interval")))
+	if err != nil && r.FormValue("check_interval") != "" {
+		d.renderStatus(w, r, http.StatusBadRequest, "error", "Error", "check interval must be a number of seconds")
+		return
+	}
+
+	labels, err := parseLabels(r.FormValue("labels"))
+	if err != nil {
+		d.renderStatus(w, r, http.StatusBadRequest, "error", "Error", err.Error())
+		return
+	}
+
+	_, err = call(d, w, r, "UpdateService", d.srv.UpdateService, &api.UpdateServiceRequest{
+		ServiceId:            r.PathValue("id"),
+		Namespace:            r.FormValue("namespace"),
+		Name:                 strings.TrimSpace(r.FormValue("name")),
+		Endpoint:             strings.TrimSpace(r.FormValue("endpoint")),
+		Status:               strings.TrimSpace(r.FormValue("status")),
+		Type:                 api.ServiceType(api.ServiceType_value[r.FormValue("type")]),
+		CheckIntervalSeconds: int32(checkInterval),
+		Labels:               labels,
+		ClearLabels:          len(labels) == 0,
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	redirect(w, r, Prefix+"services/"+url.PathEscape(r.PathValue("id")), "Service updated")
+}
+
+func (d *Dashboard) handleUnregisterForm(w http.ResponseWriter, r *http.Request) {
+	service, err := call(d, w, r, "GetService", d.srv.GetService, &api.GetServiceRequest{
+		ServiceId: r.PathValue("id"),
+		Namespace: r.URL.Query().Get("namespace"),
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	d.render(w, r, "unregister", "Unregister "+service.Name, service)
+}
+
+func (d *Dashboard) handleUnregister(w http.ResponseWriter, r *http.Request) {
+	// The confirmation page requires typing the service name
+	if r.FormValue("confirm") != r.FormValue("expected") || r.FormValue("expected") == "" {
+		redirect(w, r, Prefix+"services/"+url.PathEscape(r.PathValue("id"))+"/unregister",
+			"The name did not match, the service was not unregistered")
+		return
+	}
+
+	_, err := call(d, w, r, "UnregisterService", d.srv.UnregisterService, &api.UnregisterServiceRequest{
+		ServiceId: r.PathValue("id"),
+		Namespace: r.FormValue("namespace"),
+	})
+	if err != nil {
+		d.renderError(w, r, err)
+		return
+	}
+
+	redirect(w, r, Prefix, fmt.Sprintf("Service %s unregistered", r.FormValue("expected")))
+}
+
+func (d *Dashboard) handleLoginForm(w http.ResponseWriter, r *http.Request) {
+	d.render(w, r, "login", "Sign in", nil)
+}
+
+func (d *Dashboard) handleLogin(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.FormValue("token"))
+	if token == "" {
+		redirect(w, r, Prefix+"login", "Enter an API or admin token")
+		return
+	}
+	if !d.srv.KnownToken(token) {
+		logger.Warn("dashboard sign-in with an unknown token", "peer", r.RemoteAddr)
+		redirect(w, r, Prefix+"login", "Unknown token")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookie,
+		Value:    token,
+		Path:     Prefix,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int((12 * time.Hour).Seconds()),
+	})
+	redirect(w, r, Prefix, "Signed in")
+}
+
+func (d *Dashboard) handleLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookie,
+		Path:     Prefix,
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+	redirect(w, r, Prefix, "Signed out")
+}
+
+// csrfToken returns the double-submit token of the browser, issuing one if needed
+func csrfToken(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(csrfCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	token := hex.EncodeToString(buf)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookie,
+		Value:    token,
+		Path:     Prefix,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token
+}
+
+// validCSRF checks that the form token matches the cookie token
+func validCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(csrfCookie)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.FormValue(csrfField))) == 1
+}
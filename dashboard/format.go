@@ -0,0 +1,117 @@
+package dashboard
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"watchdog/api"
+)
+
+// formatUnix renders a Unix timestamp, or a dash for the zero value
+func formatUnix(ts int64) string {
+	if ts == 0 {
+		return "—"
+	}
+	return time.Unix(ts, 0).UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// statusClass maps a service or check status onto a CSS class
+func statusClass(status string) string {
+	switch status {
+	case "healthy", "active":
+		return "ok"
+	case "unhealthy":
+		return "bad"
+	case "":
+		return "none"
+	default:
+		return "warn"
+	}
+}
+
+// typeName shortens SERVICE_TYPE_HTTP to HTTP
+func typeName(serviceType api.ServiceType) string {
+	return strings.TrimPrefix(serviceType.String(), "SERVICE_TYPE_")
+}
+
+// serviceTypeNames lists the selectable service types in enum order
+func serviceTypeNames() []string {
+	var names []string
+	for i := int32(1); i < int32(len(api.ServiceType_name)); i++ {
+		names = append(names, api.ServiceType_name[i])
+	}
+	return names
+}
+
+// labelString renders labels as sorted "key=value" pairs
+func labelString(labels map[string]string) string {
+	var pairs []string
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// parseLabels parses comma or newline separated "key=value" pairs
+func parseLabels(value string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("label %q must be written as key=value", pair)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return labels, nil
+}
+
+const (
+	chartWidth  = 600
+	chartHeight = 120
+)
+
+// latencyChart is a pre-computed SVG polyline of check latencies, oldest first
+type latencyChart struct {
+	Width  int
+	Height int
+	Points string
+	Max    int64
+}
+
+func newLatencyChart(results []*api.CheckResult) latencyChart {
+	chart := latencyChart{Width: chartWidth, Height: chartHeight}
+	if len(results) == 0 {
+		return chart
+	}
+
+	for _, result := range results {
+		chart.Max = max(chart.Max, result.LatencyMs)
+	}
+	scale := float64(chart.Max)
+	if scale == 0 {
+		scale = 1
+	}
+
+	step := float64(chartWidth)
+	if len(results) > 1 {
+		step = float64(chartWidth) / float64(len(results)-1)
+	}
+
+	// Results are newest first, the chart reads left to right
+	points := make([]string, 0, len(results))
+	for i := len(results) - 1; i >= 0; i-- {
+		x := float64(len(results)-1-i) * step
+		y := chartHeight - float64(results[i].LatencyMs)/scale*(chartHeight-10)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	chart.Points = strings.Join(points, " ")
+
+	return chart
+}
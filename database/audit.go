@@ -0,0 +1,470 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"watchdog/ent"
+	"watchdog/ent/auditevent"
+	"watchdog/ent/audithead"
+	"watchdog/ent/service"
+)
+
+// AuditEventRecord represents an audit event record in the database
+type AuditEventRecord = ent.AuditEvent
+
+// AuditFilter narrows down ListAuditEvents results. Zero values match everything.
+type AuditFilter struct {
+	Namespace string
+	ServiceID int64
+	Actor     string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// AuditVerification is the result of walking the audit hash chain
+type AuditVerification struct {
+	Intact        bool
+	CheckedEvents int
+	// FirstBrokenID is the first event whose hash or link does not match, 0 if intact
+	FirstBrokenID int64
+}
+
+// Actor identifies the caller responsible for a mutation
+type Actor struct {
+	Name      string
+	Peer      string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor attaches the caller identity recorded in audit events to ctx
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the caller attached by WithActor, or a system actor
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{Name: "system"}
+}
+
+// auditHeadID is the ID of the single chain head row
+const auditHeadID = 1
+
+// auditedEntities maps the audited ent types to the entity recorded in their
+// events. Check results, schedules, leases and members are bookkeeping of
+// the server itself and are not audited.
+var auditedEntities = map[string]auditevent.Entity{
+	ent.TypeService:      auditevent.EntityService,
+	ent.TypeNamespace:    auditevent.EntityNamespace,
+	ent.TypeIncident:     auditevent.EntityIncident,
+	ent.TypeStatusUpdate: auditevent.EntityStatusUpdate,
+}
+
+// auditedMutation is implemented by the generated mutations of every entity
+type auditedMutation interface {
+	ent.Mutation
+	Client() *ent.Client
+	Tx() (*ent.Tx, error)
+	ID() (int64, bool)
+}
+
+// auditHook records an audit event for every create, update and delete of
+// an audited entity. It runs in the transaction of the mutation, so the
+// event and the change commit together.
+func auditHook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			entity, ok := auditedEntities[m.Type()]
+			if !ok {
+				return next.Mutate(ctx, m)
+			}
+			am, ok := m.(auditedMutation)
+			if !ok {
+				return nil, fmt.Errorf("audit: unexpected mutation %T", m)
+			}
+			if _, err := am.Tx(); err != nil {
+				return nil, fmt.Errorf("audit: %s mutations must run in a transaction", m.Type())
+			}
+
+			var action auditevent.Action
+			switch {
+			case m.Op().Is(ent.OpCreate):
+				action = auditevent.ActionCreate
+			case m.Op().Is(ent.OpUpdateOne):
+				action = auditevent.ActionUpdate
+			case m.Op().Is(ent.OpDeleteOne):
+				action = auditevent.ActionDelete
+			default:
+				return nil, fmt.Errorf("audit: bulk %s mutations are not supported", m.Type())
+			}
+
+			if action == auditevent.ActionUpdate && heartbeatOnly(m) {
+				return next.Mutate(ctx, m)
+			}
+
+			var before any
+			if id, ok := am.ID(); ok && action != auditevent.ActionCreate {
+				var err error
+				before, err = getEntity(ctx, am.Client(), m.Type(), id)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			var after any
+			if action != auditevent.ActionDelete {
+				after = value
+			}
+
+			if err := writeAuditEvent(ctx, am.Client(), entity, action, before, after); err != nil {
+				return nil, fmt.Errorf("audit: %w", err)
+			}
+
+			return value, nil
+		})
+	}
+}
+
+// getEntity loads an audited entity by ID
+func getEntity(ctx context.Context, client *ent.Client, typ string, id int64) (any, error) {
+	switch typ {
+	case ent.TypeService:
+		return client.Service.Get(ctx, id)
+	case ent.TypeNamespace:
+		return client.Namespace.Get(ctx, id)
+	case ent.TypeIncident:
+		return client.Incident.Get(ctx, id)
+	case ent.TypeStatusUpdate:
+		return client.StatusUpdate.Get(ctx, id)
+	}
+	return nil, fmt.Errorf("unexpected entity %s", typ)
+}
+
+// auditSubject returns the service ID, entity ID and namespace recorded for
+// an entity
+func auditSubject(v any) (serviceID, entityID int64, namespace string) {
+	switch v := v.(type) {
+	case *ent.Service:
+		return v.ID, 0, v.Namespace
+	case *ent.Namespace:
+		return 0, v.ID, v.Name
+	case *ent.Incident:
+		return 0, v.ID, ""
+	case *ent.StatusUpdate:
+		return 0, v.ID, ""
+	}
+	return 0, 0, ""
+}
+
+// heartbeatOnly reports whether a service update only refreshes the
+// heartbeat, which services send every few seconds and is not worth an
+// audit event
+func heartbeatOnly(m ent.Mutation) bool {
+	if m.Type() != ent.TypeService {
+		return false
+	}
+	for _, field := range m.Fields() {
+		if field != service.FieldLastHeartbeat && field != service.FieldUpdatedAt {
+			return false
+		}
+	}
+	return len(m.ClearedFields()) == 0
+}
+
+// writeAuditEvent appends an event to the hash chain
+func writeAuditEvent(ctx context.Context, client *ent.Client, entity auditevent.Entity, action auditevent.Action, before, after any) error {
+	actor := ActorFromContext(ctx)
+
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+	serviceID, entityID, namespace := auditSubject(subject)
+
+	beforeFields, err := entitySnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterFields, err := entitySnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	// Lock the chain head so concurrent writers append one after another.
+	// Locking the latest event instead would not serialize the first ones.
+	head, err := client.AuditHead.Query().
+		Where(audithead.ID(auditHeadID)).
+		ForUpdate().
+		Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read audit chain head: %w", err)
+	}
+
+	event := &ent.AuditEvent{
+		ServiceID: serviceID,
+		Entity:    entity,
+		EntityID:  entityID,
+		Namespace: namespace,
+		Action:    action,
+		Actor:     actor.Name,
+		Peer:      actor.Peer,
+		RequestID: actor.RequestID,
+		Before:    beforeFields,
+		After:     afterFields,
+		Changes:   diffSnapshots(beforeFields, afterFields),
+		PrevHash:  head.Hash,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	hash, err := auditEventHash(event)
+	if err != nil {
+		return err
+	}
+
+	err = client.AuditEvent.Create().
+		SetServiceID(event.ServiceID).
+		SetEntity(event.Entity).
+		SetEntityID(event.EntityID).
+		SetNamespace(event.Namespace).
+		SetAction(event.Action).
+		SetActor(event.Actor).
+		SetPeer(event.Peer).
+		SetRequestID(event.RequestID).
+		SetBefore(event.Before).
+		SetAfter(event.After).
+		SetChanges(event.Changes).
+		SetPrevHash(event.PrevHash).
+		SetHash(hash).
+		SetCreatedAt(event.CreatedAt).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.AuditHead.UpdateOneID(auditHeadID).SetHash(hash).Exec(ctx)
+}
+
+// ensureAuditHead creates the chain head row, pointing at the latest event
+// written before the row existed
+func (db *EntClient) ensureAuditHead(ctx context.Context) error {
+	exists, err := db.client.AuditHead.Query().
+		Where(audithead.ID(auditHeadID)).
+		Exist(ctx)
+	if err != nil || exists {
+		return err
+	}
+
+	hash := ""
+	latest, err := db.client.AuditEvent.Query().
+		Order(ent.Desc(auditevent.FieldID)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return err
+	}
+	if latest != nil {
+		hash = latest.Hash
+	}
+
+	err = db.client.AuditHead.Create().
+		SetID(auditHeadID).
+		SetHash(hash).
+		Exec(ctx)
+	// Another replica migrating at the same time created it first
+	if ent.IsConstraintError(err) {
+		return nil
+	}
+	return err
+}
+
+// entitySnapshot converts an entity into the generic form stored in JSON
+// columns, so the hash computed on write matches the one computed on read
+func entitySnapshot(entity any) (map[string]any, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot map[string]any
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	// Bookkeeping timestamps change on every write and carry no meaning
+	// here, and edges are not loaded
+	delete(snapshot, "updated_at")
+	delete(snapshot, "edges")
+
+	return snapshot, nil
+}
+
+// diffSnapshots maps every changed field to its old and new value
+func diffSnapshots(before, after map[string]any) map[string]any {
+	changes := map[string]any{}
+
+	for field, oldValue := range before {
+		newValue, ok := after[field]
+		if !ok || fmt.Sprint(oldValue) != fmt.Sprint(newValue) {
+			changes[field] = map[string]any{"from": oldValue, "to": newValue}
+		}
+	}
+	for field, newValue := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = map[string]any{"from": nil, "to": newValue}
+		}
+	}
+
+	return changes
+}
+
+// auditEventHash computes the chain hash of an event from all of its fields
+// but the ID, which is assigned on insert, and the hash itself. encoding/json
+// sorts map keys, which makes the encoding canonical.
+func auditEventHash(event *ent.AuditEvent) (string, error) {
+	fields := []any{
+		event.PrevHash,
+		string(event.Entity),
+		event.EntityID,
+		event.ServiceID,
+		event.Namespace,
+		string(event.Action),
+		event.Actor,
+		event.Peer,
+		event.RequestID,
+		event.Before,
+		event.After,
+		event.Changes,
+		event.CreatedAt.Unix(),
+	}
+
+	content, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// withTx runs fn in a transaction, committing on success
+func (db *EntClient) withTx(ctx context.Context, fn func(tx *ent.Tx) error) error {
+	tx, err := db.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rerr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents lists audit events, newest first
+func (db *EntClient) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]AuditEventRecord, error) {
+	ctx, finish := startCall(ctx, "ListAuditEvents")
+	defer finish()
+
+	query := db.client.AuditEvent.Query()
+	if filter.Namespace != "" {
+		query = query.Where(auditevent.Namespace(filter.Namespace))
+	}
+	if filter.ServiceID != 0 {
+		query = query.Where(auditevent.ServiceID(filter.ServiceID))
+	}
+	if filter.Actor != "" {
+		query = query.Where(auditevent.Actor(filter.Actor))
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where(auditevent.CreatedAtGTE(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where(auditevent.CreatedAtLT(filter.Until))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	entEvents, err := query.
+		Order(ent.Desc(auditevent.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	events := make([]AuditEventRecord, len(entEvents))
+	for i, entEvent := range entEvents {
+		events[i] = *entEvent
+	}
+
+	return events, nil
+}
+
+// VerifyAuditChain recomputes every hash of the audit chain in order and
+// reports the first event that was modified, removed or reordered
+func (db *EntClient) VerifyAuditChain(ctx context.Context) (AuditVerification, error) {
+	ctx, finish := startCall(ctx, "VerifyAuditChain")
+	defer finish()
+
+	const batchSize = 500
+
+	result := AuditVerification{Intact: true}
+	prevHash := ""
+	var lastID int64
+
+	for {
+		batch, err := db.client.AuditEvent.Query().
+			Where(auditevent.IDGT(lastID)).
+			Order(ent.Asc(auditevent.FieldID)).
+			Limit(batchSize).
+			All(ctx)
+		if err != nil {
+			return result, fmt.Errorf("failed to read audit events: %w", err)
+		}
+
+		for _, event := range batch {
+			hash, err := auditEventHash(event)
+			if err != nil {
+				return result, err
+			}
+			if event.PrevHash != prevHash || event.Hash != hash {
+				result.Intact = false
+				result.FirstBrokenID = event.ID
+				return result, nil
+			}
+
+			prevHash = event.Hash
+			lastID = event.ID
+			result.CheckedEvents++
+		}
+
+		if len(batch) < batchSize {
+			return result, nil
+		}
+	}
+}
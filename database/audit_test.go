@@ -0,0 +1,67 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"watchdog/ent"
+	"watchdog/ent/auditevent"
+)
+
+func TestAuditEventHashCoversEveryField(t *testing.T) {
+	base := func() *ent.AuditEvent {
+		return &ent.AuditEvent{
+			ServiceID: 1,
+			Entity:    auditevent.EntityService,
+			EntityID:  1,
+			Namespace: "default",
+			Action:    auditevent.ActionUpdate,
+			Actor:     "ci",
+			Peer:      "10.0.0.1:5000",
+			RequestID: "req-1",
+			Before:    map[string]any{"status": "active"},
+			After:     map[string]any{"status": "maintenance"},
+			Changes:   map[string]any{"status": map[string]any{"from": "active", "to": "maintenance"}},
+			PrevHash:  "abc",
+			CreatedAt: time.Unix(1700000000, 0).UTC(),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*ent.AuditEvent)
+	}{
+		{name: "entity", modify: func(e *ent.AuditEvent) { e.Entity = auditevent.EntityNamespace }},
+		{name: "entity id", modify: func(e *ent.AuditEvent) { e.EntityID = 2 }},
+		{name: "service id", modify: func(e *ent.AuditEvent) { e.ServiceID = 2 }},
+		{name: "namespace", modify: func(e *ent.AuditEvent) { e.Namespace = "payments" }},
+		{name: "action", modify: func(e *ent.AuditEvent) { e.Action = auditevent.ActionDelete }},
+		{name: "actor", modify: func(e *ent.AuditEvent) { e.Actor = "admin" }},
+		{name: "peer", modify: func(e *ent.AuditEvent) { e.Peer = "10.0.0.2:5000" }},
+		{name: "request id", modify: func(e *ent.AuditEvent) { e.RequestID = "req-2" }},
+		{name: "before", modify: func(e *ent.AuditEvent) { e.Before = map[string]any{"status": "draining"} }},
+		{name: "after", modify: func(e *ent.AuditEvent) { e.After = map[string]any{"status": "active"} }},
+		{name: "changes", modify: func(e *ent.AuditEvent) { e.Changes = map[string]any{} }},
+		{name: "previous hash", modify: func(e *ent.AuditEvent) { e.PrevHash = "abd" }},
+		{name: "created at", modify: func(e *ent.AuditEvent) { e.CreatedAt = e.CreatedAt.Add(time.Second) }},
+	}
+
+	want, err := auditEventHash(base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := base()
+			tt.modify(event)
+			got, err := auditEventHash(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == want {
+				t.Errorf("changing the %s keeps the hash", tt.name)
+			}
+		})
+	}
+}
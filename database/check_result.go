@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"watchdog/ent"
+	"watchdog/ent/checkresult"
+	"watchdog/ent/schema"
+)
+
+// CheckResultRecord represents a check result record in the database
+type CheckResultRecord = ent.CheckResult
+
+// LocationResult is the outcome of a check at one probe location
+type LocationResult = schema.LocationResult
+
+// DailyCheckCount counts the checks of a service on one day
+type DailyCheckCount struct {
+	ServiceID int64
+	// Day is midnight UTC of the counted day
+	Day     time.Time
+	Healthy int
+	Total   int
+}
+
+// RecordCheckResult stores the outcome of a health check
+func (db *EntClient) RecordCheckResult(ctx context.Context, result CheckResultRecord) error {
+	ctx, finish := startCall(ctx, "RecordCheckResult")
+	defer finish()
+
+	create := db.client.CheckResult.Create().
+		SetServiceID(result.ServiceID).
+		SetStatus(result.Status).
+		SetMessage(truncate(result.Message, 1000)).
+		SetLatencyMs(result.LatencyMs)
+
+	if !result.CheckedAt.IsZero() {
+		create.SetCheckedAt(result.CheckedAt)
+	}
+	if len(result.Locations) > 0 {
+		create.SetLocations(result.Locations)
+	}
+
+	if err := create.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record check result: %w", err)
+	}
+
+	db.changes.notify()
+	return nil
+}
+
+// PruneCheckResults deletes up to limit of the oldest check results recorded
+// before the given time and returns how many were deleted
+func (db *EntClient) PruneCheckResults(ctx context.Context, before time.Time, limit int) (int, error) {
+	ctx, finish := startCall(ctx, "PruneCheckResults")
+	defer finish()
+
+	ids, err := db.client.CheckResult.Query().
+		Where(checkresult.CheckedAtLT(before)).
+		Order(ent.Asc(checkresult.FieldCheckedAt)).
+		Limit(limit).
+		IDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired check results: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := db.client.CheckResult.Delete().
+		Where(checkresult.IDIn(ids...)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune check results: %w", err)
+	}
+	return deleted, nil
+}
+
+// ListCheckResults lists the check results of a service, newest first
+func (db *EntClient) ListCheckResults(ctx context.Context, serviceID int64, since time.Time, limit int) ([]CheckResultRecord, error) {
+	ctx, finish := startCall(ctx, "ListCheckResults")
+	defer finish()
+
+	query := db.client.CheckResult.Query().
+		Where(checkresult.ServiceID(serviceID))
+	if !since.IsZero() {
+		query = query.Where(checkresult.CheckedAtGTE(since))
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	entResults, err := query.
+		Order(ent.Desc(checkresult.FieldCheckedAt), ent.Desc(checkresult.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check results: %w", err)
+	}
+
+	results := make([]CheckResultRecord, len(entResults))
+	for i, entResult := range entResults {
+		results[i] = *entResult
+	}
+
+	return results, nil
+}
+
+// LatestCheckResults returns the most recent check result of each service
+// that has one, keyed by service ID
+func (db *EntClient) LatestCheckResults(ctx context.Context, serviceIDs []int64) (map[int64]CheckResultRecord, error) {
+	ctx, finish := startCall(ctx, "LatestCheckResults")
+	defer finish()
+
+	latest := make(map[int64]CheckResultRecord)
+	if len(serviceIDs) == 0 {
+		return latest, nil
+	}
+
+	var rows []struct {
+		ServiceID int64 `json:"service_id"`
+		Max       int64 `json:"max"`
+	}
+	err := db.client.CheckResult.Query().
+		Where(checkresult.ServiceIDIn(serviceIDs...)).
+		GroupBy(checkresult.FieldServiceID).
+		Aggregate(ent.Max(checkresult.FieldID)).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest check results: %w", err)
+	}
+
+	resultIDs := make([]int64, len(rows))
+	for i, row := range rows {
+		resultIDs[i] = row.Max
+	}
+
+	entResults, err := db.client.CheckResult.Query().
+		Where(checkresult.IDIn(resultIDs...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load latest check results: %w", err)
+	}
+
+	for _, entResult := range entResults {
+		latest[entResult.ServiceID] = *entResult
+	}
+
+	return latest, nil
+}
+
+// DailyCheckCounts counts the healthy and total checks of each service per
+// day since the given time. The counting is done by the database so long
+// histories do not have to be loaded.
+func (db *EntClient) DailyCheckCounts(ctx context.Context, serviceIDs []int64, since time.Time) ([]DailyCheckCount, error) {
+	ctx, finish := startCall(ctx, "DailyCheckCounts")
+	defer finish()
+
+	if len(serviceIDs) == 0 {
+		return nil, nil
+	}
+
+	day := func(s *sql.Selector) string {
+		expr := fmt.Sprintf("DATE(%s)", s.C(checkresult.FieldCheckedAt))
+		s.GroupBy(expr)
+		return sql.As(expr, "day")
+	}
+
+	var rows []struct {
+		ServiceID int64  `json:"service_id"`
+		Status    string `json:"status"`
+		Day       string `json:"day"`
+		Count     int    `json:"count"`
+	}
+	err := db.client.CheckResult.Query().
+		Where(
+			checkresult.ServiceIDIn(serviceIDs...),
+			checkresult.CheckedAtGTE(since),
+		).
+		GroupBy(checkresult.FieldServiceID, checkresult.FieldStatus).
+		Aggregate(day, ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check results: %w", err)
+	}
+
+	type key struct {
+		serviceID int64
+		day       string
+	}
+	index := make(map[key]int)
+	var result []DailyCheckCount
+	for _, row := range rows {
+		// DATE() is scanned as "2006-01-02" or as a timestamp, depending on the driver
+		if len(row.Day) < len("2006-01-02") {
+			continue
+		}
+		k := key{row.ServiceID, row.Day[:len("2006-01-02")]}
+
+		i, ok := index[k]
+		if !ok {
+			parsed, err := time.Parse("2006-01-02", k.day)
+			if err != nil {
+				continue
+			}
+			result = append(result, DailyCheckCount{ServiceID: row.ServiceID, Day: parsed})
+			i = len(result) - 1
+			index[k] = i
+		}
+
+		result[i].Total += row.Count
+		if row.Status == "healthy" {
+			result[i].Healthy += row.Count
+		}
+	}
+
+	return result, nil
+}
+
+// truncate shortens s to at most n runes so it fits its column
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
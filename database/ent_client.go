@@ -3,19 +3,30 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
+	"sync/atomic"
 	"time"
 
 	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	_ "github.com/go-sql-driver/mysql"
 
 	"watchdog/ent"
+	"watchdog/ent/checkresult"
+	"watchdog/ent/migrate"
+	"watchdog/ent/namespace"
+	"watchdog/ent/schedule"
 	"watchdog/ent/service"
+	"watchdog/logging"
 )
 
+var logger = logging.For("database")
+
 // EntClient wraps the generated Ent client to provide the same interface as the original DB
 type EntClient struct {
-	client *ent.Client
+	client   *ent.Client
+	driver   *entsql.Driver
+	migrated atomic.Bool
+	changes  changeFeed
 }
 
 // Helper function to convert ent.Service to ServiceRecord
@@ -41,25 +52,82 @@ func NewEntClient(config Config) (*EntClient, error) {
 	)
 
 	// Create Ent client with MySQL driver
-	client, err := ent.Open(dialect.MySQL, dsn)
+	driver, err := entsql.Open(dialect.MySQL, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
+	client := ent.NewClient(ent.Driver(driver))
+
+	logger.Info("connected to MySQL", "host", config.Host, "port", config.Port)
 
-	log.Printf("Connected to MySQL database using Ent at %s:%d", config.Host, config.Port)
+	// Time every query and mutation
+	instrument(client)
+	// Record an audit event for every mutation of services, namespaces and
+	// incidents
+	client.Use(auditHook())
 
-	return &EntClient{client: client}, nil
+	return &EntClient{client: client, driver: driver}, nil
 }
 
 // AutoMigrate runs automatic schema migration
 func (db *EntClient) AutoMigrate(ctx context.Context) error {
-	if err := db.client.Schema.Create(ctx); err != nil {
+	// Drop indexes that are no longer in the schema, e.g. the old
+	// (name, endpoint) unique index replaced by (namespace, name, endpoint)
+	if err := db.client.Schema.Create(ctx, migrate.WithDropIndex(true)); err != nil {
 		return fmt.Errorf("failed creating schema resources: %w", err)
 	}
-	log.Println("Database schema migration completed successfully")
+
+	if err := db.ensureAuditHead(ctx); err != nil {
+		return fmt.Errorf("failed to create audit chain head: %w", err)
+	}
+
+	// Existing services are moved into the default namespace by the column
+	// default, so make sure that namespace exists
+	exists, err := db.client.Namespace.Query().
+		Where(namespace.Name(DefaultNamespace)).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up default namespace: %w", err)
+	}
+	if !exists {
+		err = db.withTx(ctx, func(tx *ent.Tx) error {
+			return tx.Namespace.Create().
+				SetName(DefaultNamespace).
+				SetDescription("Default namespace").
+				Exec(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create default namespace: %w", err)
+		}
+	}
+
+	db.migrated.Store(true)
+	logger.InfoContext(ctx, "schema migration completed")
 	return nil
 }
 
+// Migrated reports whether AutoMigrate completed
+func (db *EntClient) Migrated() bool {
+	return db.migrated.Load()
+}
+
+// Ping checks that the database is reachable without running a query. It
+// runs every few seconds for readiness, so it is neither traced nor timed.
+func (db *EntClient) Ping(ctx context.Context) error {
+	return db.driver.DB().PingContext(ctx)
+}
+
+// now reads the clock of the database server. Expiry times replicas compare
+// with each other are computed from it, so their own clocks may drift.
+// UTC_TIMESTAMP matches the UTC times the driver stores.
+func (db *EntClient) now(ctx context.Context) (time.Time, error) {
+	var now time.Time
+	if err := db.driver.DB().QueryRowContext(ctx, "SELECT UTC_TIMESTAMP(6)").Scan(&now); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the database clock: %w", err)
+	}
+	return now, nil
+}
+
 // Close closes the database connection
 func (db *EntClient) Close() error {
 	return db.client.Close()
@@ -67,6 +135,9 @@ func (db *EntClient) Close() error {
 
 // HealthCheck checks if the database connection is healthy
 func (db *EntClient) HealthCheck() error {
+	_, finish := startCall(context.Background(), "HealthCheck")
+	defer finish()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -76,31 +147,85 @@ func (db *EntClient) HealthCheck() error {
 }
 
 // CreateService creates a new service using the generated Ent client
-func (db *EntClient) CreateService(serviceRecord ServiceRecord) (int64, error) {
-	ctx := context.Background()
+func (db *EntClient) CreateService(ctx context.Context, serviceRecord ServiceRecord) (int64, error) {
+	ctx, finish := startCall(ctx, "CreateService")
+	defer finish()
 
 	// Convert ServiceRecord to ent.Service for creation
 	entService := serviceRecordToEnt(serviceRecord)
 
-	created, err := db.client.Service.Create().
-		SetName(entService.Name).
-		SetEndpoint(entService.Endpoint).
-		SetType(entService.Type).
-		SetStatus(entService.Status).
-		SetLastHeartbeat(entService.LastHeartbeat).
-		Save(ctx)
+	namespaceName := entService.Namespace
+	if namespaceName == "" {
+		namespaceName = DefaultNamespace
+	}
+
+	// Service mutations run in a transaction so the audit hook commits with them
+	var created *ent.Service
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		// Lock the namespace so concurrent registrations count each other
+		// against its quota
+		ns, err := tx.Namespace.Query().
+			Where(namespace.Name(namespaceName)).
+			ForUpdate().
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("namespace not found")
+			}
+			return err
+		}
+		if ns.MaxServices > 0 {
+			count, err := tx.Service.Query().
+				Where(service.Namespace(namespaceName)).
+				Count(ctx)
+			if err != nil {
+				return err
+			}
+			if count >= ns.MaxServices {
+				return fmt.Errorf("namespace quota exceeded")
+			}
+		}
+
+		create := tx.Service.Create().
+			SetName(entService.Name).
+			SetEndpoint(entService.Endpoint).
+			SetType(entService.Type).
+			SetStatus(entService.Status).
+			SetLastHeartbeat(entService.LastHeartbeat)
+
+		// Leave schema defaults in place for unset fields
+		if entService.Namespace != "" {
+			create.SetNamespace(entService.Namespace)
+		}
+		if entService.CheckInterval > 0 {
+			create.SetCheckInterval(entService.CheckInterval)
+		}
+		if entService.Labels != nil {
+			create.SetLabels(entService.Labels)
+		}
 
+		created, err = create.Save(ctx)
+		return err
+	})
 	if err != nil {
+		if ent.IsConstraintError(err) {
+			return 0, fmt.Errorf("service already exists")
+		}
+		if msg := err.Error(); msg == "namespace not found" || msg == "namespace quota exceeded" {
+			return 0, err
+		}
 		return 0, fmt.Errorf("failed to create service: %w", err)
 	}
 
-	log.Printf("Service %s created with ID %d", created.Name, created.ID)
+	db.changes.notify()
+	logger.InfoContext(ctx, "service created", "service_id", created.ID, "name", created.Name)
 	return created.ID, nil
 }
 
 // GetService retrieves a service by ID using the generated Ent client
-func (db *EntClient) GetService(serviceID int64) (*ServiceRecord, error) {
-	ctx := context.Background()
+func (db *EntClient) GetService(ctx context.Context, serviceID int64) (*ServiceRecord, error) {
+	ctx, finish := startCall(ctx, "GetService")
+	defer finish()
 
 	entService, err := db.client.Service.Get(ctx, serviceID)
 	if err != nil {
@@ -113,11 +238,18 @@ func (db *EntClient) GetService(serviceID int64) (*ServiceRecord, error) {
 	return entToServiceRecord(entService), nil
 }
 
-// ListServices lists all services using the generated Ent client
-func (db *EntClient) ListServices() ([]ServiceRecord, error) {
-	ctx := context.Background()
+// ListServices lists the services of a namespace using the generated Ent client.
+// An empty namespace lists services across all namespaces.
+func (db *EntClient) ListServices(ctx context.Context, namespace string) ([]ServiceRecord, error) {
+	ctx, finish := startCall(ctx, "ListServices")
+	defer finish()
+
+	query := db.client.Service.Query()
+	if namespace != "" {
+		query = query.Where(service.Namespace(namespace))
+	}
 
-	entServices, err := db.client.Service.Query().
+	entServices, err := query.
 		Order(ent.Desc(service.FieldCreatedAt)).
 		All(ctx)
 	if err != nil {
@@ -133,21 +265,46 @@ func (db *EntClient) ListServices() ([]ServiceRecord, error) {
 	return services, nil
 }
 
+// CountServices counts the services of a namespace.
+// An empty namespace counts services across all namespaces.
+func (db *EntClient) CountServices(ctx context.Context, namespace string) (int, error) {
+	ctx, finish := startCall(ctx, "CountServices")
+	defer finish()
+
+	query := db.client.Service.Query()
+	if namespace != "" {
+		query = query.Where(service.Namespace(namespace))
+	}
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count services: %w", err)
+	}
+
+	return count, nil
+}
+
 // UpdateService updates a service using the generated Ent client
-func (db *EntClient) UpdateService(serviceID int64, newStatus string, name string, serviceType service.Type, endpoint string) error {
-	ctx := context.Background()
+// Empty values and a nil labels map keep the current value.
+func (db *EntClient) UpdateService(ctx context.Context, serviceID int64, newStatus string, name string, serviceType service.Type, endpoint string, checkInterval int, labels map[string]string) error {
+	ctx, finish := startCall(ctx, "UpdateService")
+	defer finish()
 
 	// Get current service first
-	currentService, err := db.GetService(serviceID)
+	currentService, err := db.GetService(ctx, serviceID)
 	if err != nil {
 		return fmt.Errorf("service not found: %w", err)
 	}
 
 	// Use current values for empty parameters to avoid validation errors
+	updateStatus := newStatus
 	updateName := name
 	updateEndpoint := endpoint
 	updateServiceType := serviceType
 
+	if updateStatus == "" {
+		updateStatus = currentService.Status
+	}
 	if updateName == "" {
 		updateName = currentService.Name
 	}
@@ -158,32 +315,93 @@ func (db *EntClient) UpdateService(serviceID int64, newStatus string, name strin
 	if updateServiceType == "" {
 		updateServiceType = currentService.Type
 	}
+	updateCheckInterval := checkInterval
+	if updateCheckInterval <= 0 {
+		updateCheckInterval = currentService.CheckInterval
+	}
+	updateLabels := labels
+	if updateLabels == nil {
+		updateLabels = currentService.Labels
+	}
 
 	// Direct type usage - no string conversion needed
-	_, err = db.client.Service.UpdateOneID(serviceID).
-		SetStatus(newStatus).
-		SetName(updateName).
-		SetEndpoint(updateEndpoint).
-		SetType(updateServiceType).
-		SetLastHeartbeat(time.Now()).
-		Save(ctx)
-
+	err = db.withTx(ctx, func(tx *ent.Tx) error {
+		return tx.Service.UpdateOneID(serviceID).
+			SetStatus(updateStatus).
+			SetName(updateName).
+			SetEndpoint(updateEndpoint).
+			SetType(updateServiceType).
+			SetCheckInterval(updateCheckInterval).
+			SetLabels(updateLabels).
+			SetLastHeartbeat(time.Now()).
+			Exec(ctx)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update service: %w", err)
 	}
 
-	// Log the status change
-	log.Printf("Service %d updated: status=%s, name=%s, type=%s, endpoint=%s",
-		serviceID, newStatus, updateName, string(updateServiceType), updateEndpoint)
+	db.changes.notify()
+	logger.InfoContext(ctx, "service updated",
+		"service_id", serviceID,
+		"status", updateStatus,
+		"name", updateName,
+		"type", string(updateServiceType),
+		"endpoint", updateEndpoint,
+		"check_interval", updateCheckInterval)
+
+	return nil
+}
+
+// Heartbeat refreshes the heartbeat of a service and sets its status when
+// newStatus is not empty. Heartbeats that keep the status are not audited.
+func (db *EntClient) Heartbeat(ctx context.Context, serviceID int64, newStatus string) error {
+	ctx, finish := startCall(ctx, "Heartbeat")
+	defer finish()
+
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		update := tx.Service.UpdateOneID(serviceID).SetLastHeartbeat(time.Now())
+		if newStatus != "" {
+			update.SetStatus(newStatus)
+		}
+		return update.Exec(ctx)
+	})
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("service not found")
+		}
+		return fmt.Errorf("failed to record heartbeat: %w", err)
+	}
 
+	if newStatus != "" {
+		db.changes.notify()
+		logger.InfoContext(ctx, "service status changed by heartbeat", "service_id", serviceID, "status", newStatus)
+	}
 	return nil
 }
 
 // DeleteService deletes a service using the generated Ent client
-func (db *EntClient) DeleteService(serviceID int64) error {
-	ctx := context.Background()
+func (db *EntClient) DeleteService(ctx context.Context, serviceID int64) error {
+	ctx, finish := startCall(ctx, "DeleteService")
+	defer finish()
+
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		if err := tx.Service.DeleteOneID(serviceID).Exec(ctx); err != nil {
+			return err
+		}
 
-	err := db.client.Service.DeleteOneID(serviceID).Exec(ctx)
+		// Check history is meaningless without its service
+		_, err := tx.CheckResult.Delete().
+			Where(checkresult.ServiceID(serviceID)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Schedule.Delete().
+			Where(schedule.ServiceID(serviceID)).
+			Exec(ctx)
+		return err
+	})
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return fmt.Errorf("service not found")
@@ -191,11 +409,159 @@ func (db *EntClient) DeleteService(serviceID int64) error {
 		return fmt.Errorf("failed to delete service: %w", err)
 	}
 
+	db.changes.notify()
+	return nil
+}
+
+// CreateNamespace creates a new namespace using the generated Ent client
+func (db *EntClient) CreateNamespace(ctx context.Context, namespaceRecord NamespaceRecord) error {
+	ctx, finish := startCall(ctx, "CreateNamespace")
+	defer finish()
+
+	var created *ent.Namespace
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		created, err = tx.Namespace.Create().
+			SetName(namespaceRecord.Name).
+			SetDescription(namespaceRecord.Description).
+			SetMaxServices(namespaceRecord.MaxServices).
+			SetMinCheckInterval(namespaceRecord.MinCheckInterval).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return fmt.Errorf("namespace already exists")
+		}
+		return fmt.Errorf("failed to create namespace: %w", err)
+	}
+
+	logger.InfoContext(ctx, "namespace created", "namespace_id", created.ID, "name", created.Name)
+	return nil
+}
+
+// GetNamespace retrieves a namespace by name using the generated Ent client
+func (db *EntClient) GetNamespace(ctx context.Context, name string) (*NamespaceRecord, error) {
+	ctx, finish := startCall(ctx, "GetNamespace")
+	defer finish()
+
+	entNamespace, err := db.client.Namespace.Query().
+		Where(namespace.Name(name)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("namespace not found")
+		}
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	return entNamespace, nil
+}
+
+// ListNamespaces lists all namespaces ordered by name
+func (db *EntClient) ListNamespaces(ctx context.Context) ([]NamespaceRecord, error) {
+	ctx, finish := startCall(ctx, "ListNamespaces")
+	defer finish()
+
+	entNamespaces, err := db.client.Namespace.Query().
+		Order(ent.Asc(namespace.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := make([]NamespaceRecord, len(entNamespaces))
+	for i, entNamespace := range entNamespaces {
+		namespaces[i] = *entNamespace
+	}
+
+	return namespaces, nil
+}
+
+// UpdateNamespace replaces the description and quotas of a namespace
+func (db *EntClient) UpdateNamespace(ctx context.Context, namespaceRecord NamespaceRecord) error {
+	ctx, finish := startCall(ctx, "UpdateNamespace")
+	defer finish()
+
+	// Namespace mutations run in a transaction so the audit hook commits with them
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		id, err := tx.Namespace.Query().
+			Where(namespace.Name(namespaceRecord.Name)).
+			OnlyID(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("namespace not found")
+			}
+			return err
+		}
+
+		return tx.Namespace.UpdateOneID(id).
+			SetDescription(namespaceRecord.Description).
+			SetMaxServices(namespaceRecord.MaxServices).
+			SetMinCheckInterval(namespaceRecord.MinCheckInterval).
+			Exec(ctx)
+	})
+	if err != nil {
+		if err.Error() == "namespace not found" {
+			return err
+		}
+		return fmt.Errorf("failed to update namespace: %w", err)
+	}
+
+	logger.InfoContext(ctx, "namespace updated",
+		"name", namespaceRecord.Name,
+		"max_services", namespaceRecord.MaxServices,
+		"min_check_interval", namespaceRecord.MinCheckInterval)
+
+	return nil
+}
+
+// DeleteNamespace deletes an empty namespace
+func (db *EntClient) DeleteNamespace(ctx context.Context, name string) error {
+	ctx, finish := startCall(ctx, "DeleteNamespace")
+	defer finish()
+
+	// The namespace row is locked like in CreateService, so no service can
+	// be registered between the count and the delete
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		ns, err := tx.Namespace.Query().
+			Where(namespace.Name(name)).
+			ForUpdate().
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("namespace not found")
+			}
+			return err
+		}
+
+		count, err := tx.Service.Query().
+			Where(service.Namespace(name)).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("namespace is not empty")
+		}
+
+		return tx.Namespace.DeleteOneID(ns.ID).Exec(ctx)
+	})
+	if err != nil {
+		if msg := err.Error(); msg == "namespace not found" || msg == "namespace is not empty" {
+			return err
+		}
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+
 	return nil
 }
 
 // LogHealthCheck logs a health check
 func (db *EntClient) LogHealthCheck(status string, serviceCount int) error {
-	log.Printf("Health check: %s, services: %d", status, serviceCount)
+	_, finish := startCall(context.Background(), "LogHealthCheck")
+	defer finish()
+
+	logger.Debug("health check", "status", status, "services", serviceCount)
 	return nil
 }
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"watchdog/ent"
+	"watchdog/ent/incident"
+	"watchdog/ent/statusupdate"
+)
+
+// IncidentRecord represents an incident record in the database
+type IncidentRecord = ent.Incident
+
+// StatusUpdateRecord represents an incident timeline entry in the database
+type StatusUpdateRecord = ent.StatusUpdate
+
+// CreateIncident opens an incident together with the first timeline entry
+func (db *EntClient) CreateIncident(ctx context.Context, incidentRecord IncidentRecord, message string) (*IncidentRecord, error) {
+	ctx, finish := startCall(ctx, "CreateIncident")
+	defer finish()
+
+	var created *ent.Incident
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		create := tx.Incident.Create().
+			SetTitle(incidentRecord.Title).
+			SetStatus(incidentRecord.Status).
+			SetImpact(incidentRecord.Impact).
+			SetComponents(incidentRecord.Components)
+		if incidentRecord.Status == incident.StatusResolved {
+			create.SetResolvedAt(time.Now())
+		}
+
+		var err error
+		created, err = create.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create incident: %w", err)
+		}
+
+		err = tx.StatusUpdate.Create().
+			SetIncidentID(created.ID).
+			SetStatus(statusupdate.Status(created.Status)).
+			SetMessage(truncate(message, 5000)).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create incident update: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "incident opened", "incident_id", created.ID, "title", created.Title)
+	return created, nil
+}
+
+// GetIncident retrieves an incident by ID
+func (db *EntClient) GetIncident(ctx context.Context, incidentID int64) (*IncidentRecord, error) {
+	ctx, finish := startCall(ctx, "GetIncident")
+	defer finish()
+
+	entIncident, err := db.client.Incident.Get(ctx, incidentID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("incident not found")
+		}
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	return entIncident, nil
+}
+
+// AddStatusUpdate appends a timeline entry to an incident and moves the
+// incident to the status of the entry. An empty impact keeps the current one.
+func (db *EntClient) AddStatusUpdate(ctx context.Context, incidentID int64, status incident.Status, impact incident.Impact, message string) (*IncidentRecord, error) {
+	ctx, finish := startCall(ctx, "AddStatusUpdate")
+	defer finish()
+
+	var updated *ent.Incident
+	err := db.withTx(ctx, func(tx *ent.Tx) error {
+		current, err := tx.Incident.Query().
+			Where(incident.ID(incidentID)).
+			ForUpdate().
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("incident not found")
+			}
+			return fmt.Errorf("failed to get incident: %w", err)
+		}
+
+		update := tx.Incident.UpdateOneID(incidentID).SetStatus(status)
+		if impact != "" {
+			update.SetImpact(impact)
+		}
+		switch {
+		case status == incident.StatusResolved && current.ResolvedAt == nil:
+			update.SetResolvedAt(time.Now())
+		case status != incident.StatusResolved:
+			// Reopened incidents are open again
+			update.ClearResolvedAt()
+		}
+
+		updated, err = update.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update incident: %w", err)
+		}
+
+		err = tx.StatusUpdate.Create().
+			SetIncidentID(incidentID).
+			SetStatus(statusupdate.Status(status)).
+			SetMessage(truncate(message, 5000)).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create incident update: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "incident updated", "incident_id", updated.ID, "status", updated.Status)
+	return updated, nil
+}
+
+// ListIncidents lists incidents opened since the given time, plus every
+// incident that is still open, newest first
+func (db *EntClient) ListIncidents(ctx context.Context, since time.Time, limit int) ([]IncidentRecord, error) {
+	ctx, finish := startCall(ctx, "ListIncidents")
+	defer finish()
+
+	query := db.client.Incident.Query()
+	if !since.IsZero() {
+		query = query.Where(incident.Or(
+			incident.CreatedAtGTE(since),
+			incident.ResolvedAtIsNil(),
+		))
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	entIncidents, err := query.
+		Order(ent.Desc(incident.FieldCreatedAt), ent.Desc(incident.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	incidents := make([]IncidentRecord, len(entIncidents))
+	for i, entIncident := range entIncidents {
+		incidents[i] = *entIncident
+	}
+
+	return incidents, nil
+}
+
+// ListStatusUpdates returns the timelines of the given incidents, newest
+// entry first, keyed by incident ID
+func (db *EntClient) ListStatusUpdates(ctx context.Context, incidentIDs []int64) (map[int64][]StatusUpdateRecord, error) {
+	ctx, finish := startCall(ctx, "ListStatusUpdates")
+	defer finish()
+
+	timelines := make(map[int64][]StatusUpdateRecord)
+	if len(incidentIDs) == 0 {
+		return timelines, nil
+	}
+
+	entUpdates, err := db.client.StatusUpdate.Query().
+		Where(statusupdate.IncidentIDIn(incidentIDs...)).
+		Order(ent.Desc(statusupdate.FieldCreatedAt), ent.Desc(statusupdate.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident updates: %w", err)
+	}
+
+	for _, entUpdate := range entUpdates {
+		timelines[entUpdate.IncidentID] = append(timelines[entUpdate.IncidentID], *entUpdate)
+	}
+
+	return timelines, nil
+}
@@ -1,7 +1,11 @@
 package database
 
 import (
+	"context"
+	"time"
+
 	"watchdog/ent"
+	"watchdog/ent/incident"
 	"watchdog/ent/service"
 )
 
@@ -17,6 +21,12 @@ type Config struct {
 // ServiceRecord represents a service record in the database
 type ServiceRecord = ent.Service
 
+// NamespaceRecord represents a namespace record in the database
+type NamespaceRecord = ent.Namespace
+
+// DefaultNamespace is the namespace used when a request does not name one
+const DefaultNamespace = "default"
+
 // ServiceDB defines the interface for database operations on services
 // This interface is implemented by EntClient
 type ServiceDB interface {
@@ -25,11 +35,51 @@ type ServiceDB interface {
 	HealthCheck() error
 
 	// Service operations
-	CreateService(service ServiceRecord) (int64, error)
-	GetService(serviceID int64) (*ServiceRecord, error)
-	ListServices() ([]ServiceRecord, error)
-	UpdateService(serviceID int64, newStatus string, name string, serviceType service.Type, endpoint string) error
-	DeleteService(serviceID int64) error
+	CreateService(ctx context.Context, service ServiceRecord) (int64, error)
+	GetService(ctx context.Context, serviceID int64) (*ServiceRecord, error)
+	ListServices(ctx context.Context, namespace string) ([]ServiceRecord, error)
+	WatchChanges(ctx context.Context) <-chan struct{}
+	CountServices(ctx context.Context, namespace string) (int, error)
+	UpdateService(ctx context.Context, serviceID int64, newStatus string, name string, serviceType service.Type, endpoint string, checkInterval int, labels map[string]string) error
+	Heartbeat(ctx context.Context, serviceID int64, newStatus string) error
+	DeleteService(ctx context.Context, serviceID int64) error
+
+	// Namespace operations
+	CreateNamespace(ctx context.Context, namespace NamespaceRecord) error
+	GetNamespace(ctx context.Context, name string) (*NamespaceRecord, error)
+	ListNamespaces(ctx context.Context) ([]NamespaceRecord, error)
+	UpdateNamespace(ctx context.Context, namespace NamespaceRecord) error
+	DeleteNamespace(ctx context.Context, name string) error
+
+	// Check history
+	RecordCheckResult(ctx context.Context, result CheckResultRecord) error
+	ListCheckResults(ctx context.Context, serviceID int64, since time.Time, limit int) ([]CheckResultRecord, error)
+	LatestCheckResults(ctx context.Context, serviceIDs []int64) (map[int64]CheckResultRecord, error)
+	DailyCheckCounts(ctx context.Context, serviceIDs []int64, since time.Time) ([]DailyCheckCount, error)
+	PruneCheckResults(ctx context.Context, before time.Time, limit int) (int, error)
+
+	// Status page incidents
+	CreateIncident(ctx context.Context, incident IncidentRecord, message string) (*IncidentRecord, error)
+	GetIncident(ctx context.Context, incidentID int64) (*IncidentRecord, error)
+	AddStatusUpdate(ctx context.Context, incidentID int64, status incident.Status, impact incident.Impact, message string) (*IncidentRecord, error)
+	ListIncidents(ctx context.Context, since time.Time, limit int) ([]IncidentRecord, error)
+	ListStatusUpdates(ctx context.Context, incidentIDs []int64) (map[int64][]StatusUpdateRecord, error)
+
+	// Audit log
+	ListAuditEvents(ctx context.Context, filter AuditFilter) ([]AuditEventRecord, error)
+	VerifyAuditChain(ctx context.Context) (AuditVerification, error)
+
+	// Leases held by a single replica
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*LeaseRecord, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+	GetLease(ctx context.Context, name string) (*LeaseRecord, error)
+
+	// Replicas sharing the scheduled checks
+	TouchMember(ctx context.Context, instance string, timeout time.Duration) error
+	ListMembers(ctx context.Context, timeout time.Duration) ([]MemberRecord, error)
+	RemoveMember(ctx context.Context, instance string) error
+	ClaimCheck(ctx context.Context, serviceID int64, owner string, hold time.Duration) (time.Time, bool, error)
+	ScheduleCheck(ctx context.Context, serviceID int64, owner string, after time.Duration) error
 
 	// Health logging
 	LogHealthCheck(status string, serviceCount int) error
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"watchdog/ent"
+	"watchdog/ent/lease"
+)
+
+// LeaseRecord represents a lease record in the database
+type LeaseRecord = ent.Lease
+
+// SchedulerLease is the lease held by the replica that runs the scheduler
+const SchedulerLease = "scheduler"
+
+// AcquireLease takes or renews a lease for holder. A lease held by another
+// holder is only taken once it has expired. It returns the lease as it is
+// after the call, so the caller holds it if the holder matches.
+func (db *EntClient) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*LeaseRecord, error) {
+	ctx, finish := startCall(ctx, "AcquireLease")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *ent.Lease
+	err = db.withTx(ctx, func(tx *ent.Tx) error {
+		existing, err := tx.Lease.Query().
+			Where(lease.Name(name)).
+			ForUpdate().
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(err):
+			current, err = tx.Lease.Create().
+				SetName(name).
+				SetHolder(holder).
+				SetAcquiredAt(now).
+				SetExpiresAt(now.Add(ttl)).
+				Save(ctx)
+			return err
+		case err != nil:
+			return err
+		case existing.Holder == holder:
+			current, err = existing.Update().
+				SetExpiresAt(now.Add(ttl)).
+				Save(ctx)
+			return err
+		case existing.ExpiresAt.Before(now):
+			current, err = existing.Update().
+				SetHolder(holder).
+				SetAcquiredAt(now).
+				SetExpiresAt(now.Add(ttl)).
+				Save(ctx)
+			return err
+		default:
+			current = existing
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+
+	return current, nil
+}
+
+// ReleaseLease expires a lease held by holder, so another replica can take
+// it without waiting for it to lapse
+func (db *EntClient) ReleaseLease(ctx context.Context, name, holder string) error {
+	ctx, finish := startCall(ctx, "ReleaseLease")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.client.Lease.Update().
+		Where(lease.Name(name), lease.Holder(holder)).
+		SetExpiresAt(now).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+
+	return nil
+}
+
+// GetLease retrieves a lease by name unless it has expired
+func (db *EntClient) GetLease(ctx context.Context, name string) (*LeaseRecord, error) {
+	ctx, finish := startCall(ctx, "GetLease")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entLease, err := db.client.Lease.Query().
+		Where(lease.Name(name), lease.ExpiresAtGT(now)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("lease not found")
+		}
+		return nil, fmt.Errorf("failed to get lease: %w", err)
+	}
+
+	return entLease, nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	entgo "entgo.io/ent"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchdog/ent"
+)
+
+// QueryDuration observes the latency of database queries and mutations by
+// entity and operation, e.g. "Service.All" or "Service.Create"
+var QueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "watchdog_db_call_duration_seconds",
+	Help:    "Latency of database queries and mutations made through ent.",
+	Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"operation"})
+
+var tracer = otel.Tracer("watchdog/database")
+
+// startCall starts a span for an EntClient call and returns the context to
+// run its queries with. Defer the returned function to end the span.
+func startCall(ctx context.Context, method string) (context.Context, func()) {
+	ctx, span := tracer.Start(ctx, "EntClient."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.operation", method),
+		))
+
+	return ctx, func() {
+		span.End()
+	}
+}
+
+// instrument times every query and mutation made through client
+func instrument(client *ent.Client) {
+	client.Intercept(ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, query ent.Query) (ent.Value, error) {
+			operation := "Query"
+			if qc := entgo.QueryFromContext(ctx); qc != nil {
+				operation = qc.Type + "." + qc.Op
+			}
+			defer observe(operation, time.Now())
+			return next.Query(ctx, query)
+		})
+	}))
+
+	client.Use(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			operation := m.Type() + "." + strings.TrimPrefix(m.Op().String(), "Op")
+			defer observe(operation, time.Now())
+			return next.Mutate(ctx, m)
+		})
+	})
+}
+
+// observe records the latency of an operation started at start
+func observe(operation string, start time.Time) {
+	QueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"watchdog/ent"
+	"watchdog/ent/member"
+	"watchdog/ent/schedule"
+)
+
+// MemberRecord represents a replica sharing the scheduled checks
+type MemberRecord = ent.Member
+
+// TouchMember records a heartbeat of a replica, joining it when it is new
+// or was last seen more than timeout ago
+func (db *EntClient) TouchMember(ctx context.Context, instance string, timeout time.Duration) error {
+	ctx, finish := startCall(ctx, "TouchMember")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = db.withTx(ctx, func(tx *ent.Tx) error {
+		existing, err := tx.Member.Query().
+			Where(member.Instance(instance)).
+			ForUpdate().
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(err):
+			return tx.Member.Create().
+				SetInstance(instance).
+				SetJoinedAt(now).
+				SetSeenAt(now).
+				Exec(ctx)
+		case err != nil:
+			return err
+		}
+
+		update := existing.Update().SetSeenAt(now)
+		if existing.SeenAt.Before(now.Add(-timeout)) {
+			update.SetJoinedAt(now)
+		}
+		return update.Exec(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record member heartbeat: %w", err)
+	}
+
+	return nil
+}
+
+// ListMembers lists the replicas seen within timeout, by instance name
+func (db *EntClient) ListMembers(ctx context.Context, timeout time.Duration) ([]MemberRecord, error) {
+	ctx, finish := startCall(ctx, "ListMembers")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entMembers, err := db.client.Member.Query().
+		Where(member.SeenAtGTE(now.Add(-timeout))).
+		Order(ent.Asc(member.FieldInstance)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	members := make([]MemberRecord, len(entMembers))
+	for i, entMember := range entMembers {
+		members[i] = *entMember
+	}
+	return members, nil
+}
+
+// RemoveMember removes a replica leaving the membership
+func (db *EntClient) RemoveMember(ctx context.Context, instance string) error {
+	ctx, finish := startCall(ctx, "RemoveMember")
+	defer finish()
+
+	if _, err := db.client.Member.Delete().Where(member.Instance(instance)).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	return nil
+}
+
+// ClaimCheck claims the next check of a service for owner until hold has
+// passed. When the check is not due yet or claimed by another replica, it
+// returns when the check is due instead, by the clock of this process.
+func (db *EntClient) ClaimCheck(ctx context.Context, serviceID int64, owner string, hold time.Duration) (time.Time, bool, error) {
+	ctx, finish := startCall(ctx, "ClaimCheck")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	due := now
+	claimed := false
+	err = db.withTx(ctx, func(tx *ent.Tx) error {
+		existing, err := tx.Schedule.Query().
+			Where(schedule.ServiceID(serviceID)).
+			ForUpdate().
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(err):
+			err = tx.Schedule.Create().
+				SetServiceID(serviceID).
+				SetOwner(owner).
+				SetNextCheckAt(now.Add(hold)).
+				Exec(ctx)
+		case err != nil:
+			return err
+		case existing.NextCheckAt.After(now):
+			due = existing.NextCheckAt
+			return nil
+		default:
+			err = existing.Update().
+				SetOwner(owner).
+				SetNextCheckAt(now.Add(hold)).
+				Exec(ctx)
+		}
+		claimed = err == nil
+		return err
+	})
+	if err != nil {
+		// Another replica created the schedule first, it holds the claim
+		if ent.IsConstraintError(err) {
+			return time.Now(), false, nil
+		}
+		return time.Time{}, false, fmt.Errorf("failed to claim check: %w", err)
+	}
+
+	return time.Now().Add(due.Sub(now)), claimed, nil
+}
+
+// ScheduleCheck makes the next check of a service due after the given
+// delay, if owner still holds its claim
+func (db *EntClient) ScheduleCheck(ctx context.Context, serviceID int64, owner string, after time.Duration) error {
+	ctx, finish := startCall(ctx, "ScheduleCheck")
+	defer finish()
+
+	now, err := db.now(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.client.Schedule.Update().
+		Where(schedule.ServiceID(serviceID), schedule.Owner(owner)).
+		SetNextCheckAt(now.Add(after)).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to schedule check: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"sync"
+)
+
+// changeFeed wakes watchers after services or their check results change.
+// It only sees writes made through this client.
+type changeFeed struct {
+	mu       sync.Mutex
+	watchers map[chan struct{}]struct{}
+}
+
+// notify wakes every watcher. Watchers that have not consumed the previous
+// wake-up are skipped, they will reload anyway.
+func (f *changeFeed) notify() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (f *changeFeed) subscribe(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	f.mu.Lock()
+	if f.watchers == nil {
+		f.watchers = make(map[chan struct{}]struct{})
+	}
+	f.watchers[ch] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		delete(f.watchers, ch)
+		f.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// WatchChanges returns a channel that receives a value after services are
+// created, updated or deleted or a check result is recorded, until ctx is
+// cancelled. Bursts of changes may be coalesced into one value.
+func (db *EntClient) WatchChanges(ctx context.Context) <-chan struct{} {
+	return db.changes.subscribe(ctx)
+}
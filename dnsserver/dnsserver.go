@@ -0,0 +1,403 @@
+// Package dnsserver answers DNS queries for the instances of registered
+// services, so applications without a client library find healthy
+// instances by name:
+//
+//	api.service.watchdog.             A/AAAA/SRV of the default namespace
+//	prod.api.service.watchdog.        instances with a label valued "prod"
+//	api.service.payments.watchdog.    instances in the payments namespace
+//
+// Queries outside the zone from allowed client networks are forwarded to an
+// upstream server, others are refused.
+package dnsserver
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+var logger = logging.For("dns")
+
+const (
+	// debounce coalesces bursts of changes into one refresh
+	debounce = 250 * time.Millisecond
+	// pollInterval bounds how stale the answers get when the database is
+	// changed by another replica
+	pollInterval = 15 * time.Second
+	// forwardTimeout bounds a query forwarded upstream
+	forwardTimeout = 5 * time.Second
+)
+
+// Queries counts the DNS queries answered, by response code
+var Queries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "watchdog_dns_queries_total",
+	Help: "DNS queries answered, by response code.",
+}, []string{"rcode"})
+
+// Config configures the DNS server
+type Config struct {
+	// Domain is the zone answered, e.g. "watchdog"
+	Domain string
+	// TTL of the records of instances
+	TTL time.Duration
+	// NegativeTTL is how long resolvers cache that a name or record does
+	// not exist
+	NegativeTTL time.Duration
+	// IncludeUnhealthy also returns instances whose latest check failed or
+	// that are draining or not ready
+	IncludeUnhealthy bool
+	// Upstream is the address queries outside the zone are forwarded to,
+	// e.g. "10.0.0.2:53". They are refused when empty.
+	Upstream string
+	// ForwardNetworks are the client networks whose queries outside the
+	// zone are forwarded. When empty, only loopback and private addresses
+	// are, so the server is not an open resolver.
+	ForwardNetworks []netip.Prefix
+}
+
+// ParseNetworks parses CIDR prefixes such as "10.0.0.0/8"
+func ParseNetworks(values []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		network, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		networks = append(networks, network.Masked())
+	}
+	return networks, nil
+}
+
+// Server answers queries from a snapshot of the registry
+type Server struct {
+	db   database.ServiceDB
+	cfg  Config
+	zone string
+
+	instances atomic.Pointer[[]instance]
+	// serial is the SOA serial, the time of the latest refresh
+	serial atomic.Uint32
+}
+
+// New creates a DNS server for the services in db
+func New(db database.ServiceDB, cfg Config) *Server {
+	return &Server{
+		db:   db,
+		cfg:  cfg,
+		zone: dns.CanonicalName(cfg.Domain),
+	}
+}
+
+// Run keeps the snapshot of the registry up to date until ctx is cancelled
+func (s *Server) Run(ctx context.Context) {
+	changes := s.db.WatchChanges(ctx)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "failed to refresh DNS records", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			continue
+		case <-changes:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(debounce):
+		}
+	}
+}
+
+func (s *Server) refresh(ctx context.Context) error {
+	services, err := s.db.ListServices(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	serviceIDs := make([]int64, len(services))
+	for i, svc := range services {
+		serviceIDs[i] = svc.ID
+	}
+	latest, err := s.db.LatestCheckResults(ctx, serviceIDs)
+	if err != nil {
+		return err
+	}
+
+	result := instances(services, latest)
+	s.instances.Store(&result)
+	s.serial.Store(uint32(time.Now().Unix()))
+	return nil
+}
+
+// Serve answers queries over UDP and TCP until ctx is cancelled
+func (s *Server) Serve(ctx context.Context, udp net.PacketConn, tcp net.Listener) error {
+	servers := []*dns.Server{
+		{PacketConn: udp, Handler: s},
+		{Listener: tcp, Handler: s},
+	}
+	// Shutdown does nothing to a server not started yet, so both must have
+	// started before the first shutdown
+	shutdown := func() {
+		for _, server := range servers {
+			server.Shutdown()
+		}
+	}
+
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		started := make(chan struct{})
+		server.NotifyStartedFunc = func() { close(started) }
+		go func() {
+			errs <- server.ActivateAndServe()
+		}()
+
+		select {
+		case <-started:
+		case err := <-errs:
+			shutdown()
+			return err
+		}
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+	shutdown()
+	return err
+}
+
+// ServeDNS answers a query
+func (s *Server) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	if len(req.Question) != 1 {
+		s.reply(w, req, new(dns.Msg).SetRcode(req, dns.RcodeFormatError))
+		return
+	}
+
+	question := req.Question[0]
+	name := dns.CanonicalName(question.Name)
+	if !dns.IsSubDomain(s.zone, name) {
+		s.forward(w, req)
+		return
+	}
+
+	resp := new(dns.Msg).SetReply(req)
+	resp.Authoritative = true
+	resp.RecursionAvailable = s.forwards(w.RemoteAddr())
+
+	if name == s.zone {
+		if question.Qtype == dns.TypeSOA {
+			resp.Answer = append(resp.Answer, s.soa())
+		} else {
+			resp.Ns = append(resp.Ns, s.soa())
+		}
+		s.reply(w, req, resp)
+		return
+	}
+
+	q, ok := parseQuery(strings.TrimSuffix(name, "."+s.zone))
+	if !ok {
+		s.reply(w, req, s.nameError(resp))
+		return
+	}
+
+	var snapshot []instance
+	if p := s.instances.Load(); p != nil {
+		snapshot = *p
+	}
+
+	exists := false
+	var matched []*instance
+	for i := range snapshot {
+		inst := &snapshot[i]
+		if !q.matches(inst) {
+			continue
+		}
+		exists = true
+		if inst.healthy || s.cfg.IncludeUnhealthy {
+			matched = append(matched, inst)
+		}
+	}
+	if !exists {
+		s.reply(w, req, s.nameError(resp))
+		return
+	}
+
+	// Spread the load over the instances
+	rand.Shuffle(len(matched), func(i, j int) {
+		matched[i], matched[j] = matched[j], matched[i]
+	})
+	// Instances sharing an address, e.g. one per port, give one record
+	addresses := make(map[string]bool)
+	for _, inst := range matched {
+		switch question.Qtype {
+		case dns.TypeA, dns.TypeAAAA:
+			if rr := s.address(name, inst, question.Qtype); rr != nil && !addresses[inst.ip.String()] {
+				addresses[inst.ip.String()] = true
+				resp.Answer = append(resp.Answer, rr)
+			}
+		case dns.TypeSRV:
+			if q.instanceID != 0 {
+				continue
+			}
+			target := s.target(inst)
+			resp.Answer = append(resp.Answer, &dns.SRV{
+				Hdr:      s.header(name, dns.TypeSRV),
+				Priority: 1,
+				Weight:   1,
+				Port:     inst.port,
+				Target:   target,
+			})
+			if rr := s.address(target, inst, dns.TypeA); rr != nil {
+				resp.Extra = append(resp.Extra, rr)
+			}
+			if rr := s.address(target, inst, dns.TypeAAAA); rr != nil {
+				resp.Extra = append(resp.Extra, rr)
+			}
+		}
+	}
+	if len(resp.Answer) == 0 {
+		resp.Ns = append(resp.Ns, s.soa())
+	}
+	s.reply(w, req, resp)
+}
+
+// address is the A or AAAA record of an instance registered by IP, nil
+// when it has no address of that type
+func (s *Server) address(name string, inst *instance, qtype uint16) dns.RR {
+	if inst.ip == nil {
+		return nil
+	}
+	if ip4 := inst.ip.To4(); ip4 != nil {
+		if qtype != dns.TypeA {
+			return nil
+		}
+		return &dns.A{Hdr: s.header(name, dns.TypeA), A: ip4}
+	}
+	if qtype != dns.TypeAAAA {
+		return nil
+	}
+	return &dns.AAAA{Hdr: s.header(name, dns.TypeAAAA), AAAA: inst.ip}
+}
+
+// target is the SRV target of an instance: its host name, or a name in the
+// zone resolving to its address
+func (s *Server) target(inst *instance) string {
+	if inst.ip == nil {
+		return dns.Fqdn(inst.host)
+	}
+	return strings.Join([]string{strconv.FormatInt(inst.id, 10), "instance", s.zone}, ".")
+}
+
+func (s *Server) header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{Name: name, Rrtype: rrtype, Class: dns.ClassINET, Ttl: seconds(s.cfg.TTL)}
+}
+
+func (s *Server) soa() dns.RR {
+	negativeTTL := seconds(s.cfg.NegativeTTL)
+	return &dns.SOA{
+		Hdr:     dns.RR_Header{Name: s.zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: negativeTTL},
+		Ns:      "ns." + s.zone,
+		Mbox:    "hostmaster." + s.zone,
+		Serial:  s.serial.Load(),
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  negativeTTL,
+	}
+}
+
+func (s *Server) nameError(resp *dns.Msg) *dns.Msg {
+	resp.Rcode = dns.RcodeNameError
+	resp.Ns = append(resp.Ns, s.soa())
+	return resp
+}
+
+// forwards reports whether queries outside the zone from a client are
+// forwarded upstream
+func (s *Server) forwards(client net.Addr) bool {
+	if s.cfg.Upstream == "" {
+		return false
+	}
+
+	addrPort, err := netip.ParseAddrPort(client.String())
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+
+	if len(s.cfg.ForwardNetworks) == 0 {
+		return addr.IsLoopback() || addr.IsPrivate()
+	}
+	for _, network := range s.cfg.ForwardNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forward relays a query outside the zone to the upstream server, or
+// refuses it without one or from clients outside the forward networks
+func (s *Server) forward(w dns.ResponseWriter, req *dns.Msg) {
+	if !s.forwards(w.RemoteAddr()) {
+		s.reply(w, req, new(dns.Msg).SetRcode(req, dns.RcodeRefused))
+		return
+	}
+
+	// Forward over the transport of the query, so truncated UDP answers
+	// are retried over TCP by the client
+	c := &dns.Client{Net: w.LocalAddr().Network(), Timeout: forwardTimeout}
+	resp, _, err := c.Exchange(req, s.cfg.Upstream)
+	if err != nil {
+		logger.Warn("failed to forward query", "name", req.Question[0].Name, "upstream", s.cfg.Upstream, "error", err)
+		s.reply(w, req, new(dns.Msg).SetRcode(req, dns.RcodeServerFailure))
+		return
+	}
+	resp.Id = req.Id
+	s.reply(w, req, resp)
+}
+
+// reply writes resp, truncated to what the client accepts over UDP
+func (s *Server) reply(w dns.ResponseWriter, req, resp *dns.Msg) {
+	size := dns.MaxMsgSize
+	if w.LocalAddr().Network() == "udp" {
+		size = dns.MinMsgSize
+		if opt := req.IsEdns0(); opt != nil {
+			size = int(opt.UDPSize())
+			if resp.IsEdns0() == nil {
+				resp.SetEdns0(opt.UDPSize(), false)
+			}
+		}
+	}
+	resp.Truncate(size)
+
+	Queries.WithLabelValues(dns.RcodeToString[resp.Rcode]).Inc()
+	if err := w.WriteMsg(resp); err != nil {
+		logger.Debug("failed to write DNS response", "error", err)
+	}
+}
+
+func seconds(d time.Duration) uint32 {
+	return uint32(d / time.Second)
+}
@@ -0,0 +1,52 @@
+package dnsserver
+
+import (
+	"net"
+	"testing"
+)
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := ParseNetworks([]string{"10.1.2.3/8", "2001:db8::/32"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(networks) != 2 || networks[0].String() != "10.0.0.0/8" || networks[1].String() != "2001:db8::/32" {
+		t.Errorf("ParseNetworks() = %v", networks)
+	}
+
+	if _, err := ParseNetworks([]string{"10.0.0.1"}); err == nil {
+		t.Error("ParseNetworks() accepted an address without a prefix length")
+	}
+}
+
+func TestForwards(t *testing.T) {
+	office, err := ParseNetworks([]string{"203.0.113.0/24"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cfg    Config
+		client net.Addr
+		want   bool
+	}{
+		{name: "no upstream", cfg: Config{}, client: &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5353}, want: false},
+		{name: "loopback", cfg: Config{Upstream: "10.0.0.2:53"}, client: &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5353}, want: true},
+		{name: "private", cfg: Config{Upstream: "10.0.0.2:53"}, client: &net.TCPAddr{IP: net.ParseIP("192.168.1.10"), Port: 5353}, want: true},
+		{name: "private IPv6", cfg: Config{Upstream: "10.0.0.2:53"}, client: &net.UDPAddr{IP: net.ParseIP("fd00::1"), Port: 5353}, want: true},
+		{name: "mapped private", cfg: Config{Upstream: "10.0.0.2:53"}, client: &net.UDPAddr{IP: net.ParseIP("::ffff:10.1.1.1"), Port: 5353}, want: true},
+		{name: "public", cfg: Config{Upstream: "10.0.0.2:53"}, client: &net.UDPAddr{IP: net.ParseIP("198.51.100.7"), Port: 5353}, want: false},
+		{name: "configured network", cfg: Config{Upstream: "10.0.0.2:53", ForwardNetworks: office}, client: &net.UDPAddr{IP: net.ParseIP("203.0.113.9"), Port: 5353}, want: true},
+		{name: "private outside configured networks", cfg: Config{Upstream: "10.0.0.2:53", ForwardNetworks: office}, client: &net.UDPAddr{IP: net.ParseIP("10.1.1.1"), Port: 5353}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(nil, tt.cfg)
+			if got := s.forwards(tt.client); got != tt.want {
+				t.Errorf("forwards(%s) = %v, want %v", tt.client, got, tt.want)
+			}
+		})
+	}
+}
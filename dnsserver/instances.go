@@ -0,0 +1,131 @@
+package dnsserver
+
+import (
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"watchdog/client"
+	"watchdog/database"
+	"watchdog/servicestatus"
+)
+
+// instance is a registered service reachable at a host and port
+type instance struct {
+	id        int64
+	name      string
+	namespace string
+	// labels are the lowercased label values, matched against subdomains
+	labels []string
+	host   string
+	port   uint16
+	// ip is nil when host is a name
+	ip      net.IP
+	healthy bool
+}
+
+// instances turns the services with a host:port endpoint into instances.
+// Names are lowercased, DNS names are case-insensitive.
+func instances(services []database.ServiceRecord, latest map[int64]database.CheckResultRecord) []instance {
+	var result []instance
+	for i := range services {
+		svc := &services[i]
+		hostPort, err := client.HostPort(svc.Endpoint)
+		if err != nil {
+			continue
+		}
+		host, portText, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			continue
+		}
+		port, err := strconv.ParseUint(portText, 10, 16)
+		if err != nil || port == 0 {
+			continue
+		}
+
+		var lastCheck *database.CheckResultRecord
+		if check, ok := latest[svc.ID]; ok {
+			lastCheck = &check
+		}
+
+		labels := make([]string, 0, len(svc.Labels))
+		for _, value := range svc.Labels {
+			labels = append(labels, strings.ToLower(value))
+		}
+
+		result = append(result, instance{
+			id:        svc.ID,
+			name:      strings.ToLower(svc.Name),
+			namespace: strings.ToLower(svc.Namespace),
+			labels:    labels,
+			host:      strings.ToLower(host),
+			port:      uint16(port),
+			ip:        net.ParseIP(host),
+			healthy:   healthy(svc, lastCheck),
+		})
+	}
+	return result
+}
+
+// healthy reports whether an instance should be returned: it is active and
+// its latest check passed or it was not checked yet
+func healthy(svc *database.ServiceRecord, lastCheck *database.CheckResultRecord) bool {
+	if !servicestatus.Routable(svc.Status) {
+		return false
+	}
+	return lastCheck == nil || lastCheck.Status == "healthy"
+}
+
+// query is a parsed in-zone name
+type query struct {
+	// name, namespace and labels select the instances of a service
+	name      string
+	namespace string
+	labels    []string
+	// instanceID is set for the names of single instances, the SRV targets
+	// of instances registered by IP
+	instanceID int64
+}
+
+// parseQuery parses a name relative to the zone, which is
+// [label.]...<name>.service[.<namespace>] or <id>.instance
+func parseQuery(relative string) (query, bool) {
+	parts := strings.Split(relative, ".")
+
+	if len(parts) == 2 && parts[1] == "instance" {
+		id, err := strconv.ParseInt(parts[0], 10, 64)
+		return query{instanceID: id}, err == nil && id > 0
+	}
+
+	q := query{namespace: database.DefaultNamespace}
+	switch {
+	case len(parts) >= 2 && parts[len(parts)-1] == "service":
+		parts = parts[:len(parts)-1]
+	case len(parts) >= 3 && parts[len(parts)-2] == "service":
+		q.namespace = parts[len(parts)-1]
+		parts = parts[:len(parts)-2]
+	default:
+		return query{}, false
+	}
+
+	q.name = parts[len(parts)-1]
+	q.labels = parts[:len(parts)-1]
+	return q, q.name != ""
+}
+
+// matches reports whether an instance belongs to the service selected by q
+func (q query) matches(inst *instance) bool {
+	if q.instanceID != 0 {
+		return inst.id == q.instanceID
+	}
+	if inst.name != q.name || inst.namespace != q.namespace {
+		return false
+	}
+	for _, label := range q.labels {
+		if !slices.Contains(inst.labels, label) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package dnsserver
+
+import (
+	"slices"
+	"testing"
+
+	"watchdog/database"
+	"watchdog/servicestatus"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		relative string
+		want     query
+		ok       bool
+	}{
+		{
+			relative: "api.service",
+			want:     query{name: "api", namespace: database.DefaultNamespace, labels: []string{}},
+			ok:       true,
+		},
+		{
+			relative: "api.service.payments",
+			want:     query{name: "api", namespace: "payments", labels: []string{}},
+			ok:       true,
+		},
+		{
+			relative: "prod.eu.api.service",
+			want:     query{name: "api", namespace: database.DefaultNamespace, labels: []string{"prod", "eu"}},
+			ok:       true,
+		},
+		{
+			relative: "prod.api.service.payments",
+			want:     query{name: "api", namespace: "payments", labels: []string{"prod"}},
+			ok:       true,
+		},
+		{relative: "42.instance", want: query{instanceID: 42}, ok: true},
+		{relative: "0.instance", ok: false},
+		{relative: "-1.instance", ok: false},
+		{relative: "api.instance", ok: false},
+		{relative: "service", ok: false},
+		{relative: ".service", ok: false},
+		{relative: "api", ok: false},
+		{relative: "api.payments", ok: false},
+		{relative: "api.service.payments.extra", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relative, func(t *testing.T) {
+			got, ok := parseQuery(tt.relative)
+			if ok != tt.ok {
+				t.Fatalf("parseQuery(%q) ok = %v, want %v", tt.relative, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got.name != tt.want.name || got.namespace != tt.want.namespace ||
+				got.instanceID != tt.want.instanceID || !slices.Equal(got.labels, tt.want.labels) {
+				t.Errorf("parseQuery(%q) = %+v, want %+v", tt.relative, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryMatches(t *testing.T) {
+	inst := &instance{
+		id:        7,
+		name:      "api",
+		namespace: database.DefaultNamespace,
+		labels:    []string{"prod", "eu"},
+	}
+
+	tests := []struct {
+		name  string
+		query query
+		want  bool
+	}{
+		{name: "name", query: query{name: "api", namespace: database.DefaultNamespace}, want: true},
+		{name: "other name", query: query{name: "web", namespace: database.DefaultNamespace}, want: false},
+		{name: "other namespace", query: query{name: "api", namespace: "payments"}, want: false},
+		{name: "label", query: query{name: "api", namespace: database.DefaultNamespace, labels: []string{"eu"}}, want: true},
+		{name: "every label", query: query{name: "api", namespace: database.DefaultNamespace, labels: []string{"prod", "eu"}}, want: true},
+		{name: "missing label", query: query{name: "api", namespace: database.DefaultNamespace, labels: []string{"prod", "us"}}, want: false},
+		{name: "instance", query: query{instanceID: 7}, want: true},
+		{name: "other instance", query: query{instanceID: 8}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.matches(inst); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstances(t *testing.T) {
+	services := []database.ServiceRecord{
+		{ID: 1, Name: "API", Namespace: "Default", Endpoint: "http://10.0.0.1:8080/health", Labels: map[string]string{"env": "Prod"}},
+		{ID: 2, Name: "db", Endpoint: "db.internal:5432"},
+		{ID: 3, Name: "nameless", Endpoint: "not a host"},
+		{ID: 4, Name: "draining", Endpoint: "10.0.0.4:80", Status: servicestatus.Draining},
+		{ID: 5, Name: "failing", Endpoint: "10.0.0.5:80"},
+		{ID: 6, Name: "maintenance", Endpoint: "10.0.0.6:80", Status: "maintenance"},
+	}
+	latest := map[int64]database.CheckResultRecord{
+		1: {Status: "healthy"},
+		5: {Status: "unhealthy"},
+	}
+
+	got := instances(services, latest)
+	if len(got) != 5 {
+		t.Fatalf("instances() returned %d instances, want 5", len(got))
+	}
+
+	api := got[0]
+	if api.name != "api" || api.namespace != "default" || api.host != "10.0.0.1" || api.port != 8080 ||
+		api.ip == nil || !slices.Equal(api.labels, []string{"prod"}) || !api.healthy {
+		t.Errorf("api instance = %+v", api)
+	}
+	if db := got[1]; db.host != "db.internal" || db.port != 5432 || db.ip != nil || !db.healthy {
+		t.Errorf("db instance = %+v", db)
+	}
+	if got[2].healthy {
+		t.Error("draining instance is healthy")
+	}
+	if got[3].healthy {
+		t.Error("instance with a failed check is healthy")
+	}
+	if got[4].healthy {
+		t.Error("instance in maintenance is healthy")
+	}
+}
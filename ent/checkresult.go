@@ -0,0 +1,168 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"watchdog/ent/checkresult"
+	"watchdog/ent/schema"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// CheckResult is the model entity for the CheckResult schema.
+type CheckResult struct {
+	config `json:"-"`
+	// ID of the ent.
+	// Check result unique identifier (auto-increment)
+	ID int64 `json:"id,omitempty"`
+	// ID of the checked service
+	ServiceID int64 `json:"service_id,omitempty"`
+	// Outcome of the check, e.g. healthy or unhealthy
+	Status string `json:"status,omitempty"`
+	// Error or detail reported by the check
+	Message string `json:"message,omitempty"`
+	// Duration of the check in milliseconds
+	LatencyMs int64 `json:"latency_ms,omitempty"`
+	// When the check ran
+	CheckedAt time.Time `json:"checked_at,omitempty"`
+	// Outcome at every probe location of a multi-location check
+	Locations    []schema.LocationResult `json:"locations,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*CheckResult) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case checkresult.FieldLocations:
+			values[i] = new([]byte)
+		case checkresult.FieldID, checkresult.FieldServiceID, checkresult.FieldLatencyMs:
+			values[i] = new(sql.NullInt64)
+		case checkresult.FieldStatus, checkresult.FieldMessage:
+			values[i] = new(sql.NullString)
+		case checkresult.FieldCheckedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the CheckResult fields.
+func (_m *CheckResult) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case checkresult.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case checkresult.FieldServiceID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field service_id", values[i])
+			} else if value.Valid {
+				_m.ServiceID = value.Int64
+			}
+		case checkresult.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = value.String
+			}
+		case checkresult.FieldMessage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field message", values[i])
+			} else if value.Valid {
+				_m.Message = value.String
+			}
+		case checkresult.FieldLatencyMs:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field latency_ms", values[i])
+			} else if value.Valid {
+				_m.LatencyMs = value.Int64
+			}
+		case checkresult.FieldCheckedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field checked_at", values[i])
+			} else if value.Valid {
+				_m.CheckedAt = value.Time
+			}
+		case checkresult.FieldLocations:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field locations", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Locations); err != nil {
+					return fmt.Errorf("unmarshal field locations: %w", err)
+				}
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the CheckResult.
+// This includes values selected through modifiers, order, etc.
+func (_m *CheckResult) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this CheckResult.
+// Note that you need to call CheckResult.Unwrap() before calling this method if this CheckResult
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *CheckResult) Update() *CheckResultUpdateOne {
+	return NewCheckResultClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the CheckResult entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *CheckResult) Unwrap() *CheckResult {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: CheckResult is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *CheckResult) String() string {
+	var builder strings.Builder
+	builder.WriteString("CheckResult(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("service_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ServiceID))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(_m.Status)
+	builder.WriteString(", ")
+	builder.WriteString("message=")
+	builder.WriteString(_m.Message)
+	builder.WriteString(", ")
+	builder.WriteString("latency_ms=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LatencyMs))
+	builder.WriteString(", ")
+	builder.WriteString("checked_at=")
+	builder.WriteString(_m.CheckedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("locations=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Locations))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// CheckResults is a parsable slice of CheckResult.
+type CheckResults []*CheckResult
@@ -0,0 +1,321 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"watchdog/ent/checkresult"
+	"watchdog/ent/schema"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CheckResultCreate is the builder for creating a CheckResult entity.
+type CheckResultCreate struct {
+	config
+	mutation *CheckResultMutation
+	hooks    []Hook
+}
+
+// SetServiceID sets the "service_id" field.
+func (_c *CheckResultCreate) SetServiceID(v int64) *CheckResultCreate {
+	_c.mutation.SetServiceID(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *CheckResultCreate) SetStatus(v string) *CheckResultCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetMessage sets the "message" field.
+func (_c *CheckResultCreate) SetMessage(v string) *CheckResultCreate {
+	_c.mutation.SetMessage(v)
+	return _c
+}
+
+// SetNillableMessage sets the "message" field if the given value is not nil.
+func (_c *CheckResultCreate) SetNillableMessage(v *string) *CheckResultCreate {
+	if v != nil {
+		_c.SetMessage(*v)
+	}
+	return _c
+}
+
+// SetLatencyMs sets the "latency_ms" field.
+func (_c *CheckResultCreate) SetLatencyMs(v int64) *CheckResultCreate {
+	_c.mutation.SetLatencyMs(v)
+	return _c
+}
+
+// SetNillableLatencyMs sets the "latency_ms" field if the given value is not nil.
+func (_c *CheckResultCreate) SetNillableLatencyMs(v *int64) *CheckResultCreate {
+	if v != nil {
+		_c.SetLatencyMs(*v)
+	}
+	return _c
+}
+
+// SetCheckedAt sets the "checked_at" field.
+func (_c *CheckResultCreate) SetCheckedAt(v time.Time) *CheckResultCreate {
+	_c.mutation.SetCheckedAt(v)
+	return _c
+}
+
+// SetNillableCheckedAt sets the "checked_at" field if the given value is not nil.
+func (_c *CheckResultCreate) SetNillableCheckedAt(v *time.Time) *CheckResultCreate {
+	if v != nil {
+		_c.SetCheckedAt(*v)
+	}
+	return _c
+}
+
+// SetLocations sets the "locations" field.
+func (_c *CheckResultCreate) SetLocations(v []schema.LocationResult) *CheckResultCreate {
+	_c.mutation.SetLocations(v)
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *CheckResultCreate) SetID(v int64) *CheckResultCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the CheckResultMutation object of the builder.
+func (_c *CheckResultCreate) Mutation() *CheckResultMutation {
+	return _c.mutation
+}
+
+// Save creates the CheckResult in the database.
+func (_c *CheckResultCreate) Save(ctx context.Context) (*CheckResult, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *CheckResultCreate) SaveX(ctx context.Context) *CheckResult {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CheckResultCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CheckResultCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *CheckResultCreate) defaults() {
+	if _, ok := _c.mutation.Message(); !ok {
+		v := checkresult.DefaultMessage
+		_c.mutation.SetMessage(v)
+	}
+	if _, ok := _c.mutation.LatencyMs(); !ok {
+		v := checkresult.DefaultLatencyMs
+		_c.mutation.SetLatencyMs(v)
+	}
+	if _, ok := _c.mutation.CheckedAt(); !ok {
+		v := checkresult.DefaultCheckedAt()
+		_c.mutation.SetCheckedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *CheckResultCreate) check() error {
+	if _, ok := _c.mutation.ServiceID(); !ok {
+		return &ValidationError{Name: "service_id", err: errors.New(`ent: missing required field "CheckResult.service_id"`)}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "CheckResult.status"`)}
+	}
+	if v, ok := _c.mutation.Status(); ok {
+		if err := checkresult.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "CheckResult.status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Message(); !ok {
+		return &ValidationError{Name: "message", err: errors.New(`ent: missing required field "CheckResult.message"`)}
+	}
+	if v, ok := _c.mutation.Message(); ok {
+		if err := checkresult.MessageValidator(v); err != nil {
+			return &ValidationError{Name: "message", err: fmt.Errorf(`ent: validator failed for field "CheckResult.message": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.LatencyMs(); !ok {
+		return &ValidationError{Name: "latency_ms", err: errors.New(`ent: missing required field "CheckResult.latency_ms"`)}
+	}
+	if v, ok := _c.mutation.LatencyMs(); ok {
+		if err := checkresult.LatencyMsValidator(v); err != nil {
+			return &ValidationError{Name: "latency_ms", err: fmt.Errorf(`ent: validator failed for field "CheckResult.latency_ms": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CheckedAt(); !ok {
+		return &ValidationError{Name: "checked_at", err: errors.New(`ent: missing required field "CheckResult.checked_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := checkresult.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "CheckResult.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *CheckResultCreate) sqlSave(ctx context.Context) (*CheckResult, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *CheckResultCreate) createSpec() (*CheckResult, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CheckResult{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(checkresult.Table, sqlgraph.NewFieldSpec(checkresult.FieldID, field.TypeInt64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.ServiceID(); ok {
+		_spec.SetField(checkresult.FieldServiceID, field.TypeInt64, value)
+		_node.ServiceID = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(checkresult.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.Message(); ok {
+		_spec.SetField(checkresult.FieldMessage, field.TypeString, value)
+		_node.Message = value
+	}
+	if value, ok := _c.mutation.LatencyMs(); ok {
+		_spec.SetField(checkresult.FieldLatencyMs, field.TypeInt64, value)
+		_node.LatencyMs = value
+	}
+	if value, ok := _c.mutation.CheckedAt(); ok {
+		_spec.SetField(checkresult.FieldCheckedAt, field.TypeTime, value)
+		_node.CheckedAt = value
+	}
+	if value, ok := _c.mutation.Locations(); ok {
+		_spec.SetField(checkresult.FieldLocations, field.TypeJSON, value)
+		_node.Locations = value
+	}
+	return _node, _spec
+}
+
+// CheckResultCreateBulk is the builder for creating many CheckResult entities in bulk.
+type CheckResultCreateBulk struct {
+	config
+	err      error
+	builders []*CheckResultCreate
+}
+
+// Save creates the CheckResult entities in the database.
+func (_c *CheckResultCreateBulk) Save(ctx context.Context) ([]*CheckResult, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*CheckResult, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CheckResultMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *CheckResultCreateBulk) SaveX(ctx context.Context) []*CheckResult {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CheckResultCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CheckResultCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
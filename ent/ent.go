@@ -8,7 +8,16 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"watchdog/ent/auditevent"
+	"watchdog/ent/audithead"
+	"watchdog/ent/checkresult"
+	"watchdog/ent/incident"
+	"watchdog/ent/lease"
+	"watchdog/ent/member"
+	"watchdog/ent/namespace"
+	"watchdog/ent/schedule"
 	"watchdog/ent/service"
+	"watchdog/ent/statusupdate"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
@@ -73,7 +82,16 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			service.Table: service.ValidColumn,
+			auditevent.Table:   auditevent.ValidColumn,
+			audithead.Table:    audithead.ValidColumn,
+			checkresult.Table:  checkresult.ValidColumn,
+			incident.Table:     incident.ValidColumn,
+			lease.Table:        lease.ValidColumn,
+			member.Table:       member.ValidColumn,
+			namespace.Table:    namespace.ValidColumn,
+			schedule.Table:     schedule.ValidColumn,
+			service.Table:      service.ValidColumn,
+			statusupdate.Table: statusupdate.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -16,12 +16,16 @@ func main() {
 `,
 		Package: "watchdog/ent",
 		Target:  "./ent",
+		Features: []gen.Feature{
+			// Row locks keep the audit hash chain linear under concurrent writes
+			gen.FeatureLock,
+		},
 	}
 
 	err := entc.Generate("./ent/schema", cfg)
 	if err != nil {
 		log.Fatalf("running ent codegen: %v", err)
 	}
-	
+
 	log.Println("Ent code generation completed successfully")
-}
\ No newline at end of file
+}
@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"watchdog/ent/incident"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Incident is the model entity for the Incident schema.
+type Incident struct {
+	config `json:"-"`
+	// ID of the ent.
+	// Incident unique identifier (auto-increment)
+	ID int64 `json:"id,omitempty"`
+	// Customer-facing summary of the incident
+	Title string `json:"title,omitempty"`
+	// Current stage of the incident, from the latest update
+	Status incident.Status `json:"status,omitempty"`
+	// Severity shown on the status page
+	Impact incident.Impact `json:"impact,omitempty"`
+	// Names of the affected status page components
+	Components []string `json:"components,omitempty"`
+	// When the incident was opened
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// When the incident was last updated
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// When the incident was resolved, NULL while open
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Incident) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case incident.FieldComponents:
+			values[i] = new([]byte)
+		case incident.FieldID:
+			values[i] = new(sql.NullInt64)
+		case incident.FieldTitle, incident.FieldStatus, incident.FieldImpact:
+			values[i] = new(sql.NullString)
+		case incident.FieldCreatedAt, incident.FieldUpdatedAt, incident.FieldResolvedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Incident fields.
+func (_m *Incident) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case incident.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case incident.FieldTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field title", values[i])
+			} else if value.Valid {
+				_m.Title = value.String
+			}
+		case incident.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = incident.Status(value.String)
+			}
+		case incident.FieldImpact:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field impact", values[i])
+			} else if value.Valid {
+				_m.Impact = incident.Impact(value.String)
+			}
+		case incident.FieldComponents:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field components", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Components); err != nil {
+					return fmt.Errorf("unmarshal field components: %w", err)
+				}
+			}
+		case incident.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case incident.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case incident.FieldResolvedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field resolved_at", values[i])
+			} else if value.Valid {
+				_m.ResolvedAt = new(time.Time)
+				*_m.ResolvedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Incident.
+// This includes values selected through modifiers, order, etc.
+func (_m *Incident) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Incident.
+// Note that you need to call Incident.Unwrap() before calling this method if this Incident
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Incident) Update() *IncidentUpdateOne {
+	return NewIncidentClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Incident entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Incident) Unwrap() *Incident {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Incident is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Incident) String() string {
+	var builder strings.Builder
+	builder.WriteString("Incident(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("title=")
+	builder.WriteString(_m.Title)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Status))
+	builder.WriteString(", ")
+	builder.WriteString("impact=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Impact))
+	builder.WriteString(", ")
+	builder.WriteString("components=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Components))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.ResolvedAt; v != nil {
+		builder.WriteString("resolved_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Incidents is a parsable slice of Incident.
+type Incidents []*Incident
@@ -0,0 +1,162 @@
+// Code generated by ent, DO NOT EDIT.
+
+package incident
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the incident type in the database.
+	Label = "incident"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTitle holds the string denoting the title field in the database.
+	FieldTitle = "title"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldImpact holds the string denoting the impact field in the database.
+	FieldImpact = "impact"
+	// FieldComponents holds the string denoting the components field in the database.
+	FieldComponents = "components"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// FieldResolvedAt holds the string denoting the resolved_at field in the database.
+	FieldResolvedAt = "resolved_at"
+	// Table holds the table name of the incident in the database.
+	Table = "incidents"
+)
+
+// Columns holds all SQL columns for incident fields.
+var Columns = []string{
+	FieldID,
+	FieldTitle,
+	FieldStatus,
+	FieldImpact,
+	FieldComponents,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+	FieldResolvedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	TitleValidator func(string) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(int64) error
+)
+
+// Status defines the type for the "status" enum field.
+type Status string
+
+// StatusInvestigating is the default value of the Status enum.
+const DefaultStatus = StatusInvestigating
+
+// Status values.
+const (
+	StatusInvestigating Status = "investigating"
+	StatusIdentified    Status = "identified"
+	StatusMonitoring    Status = "monitoring"
+	StatusResolved      Status = "resolved"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusInvestigating, StatusIdentified, StatusMonitoring, StatusResolved:
+		return nil
+	default:
+		return fmt.Errorf("incident: invalid enum value for status field: %q", s)
+	}
+}
+
+// Impact defines the type for the "impact" enum field.
+type Impact string
+
+// ImpactMinor is the default value of the Impact enum.
+const DefaultImpact = ImpactMinor
+
+// Impact values.
+const (
+	ImpactNone     Impact = "none"
+	ImpactMinor    Impact = "minor"
+	ImpactMajor    Impact = "major"
+	ImpactCritical Impact = "critical"
+)
+
+func (i Impact) String() string {
+	return string(i)
+}
+
+// ImpactValidator is a validator for the "impact" field enum values. It is called by the builders before save.
+func ImpactValidator(i Impact) error {
+	switch i {
+	case ImpactNone, ImpactMinor, ImpactMajor, ImpactCritical:
+		return nil
+	default:
+		return fmt.Errorf("incident: invalid enum value for impact field: %q", i)
+	}
+}
+
+// OrderOption defines the ordering options for the Incident queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTitle orders the results by the title field.
+func ByTitle(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTitle, opts...).ToFunc()
+}
+
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// ByImpact orders the results by the impact field.
+func ByImpact(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldImpact, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByResolvedAt orders the results by the resolved_at field.
+func ByResolvedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResolvedAt, opts...).ToFunc()
+}
@@ -0,0 +1,350 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"watchdog/ent/incident"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// IncidentCreate is the builder for creating a Incident entity.
+type IncidentCreate struct {
+	config
+	mutation *IncidentMutation
+	hooks    []Hook
+}
+
+// SetTitle sets the "title" field.
+func (_c *IncidentCreate) SetTitle(v string) *IncidentCreate {
+	_c.mutation.SetTitle(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *IncidentCreate) SetStatus(v incident.Status) *IncidentCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *IncidentCreate) SetNillableStatus(v *incident.Status) *IncidentCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetImpact sets the "impact" field.
+func (_c *IncidentCreate) SetImpact(v incident.Impact) *IncidentCreate {
+	_c.mutation.SetImpact(v)
+	return _c
+}
+
+// SetNillableImpact sets the "impact" field if the given value is not nil.
+func (_c *IncidentCreate) SetNillableImpact(v *incident.Impact) *IncidentCreate {
+	if v != nil {
+		_c.SetImpact(*v)
+	}
+	return _c
+}
+
+// SetComponents sets the "components" field.
+func (_c *IncidentCreate) SetComponents(v []string) *IncidentCreate {
+	_c.mutation.SetComponents(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *IncidentCreate) SetCreatedAt(v time.Time) *IncidentCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *IncidentCreate) SetNillableCreatedAt(v *time.Time) *IncidentCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *IncidentCreate) SetUpdatedAt(v time.Time) *IncidentCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *IncidentCreate) SetNillableUpdatedAt(v *time.Time) *IncidentCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetResolvedAt sets the "resolved_at" field.
+func (_c *IncidentCreate) SetResolvedAt(v time.Time) *IncidentCreate {
+	_c.mutation.SetResolvedAt(v)
+	return _c
+}
+
+// SetNillableResolvedAt sets the "resolved_at" field if the given value is not nil.
+func (_c *IncidentCreate) SetNillableResolvedAt(v *time.Time) *IncidentCreate {
+	if v != nil {
+		_c.SetResolvedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *IncidentCreate) SetID(v int64) *IncidentCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the IncidentMutation object of the builder.
+func (_c *IncidentCreate) Mutation() *IncidentMutation {
+	return _c.mutation
+}
+
+// Save creates the Incident in the database.
+func (_c *IncidentCreate) Save(ctx context.Context) (*Incident, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *IncidentCreate) SaveX(ctx context.Context) *Incident {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *IncidentCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *IncidentCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *IncidentCreate) defaults() {
+	if _, ok := _c.mutation.Status(); !ok {
+		v := incident.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.Impact(); !ok {
+		v := incident.DefaultImpact
+		_c.mutation.SetImpact(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := incident.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := incident.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *IncidentCreate) check() error {
+	if _, ok := _c.mutation.Title(); !ok {
+		return &ValidationError{Name: "title", err: errors.New(`ent: missing required field "Incident.title"`)}
+	}
+	if v, ok := _c.mutation.Title(); ok {
+		if err := incident.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Incident.title": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Incident.status"`)}
+	}
+	if v, ok := _c.mutation.Status(); ok {
+		if err := incident.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Incident.status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Impact(); !ok {
+		return &ValidationError{Name: "impact", err: errors.New(`ent: missing required field "Incident.impact"`)}
+	}
+	if v, ok := _c.mutation.Impact(); ok {
+		if err := incident.ImpactValidator(v); err != nil {
+			return &ValidationError{Name: "impact", err: fmt.Errorf(`ent: validator failed for field "Incident.impact": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Incident.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Incident.updated_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := incident.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Incident.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *IncidentCreate) sqlSave(ctx context.Context) (*Incident, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *IncidentCreate) createSpec() (*Incident, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Incident{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(incident.Table, sqlgraph.NewFieldSpec(incident.FieldID, field.TypeInt64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Title(); ok {
+		_spec.SetField(incident.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(incident.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.Impact(); ok {
+		_spec.SetField(incident.FieldImpact, field.TypeEnum, value)
+		_node.Impact = value
+	}
+	if value, ok := _c.mutation.Components(); ok {
+		_spec.SetField(incident.FieldComponents, field.TypeJSON, value)
+		_node.Components = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(incident.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(incident.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.ResolvedAt(); ok {
+		_spec.SetField(incident.FieldResolvedAt, field.TypeTime, value)
+		_node.ResolvedAt = &value
+	}
+	return _node, _spec
+}
+
+// IncidentCreateBulk is the builder for creating many Incident entities in bulk.
+type IncidentCreateBulk struct {
+	config
+	err      error
+	builders []*IncidentCreate
+}
+
+// Save creates the Incident entities in the database.
+func (_c *IncidentCreateBulk) Save(ctx context.Context) ([]*Incident, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Incident, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*IncidentMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *IncidentCreateBulk) SaveX(ctx context.Context) []*Incident {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *IncidentCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *IncidentCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
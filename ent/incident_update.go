@@ -0,0 +1,471 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"watchdog/ent/incident"
+	"watchdog/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// IncidentUpdate is the builder for updating Incident entities.
+type IncidentUpdate struct {
+	config
+	hooks    []Hook
+	mutation *IncidentMutation
+}
+
+// Where appends a list predicates to the IncidentUpdate builder.
+func (_u *IncidentUpdate) Where(ps ...predicate.Incident) *IncidentUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTitle sets the "title" field.
+func (_u *IncidentUpdate) SetTitle(v string) *IncidentUpdate {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *IncidentUpdate) SetNillableTitle(v *string) *IncidentUpdate {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *IncidentUpdate) SetStatus(v incident.Status) *IncidentUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *IncidentUpdate) SetNillableStatus(v *incident.Status) *IncidentUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetImpact sets the "impact" field.
+func (_u *IncidentUpdate) SetImpact(v incident.Impact) *IncidentUpdate {
+	_u.mutation.SetImpact(v)
+	return _u
+}
+
+// SetNillableImpact sets the "impact" field if the given value is not nil.
+func (_u *IncidentUpdate) SetNillableImpact(v *incident.Impact) *IncidentUpdate {
+	if v != nil {
+		_u.SetImpact(*v)
+	}
+	return _u
+}
+
+// SetComponents sets the "components" field.
+func (_u *IncidentUpdate) SetComponents(v []string) *IncidentUpdate {
+	_u.mutation.SetComponents(v)
+	return _u
+}
+
+// AppendComponents appends value to the "components" field.
+func (_u *IncidentUpdate) AppendComponents(v []string) *IncidentUpdate {
+	_u.mutation.AppendComponents(v)
+	return _u
+}
+
+// ClearComponents clears the value of the "components" field.
+func (_u *IncidentUpdate) ClearComponents() *IncidentUpdate {
+	_u.mutation.ClearComponents()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *IncidentUpdate) SetUpdatedAt(v time.Time) *IncidentUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetResolvedAt sets the "resolved_at" field.
+func (_u *IncidentUpdate) SetResolvedAt(v time.Time) *IncidentUpdate {
+	_u.mutation.SetResolvedAt(v)
+	return _u
+}
+
+// SetNillableResolvedAt sets the "resolved_at" field if the given value is not nil.
+func (_u *IncidentUpdate) SetNillableResolvedAt(v *time.Time) *IncidentUpdate {
+	if v != nil {
+		_u.SetResolvedAt(*v)
+	}
+	return _u
+}
+
+// ClearResolvedAt clears the value of the "resolved_at" field.
+func (_u *IncidentUpdate) ClearResolvedAt() *IncidentUpdate {
+	_u.mutation.ClearResolvedAt()
+	return _u
+}
+
+// Mutation returns the IncidentMutation object of the builder.
+func (_u *IncidentUpdate) Mutation() *IncidentMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *IncidentUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *IncidentUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *IncidentUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *IncidentUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *IncidentUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := incident.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *IncidentUpdate) check() error {
+	if v, ok := _u.mutation.Title(); ok {
+		if err := incident.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Incident.title": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := incident.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Incident.status": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Impact(); ok {
+		if err := incident.ImpactValidator(v); err != nil {
+			return &ValidationError{Name: "impact", err: fmt.Errorf(`ent: validator failed for field "Incident.impact": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *IncidentUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(incident.Table, incident.Columns, sqlgraph.NewFieldSpec(incident.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(incident.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(incident.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Impact(); ok {
+		_spec.SetField(incident.FieldImpact, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Components(); ok {
+		_spec.SetField(incident.FieldComponents, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedComponents(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, incident.FieldComponents, value)
+		})
+	}
+	if _u.mutation.ComponentsCleared() {
+		_spec.ClearField(incident.FieldComponents, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(incident.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.ResolvedAt(); ok {
+		_spec.SetField(incident.FieldResolvedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ResolvedAtCleared() {
+		_spec.ClearField(incident.FieldResolvedAt, field.TypeTime)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{incident.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// IncidentUpdateOne is the builder for updating a single Incident entity.
+type IncidentUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *IncidentMutation
+}
+
+// SetTitle sets the "title" field.
+func (_u *IncidentUpdateOne) SetTitle(v string) *IncidentUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *IncidentUpdateOne) SetNillableTitle(v *string) *IncidentUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *IncidentUpdateOne) SetStatus(v incident.Status) *IncidentUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *IncidentUpdateOne) SetNillableStatus(v *incident.Status) *IncidentUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetImpact sets the "impact" field.
+func (_u *IncidentUpdateOne) SetImpact(v incident.Impact) *IncidentUpdateOne {
+	_u.mutation.SetImpact(v)
+	return _u
+}
+
+// SetNillableImpact sets the "impact" field if the given value is not nil.
+func (_u *IncidentUpdateOne) SetNillableImpact(v *incident.Impact) *IncidentUpdateOne {
+	if v != nil {
+		_u.SetImpact(*v)
+	}
+	return _u
+}
+
+// SetComponents sets the "components" field.
+func (_u *IncidentUpdateOne) SetComponents(v []string) *IncidentUpdateOne {
+	_u.mutation.SetComponents(v)
+	return _u
+}
+
+// AppendComponents appends value to the "components" field.
+func (_u *IncidentUpdateOne) AppendComponents(v []string) *IncidentUpdateOne {
+	_u.mutation.AppendComponents(v)
+	return _u
+}
+
+// ClearComponents clears the value of the "components" field.
+func (_u *IncidentUpdateOne) ClearComponents() *IncidentUpdateOne {
+	_u.mutation.ClearComponents()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *IncidentUpdateOne) SetUpdatedAt(v time.Time) *IncidentUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetResolvedAt sets the "resolved_at" field.
+func (_u *IncidentUpdateOne) SetResolvedAt(v time.Time) *IncidentUpdateOne {
+	_u.mutation.SetResolvedAt(v)
+	return _u
+}
+
+// SetNillableResolvedAt sets the "resolved_at" field if the given value is not nil.
+func (_u *IncidentUpdateOne) SetNillableResolvedAt(v *time.Time) *IncidentUpdateOne {
+	if v != nil {
+		_u.SetResolvedAt(*v)
+	}
+	return _u
+}
+
+// ClearResolvedAt clears the value of the "resolved_at" field.
+func (_u *IncidentUpdateOne) ClearResolvedAt() *IncidentUpdateOne {
+	_u.mutation.ClearResolvedAt()
+	return _u
+}
+
+// Mutation returns the IncidentMutation object of the builder.
+func (_u *IncidentUpdateOne) Mutation() *IncidentMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the IncidentUpdate builder.
+func (_u *IncidentUpdateOne) Where(ps ...predicate.Incident) *IncidentUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *IncidentUpdateOne) Select(field string, fields ...string) *IncidentUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Incident entity.
+func (_u *IncidentUpdateOne) Save(ctx context.Context) (*Incident, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *IncidentUpdateOne) SaveX(ctx context.Context) *Incident {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *IncidentUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *IncidentUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *IncidentUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := incident.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *IncidentUpdateOne) check() error {
+	if v, ok := _u.mutation.Title(); ok {
+		if err := incident.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Incident.title": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := incident.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Incident.status": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Impact(); ok {
+		if err := incident.ImpactValidator(v); err != nil {
+			return &ValidationError{Name: "impact", err: fmt.Errorf(`ent: validator failed for field "Incident.impact": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *IncidentUpdateOne) sqlSave(ctx context.Context) (_node *Incident, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(incident.Table, incident.Columns, sqlgraph.NewFieldSpec(incident.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Incident.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, incident.FieldID)
+		for _, f := range fields {
+			if !incident.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != incident.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(incident.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(incident.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Impact(); ok {
+		_spec.SetField(incident.FieldImpact, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Components(); ok {
+		_spec.SetField(incident.FieldComponents, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedComponents(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, incident.FieldComponents, value)
+		})
+	}
+	if _u.mutation.ComponentsCleared() {
+		_spec.ClearField(incident.FieldComponents, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(incident.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.ResolvedAt(); ok {
+		_spec.SetField(incident.FieldResolvedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ResolvedAtCleared() {
+		_spec.ClearField(incident.FieldResolvedAt, field.TypeTime)
+	}
+	_node = &Incident{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{incident.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
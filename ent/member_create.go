@@ -0,0 +1,262 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"watchdog/ent/member"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// MemberCreate is the builder for creating a Member entity.
+type MemberCreate struct {
+	config
+	mutation *MemberMutation
+	hooks    []Hook
+}
+
+// SetInstance sets the "instance" field.
+func (_c *MemberCreate) SetInstance(v string) *MemberCreate {
+	_c.mutation.SetInstance(v)
+	return _c
+}
+
+// SetJoinedAt sets the "joined_at" field.
+func (_c *MemberCreate) SetJoinedAt(v time.Time) *MemberCreate {
+	_c.mutation.SetJoinedAt(v)
+	return _c
+}
+
+// SetNillableJoinedAt sets the "joined_at" field if the given value is not nil.
+func (_c *MemberCreate) SetNillableJoinedAt(v *time.Time) *MemberCreate {
+	if v != nil {
+		_c.SetJoinedAt(*v)
+	}
+	return _c
+}
+
+// SetSeenAt sets the "seen_at" field.
+func (_c *MemberCreate) SetSeenAt(v time.Time) *MemberCreate {
+	_c.mutation.SetSeenAt(v)
+	return _c
+}
+
+// SetNillableSeenAt sets the "seen_at" field if the given value is not nil.
+func (_c *MemberCreate) SetNillableSeenAt(v *time.Time) *MemberCreate {
+	if v != nil {
+		_c.SetSeenAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *MemberCreate) SetID(v int64) *MemberCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the MemberMutation object of the builder.
+func (_c *MemberCreate) Mutation() *MemberMutation {
+	return _c.mutation
+}
+
+// Save creates the Member in the database.
+func (_c *MemberCreate) Save(ctx context.Context) (*Member, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *MemberCreate) SaveX(ctx context.Context) *Member {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *MemberCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *MemberCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *MemberCreate) defaults() {
+	if _, ok := _c.mutation.JoinedAt(); !ok {
+		v := member.DefaultJoinedAt()
+		_c.mutation.SetJoinedAt(v)
+	}
+	if _, ok := _c.mutation.SeenAt(); !ok {
+		v := member.DefaultSeenAt()
+		_c.mutation.SetSeenAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *MemberCreate) check() error {
+	if _, ok := _c.mutation.Instance(); !ok {
+		return &ValidationError{Name: "instance", err: errors.New(`ent: missing required field "Member.instance"`)}
+	}
+	if v, ok := _c.mutation.Instance(); ok {
+		if err := member.InstanceValidator(v); err != nil {
+			return &ValidationError{Name: "instance", err: fmt.Errorf(`ent: validator failed for field "Member.instance": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.JoinedAt(); !ok {
+		return &ValidationError{Name: "joined_at", err: errors.New(`ent: missing required field "Member.joined_at"`)}
+	}
+	if _, ok := _c.mutation.SeenAt(); !ok {
+		return &ValidationError{Name: "seen_at", err: errors.New(`ent: missing required field "Member.seen_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := member.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Member.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *MemberCreate) sqlSave(ctx context.Context) (*Member, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *MemberCreate) createSpec() (*Member, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Member{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(member.Table, sqlgraph.NewFieldSpec(member.FieldID, field.TypeInt64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Instance(); ok {
+		_spec.SetField(member.FieldInstance, field.TypeString, value)
+		_node.Instance = value
+	}
+	if value, ok := _c.mutation.JoinedAt(); ok {
+		_spec.SetField(member.FieldJoinedAt, field.TypeTime, value)
+		_node.JoinedAt = value
+	}
+	if value, ok := _c.mutation.SeenAt(); ok {
+		_spec.SetField(member.FieldSeenAt, field.TypeTime, value)
+		_node.SeenAt = value
+	}
+	return _node, _spec
+}
+
+// MemberCreateBulk is the builder for creating many Member entities in bulk.
+type MemberCreateBulk struct {
+	config
+	err      error
+	builders []*MemberCreate
+}
+
+// Save creates the Member entities in the database.
+func (_c *MemberCreateBulk) Save(ctx context.Context) ([]*Member, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Member, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*MemberMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *MemberCreateBulk) SaveX(ctx context.Context) []*Member {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *MemberCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *MemberCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
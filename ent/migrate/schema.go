@@ -9,13 +9,219 @@ import (
 )
 
 var (
+	// AuditEventsColumns holds the columns for the "audit_events" table.
+	AuditEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "service_id", Type: field.TypeInt64},
+		{Name: "entity", Type: field.TypeEnum, Enums: []string{"service", "namespace", "incident", "status_update"}, Default: "service"},
+		{Name: "entity_id", Type: field.TypeInt64, Default: 0},
+		{Name: "namespace", Type: field.TypeString, Size: 63},
+		{Name: "action", Type: field.TypeEnum, Enums: []string{"create", "update", "delete"}},
+		{Name: "actor", Type: field.TypeString, Size: 255},
+		{Name: "peer", Type: field.TypeString, Size: 255, Default: ""},
+		{Name: "request_id", Type: field.TypeString, Size: 64, Default: ""},
+		{Name: "before", Type: field.TypeJSON, Nullable: true},
+		{Name: "after", Type: field.TypeJSON, Nullable: true},
+		{Name: "changes", Type: field.TypeJSON, Nullable: true},
+		{Name: "prev_hash", Type: field.TypeString, Size: 64, Default: ""},
+		{Name: "hash", Type: field.TypeString, Size: 64},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// AuditEventsTable holds the schema information for the "audit_events" table.
+	AuditEventsTable = &schema.Table{
+		Name:       "audit_events",
+		Columns:    AuditEventsColumns,
+		PrimaryKey: []*schema.Column{AuditEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditevent_hash",
+				Unique:  true,
+				Columns: []*schema.Column{AuditEventsColumns[13]},
+			},
+			{
+				Name:    "auditevent_service_id",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[1]},
+			},
+			{
+				Name:    "auditevent_actor",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[6]},
+			},
+			{
+				Name:    "auditevent_namespace_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[4], AuditEventsColumns[14]},
+			},
+			{
+				Name:    "auditevent_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEventsColumns[14]},
+			},
+		},
+	}
+	// AuditHeadColumns holds the columns for the "audit_head" table.
+	AuditHeadColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "hash", Type: field.TypeString, Size: 64, Default: ""},
+	}
+	// AuditHeadTable holds the schema information for the "audit_head" table.
+	AuditHeadTable = &schema.Table{
+		Name:       "audit_head",
+		Columns:    AuditHeadColumns,
+		PrimaryKey: []*schema.Column{AuditHeadColumns[0]},
+	}
+	// CheckResultsColumns holds the columns for the "check_results" table.
+	CheckResultsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "service_id", Type: field.TypeInt64},
+		{Name: "status", Type: field.TypeString, Size: 50},
+		{Name: "message", Type: field.TypeString, Size: 1000, Default: ""},
+		{Name: "latency_ms", Type: field.TypeInt64, Default: 0},
+		{Name: "checked_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+		{Name: "locations", Type: field.TypeJSON, Nullable: true},
+	}
+	// CheckResultsTable holds the schema information for the "check_results" table.
+	CheckResultsTable = &schema.Table{
+		Name:       "check_results",
+		Columns:    CheckResultsColumns,
+		PrimaryKey: []*schema.Column{CheckResultsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "checkresult_service_id_checked_at",
+				Unique:  false,
+				Columns: []*schema.Column{CheckResultsColumns[1], CheckResultsColumns[5]},
+			},
+			{
+				Name:    "checkresult_checked_at",
+				Unique:  false,
+				Columns: []*schema.Column{CheckResultsColumns[5]},
+			},
+		},
+	}
+	// IncidentsColumns holds the columns for the "incidents" table.
+	IncidentsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "title", Type: field.TypeString, Size: 255},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"investigating", "identified", "monitoring", "resolved"}, Default: "investigating"},
+		{Name: "impact", Type: field.TypeEnum, Enums: []string{"none", "minor", "major", "critical"}, Default: "minor"},
+		{Name: "components", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+		{Name: "updated_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+		{Name: "resolved_at", Type: field.TypeTime, Nullable: true},
+	}
+	// IncidentsTable holds the schema information for the "incidents" table.
+	IncidentsTable = &schema.Table{
+		Name:       "incidents",
+		Columns:    IncidentsColumns,
+		PrimaryKey: []*schema.Column{IncidentsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "incident_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{IncidentsColumns[5]},
+			},
+			{
+				Name:    "incident_resolved_at",
+				Unique:  false,
+				Columns: []*schema.Column{IncidentsColumns[7]},
+			},
+		},
+	}
+	// LeasesColumns holds the columns for the "leases" table.
+	LeasesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString, Size: 63},
+		{Name: "holder", Type: field.TypeString, Size: 255},
+		{Name: "acquired_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+	}
+	// LeasesTable holds the schema information for the "leases" table.
+	LeasesTable = &schema.Table{
+		Name:       "leases",
+		Columns:    LeasesColumns,
+		PrimaryKey: []*schema.Column{LeasesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "lease_name",
+				Unique:  true,
+				Columns: []*schema.Column{LeasesColumns[1]},
+			},
+		},
+	}
+	// MembersColumns holds the columns for the "members" table.
+	MembersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "instance", Type: field.TypeString, Size: 255},
+		{Name: "joined_at", Type: field.TypeTime},
+		{Name: "seen_at", Type: field.TypeTime},
+	}
+	// MembersTable holds the schema information for the "members" table.
+	MembersTable = &schema.Table{
+		Name:       "members",
+		Columns:    MembersColumns,
+		PrimaryKey: []*schema.Column{MembersColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "member_instance",
+				Unique:  true,
+				Columns: []*schema.Column{MembersColumns[1]},
+			},
+		},
+	}
+	// NamespacesColumns holds the columns for the "namespaces" table.
+	NamespacesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString, Size: 63},
+		{Name: "description", Type: field.TypeString, Size: 500, Default: ""},
+		{Name: "max_services", Type: field.TypeInt, Default: 0},
+		{Name: "min_check_interval", Type: field.TypeInt, Default: 0},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+		{Name: "updated_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// NamespacesTable holds the schema information for the "namespaces" table.
+	NamespacesTable = &schema.Table{
+		Name:       "namespaces",
+		Columns:    NamespacesColumns,
+		PrimaryKey: []*schema.Column{NamespacesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "namespace_name",
+				Unique:  true,
+				Columns: []*schema.Column{NamespacesColumns[1]},
+			},
+		},
+	}
+	// SchedulesColumns holds the columns for the "schedules" table.
+	SchedulesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "service_id", Type: field.TypeInt64},
+		{Name: "next_check_at", Type: field.TypeTime},
+		{Name: "owner", Type: field.TypeString, Size: 255, Default: ""},
+	}
+	// SchedulesTable holds the schema information for the "schedules" table.
+	SchedulesTable = &schema.Table{
+		Name:       "schedules",
+		Columns:    SchedulesColumns,
+		PrimaryKey: []*schema.Column{SchedulesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "schedule_service_id",
+				Unique:  true,
+				Columns: []*schema.Column{SchedulesColumns[1]},
+			},
+		},
+	}
 	// ServicesColumns holds the columns for the "services" table.
 	ServicesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "namespace", Type: field.TypeString, Size: 63, Default: "default"},
 		{Name: "name", Type: field.TypeString, Size: 255},
 		{Name: "endpoint", Type: field.TypeString, Size: 500},
 		{Name: "type", Type: field.TypeEnum, Enums: []string{"SERVICE_TYPE_UNSPECIFIED", "SERVICE_TYPE_HTTP", "SERVICE_TYPE_GRPC", "SERVICE_TYPE_DATABASE", "SERVICE_TYPE_CACHE", "SERVICE_TYPE_QUEUE", "SERVICE_TYPE_STORAGE", "SERVICE_TYPE_EXTERNAL_API", "SERVICE_TYPE_MICROSERVICE", "SERVICE_TYPE_OTHER", "SERVICE_TYPE_SYSTEMD"}, Default: "SERVICE_TYPE_UNSPECIFIED"},
 		{Name: "status", Type: field.TypeString, Size: 50, Default: "active"},
+		{Name: "labels", Type: field.TypeJSON, Nullable: true},
+		{Name: "check_interval", Type: field.TypeInt, Default: 60},
 		{Name: "last_heartbeat", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
 		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
 		{Name: "updated_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
@@ -27,43 +233,132 @@ var (
 		PrimaryKey: []*schema.Column{ServicesColumns[0]},
 		Indexes: []*schema.Index{
 			{
-				Name:    "service_name_endpoint",
+				Name:    "service_namespace_name_endpoint",
 				Unique:  true,
-				Columns: []*schema.Column{ServicesColumns[1], ServicesColumns[2]},
+				Columns: []*schema.Column{ServicesColumns[1], ServicesColumns[2], ServicesColumns[3]},
+			},
+			{
+				Name:    "service_namespace",
+				Unique:  false,
+				Columns: []*schema.Column{ServicesColumns[1]},
 			},
 			{
 				Name:    "service_type",
 				Unique:  false,
-				Columns: []*schema.Column{ServicesColumns[3]},
+				Columns: []*schema.Column{ServicesColumns[4]},
 			},
 			{
 				Name:    "service_status",
 				Unique:  false,
-				Columns: []*schema.Column{ServicesColumns[4]},
+				Columns: []*schema.Column{ServicesColumns[5]},
 			},
 			{
 				Name:    "service_last_heartbeat",
 				Unique:  false,
-				Columns: []*schema.Column{ServicesColumns[5]},
+				Columns: []*schema.Column{ServicesColumns[8]},
 			},
 			{
 				Name:    "service_type_status",
 				Unique:  false,
-				Columns: []*schema.Column{ServicesColumns[3], ServicesColumns[4]},
+				Columns: []*schema.Column{ServicesColumns[4], ServicesColumns[5]},
+			},
+		},
+	}
+	// IncidentUpdatesColumns holds the columns for the "incident_updates" table.
+	IncidentUpdatesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "incident_id", Type: field.TypeInt64},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"investigating", "identified", "monitoring", "resolved"}},
+		{Name: "message", Type: field.TypeString, Size: 5000},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// IncidentUpdatesTable holds the schema information for the "incident_updates" table.
+	IncidentUpdatesTable = &schema.Table{
+		Name:       "incident_updates",
+		Columns:    IncidentUpdatesColumns,
+		PrimaryKey: []*schema.Column{IncidentUpdatesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "statusupdate_incident_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{IncidentUpdatesColumns[1], IncidentUpdatesColumns[4]},
 			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AuditEventsTable,
+		AuditHeadTable,
+		CheckResultsTable,
+		IncidentsTable,
+		LeasesTable,
+		MembersTable,
+		NamespacesTable,
+		SchedulesTable,
 		ServicesTable,
+		IncidentUpdatesTable,
 	}
 )
 
 func init() {
+	AuditEventsTable.Annotation = &entsql.Annotation{
+		Table:     "audit_events",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	AuditHeadTable.Annotation = &entsql.Annotation{
+		Table:     "audit_head",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	CheckResultsTable.Annotation = &entsql.Annotation{
+		Table:     "check_results",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	IncidentsTable.Annotation = &entsql.Annotation{
+		Table:     "incidents",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	LeasesTable.Annotation = &entsql.Annotation{
+		Table:     "leases",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	MembersTable.Annotation = &entsql.Annotation{
+		Table:     "members",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	NamespacesTable.Annotation = &entsql.Annotation{
+		Table:     "namespaces",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
+	SchedulesTable.Annotation = &entsql.Annotation{
+		Table:     "schedules",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
 	ServicesTable.Annotation = &entsql.Annotation{
 		Table:     "services",
 		Charset:   "utf8mb4",
 		Collation: "utf8mb4_unicode_ci",
 		Options:   "ENGINE=InnoDB",
 	}
+	IncidentUpdatesTable.Annotation = &entsql.Annotation{
+		Table:     "incident_updates",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+		Options:   "ENGINE=InnoDB",
+	}
 }
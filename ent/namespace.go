@@ -0,0 +1,162 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"watchdog/ent/namespace"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Namespace is the model entity for the Namespace schema.
+type Namespace struct {
+	config `json:"-"`
+	// ID of the ent.
+	// Namespace unique identifier (auto-increment)
+	ID int64 `json:"id,omitempty"`
+	// Namespace name, referenced by services
+	Name string `json:"name,omitempty"`
+	// Free-form description of the owning team or project
+	Description string `json:"description,omitempty"`
+	// Maximum number of services in the namespace, 0 means unlimited
+	MaxServices int `json:"max_services,omitempty"`
+	// Smallest check interval in seconds services may use, 0 means no limit
+	MinCheckInterval int `json:"min_check_interval,omitempty"`
+	// When the namespace was created
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// When the namespace record was last updated
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Namespace) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case namespace.FieldID, namespace.FieldMaxServices, namespace.FieldMinCheckInterval:
+			values[i] = new(sql.NullInt64)
+		case namespace.FieldName, namespace.FieldDescription:
+			values[i] = new(sql.NullString)
+		case namespace.FieldCreatedAt, namespace.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Namespace fields.
+func (_m *Namespace) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case namespace.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case namespace.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case namespace.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				_m.Description = value.String
+			}
+		case namespace.FieldMaxServices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_services", values[i])
+			} else if value.Valid {
+				_m.MaxServices = int(value.Int64)
+			}
+		case namespace.FieldMinCheckInterval:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_check_interval", values[i])
+			} else if value.Valid {
+				_m.MinCheckInterval = int(value.Int64)
+			}
+		case namespace.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case namespace.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Namespace.
+// This includes values selected through modifiers, order, etc.
+func (_m *Namespace) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Namespace.
+// Note that you need to call Namespace.Unwrap() before calling this method if this Namespace
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Namespace) Update() *NamespaceUpdateOne {
+	return NewNamespaceClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Namespace entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Namespace) Unwrap() *Namespace {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Namespace is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Namespace) String() string {
+	var builder strings.Builder
+	builder.WriteString("Namespace(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(_m.Description)
+	builder.WriteString(", ")
+	builder.WriteString("max_services=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxServices))
+	builder.WriteString(", ")
+	builder.WriteString("min_check_interval=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MinCheckInterval))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Namespaces is a parsable slice of Namespace.
+type Namespaces []*Namespace
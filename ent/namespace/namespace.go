@@ -0,0 +1,114 @@
+// Code generated by ent, DO NOT EDIT.
+
+package namespace
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the namespace type in the database.
+	Label = "namespace"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldDescription holds the string denoting the description field in the database.
+	FieldDescription = "description"
+	// FieldMaxServices holds the string denoting the max_services field in the database.
+	FieldMaxServices = "max_services"
+	// FieldMinCheckInterval holds the string denoting the min_check_interval field in the database.
+	FieldMinCheckInterval = "min_check_interval"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the namespace in the database.
+	Table = "namespaces"
+)
+
+// Columns holds all SQL columns for namespace fields.
+var Columns = []string{
+	FieldID,
+	FieldName,
+	FieldDescription,
+	FieldMaxServices,
+	FieldMinCheckInterval,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// DefaultDescription holds the default value on creation for the "description" field.
+	DefaultDescription string
+	// DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	DescriptionValidator func(string) error
+	// DefaultMaxServices holds the default value on creation for the "max_services" field.
+	DefaultMaxServices int
+	// MaxServicesValidator is a validator for the "max_services" field. It is called by the builders before save.
+	MaxServicesValidator func(int) error
+	// DefaultMinCheckInterval holds the default value on creation for the "min_check_interval" field.
+	DefaultMinCheckInterval int
+	// MinCheckIntervalValidator is a validator for the "min_check_interval" field. It is called by the builders before save.
+	MinCheckIntervalValidator func(int) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(int64) error
+)
+
+// OrderOption defines the ordering options for the Namespace queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByDescription orders the results by the description field.
+func ByDescription(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDescription, opts...).ToFunc()
+}
+
+// ByMaxServices orders the results by the max_services field.
+func ByMaxServices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxServices, opts...).ToFunc()
+}
+
+// ByMinCheckInterval orders the results by the min_check_interval field.
+func ByMinCheckInterval(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinCheckInterval, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
@@ -0,0 +1,352 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"watchdog/ent/namespace"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// NamespaceCreate is the builder for creating a Namespace entity.
+type NamespaceCreate struct {
+	config
+	mutation *NamespaceMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (_c *NamespaceCreate) SetName(v string) *NamespaceCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetDescription sets the "description" field.
+func (_c *NamespaceCreate) SetDescription(v string) *NamespaceCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *NamespaceCreate) SetNillableDescription(v *string) *NamespaceCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
+// SetMaxServices sets the "max_services" field.
+func (_c *NamespaceCreate) SetMaxServices(v int) *NamespaceCreate {
+	_c.mutation.SetMaxServices(v)
+	return _c
+}
+
+// SetNillableMaxServices sets the "max_services" field if the given value is not nil.
+func (_c *NamespaceCreate) SetNillableMaxServices(v *int) *NamespaceCreate {
+	if v != nil {
+		_c.SetMaxServices(*v)
+	}
+	return _c
+}
+
+// SetMinCheckInterval sets the "min_check_interval" field.
+func (_c *NamespaceCreate) SetMinCheckInterval(v int) *NamespaceCreate {
+	_c.mutation.SetMinCheckInterval(v)
+	return _c
+}
+
+// SetNillableMinCheckInterval sets the "min_check_interval" field if the given value is not nil.
+func (_c *NamespaceCreate) SetNillableMinCheckInterval(v *int) *NamespaceCreate {
+	if v != nil {
+		_c.SetMinCheckInterval(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *NamespaceCreate) SetCreatedAt(v time.Time) *NamespaceCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *NamespaceCreate) SetNillableCreatedAt(v *time.Time) *NamespaceCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *NamespaceCreate) SetUpdatedAt(v time.Time) *NamespaceCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *NamespaceCreate) SetNillableUpdatedAt(v *time.Time) *NamespaceCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *NamespaceCreate) SetID(v int64) *NamespaceCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the NamespaceMutation object of the builder.
+func (_c *NamespaceCreate) Mutation() *NamespaceMutation {
+	return _c.mutation
+}
+
+// Save creates the Namespace in the database.
+func (_c *NamespaceCreate) Save(ctx context.Context) (*Namespace, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *NamespaceCreate) SaveX(ctx context.Context) *Namespace {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *NamespaceCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *NamespaceCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *NamespaceCreate) defaults() {
+	if _, ok := _c.mutation.Description(); !ok {
+		v := namespace.DefaultDescription
+		_c.mutation.SetDescription(v)
+	}
+	if _, ok := _c.mutation.MaxServices(); !ok {
+		v := namespace.DefaultMaxServices
+		_c.mutation.SetMaxServices(v)
+	}
+	if _, ok := _c.mutation.MinCheckInterval(); !ok {
+		v := namespace.DefaultMinCheckInterval
+		_c.mutation.SetMinCheckInterval(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := namespace.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := namespace.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *NamespaceCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Namespace.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := namespace.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Namespace.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Description(); !ok {
+		return &ValidationError{Name: "description", err: errors.New(`ent: missing required field "Namespace.description"`)}
+	}
+	if v, ok := _c.mutation.Description(); ok {
+		if err := namespace.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "Namespace.description": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MaxServices(); !ok {
+		return &ValidationError{Name: "max_services", err: errors.New(`ent: missing required field "Namespace.max_services"`)}
+	}
+	if v, ok := _c.mutation.MaxServices(); ok {
+		if err := namespace.MaxServicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_services", err: fmt.Errorf(`ent: validator failed for field "Namespace.max_services": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MinCheckInterval(); !ok {
+		return &ValidationError{Name: "min_check_interval", err: errors.New(`ent: missing required field "Namespace.min_check_interval"`)}
+	}
+	if v, ok := _c.mutation.MinCheckInterval(); ok {
+		if err := namespace.MinCheckIntervalValidator(v); err != nil {
+			return &ValidationError{Name: "min_check_interval", err: fmt.Errorf(`ent: validator failed for field "Namespace.min_check_interval": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Namespace.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Namespace.updated_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := namespace.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Namespace.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *NamespaceCreate) sqlSave(ctx context.Context) (*Namespace, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *NamespaceCreate) createSpec() (*Namespace, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Namespace{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(namespace.Table, sqlgraph.NewFieldSpec(namespace.FieldID, field.TypeInt64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(namespace.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(namespace.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := _c.mutation.MaxServices(); ok {
+		_spec.SetField(namespace.FieldMaxServices, field.TypeInt, value)
+		_node.MaxServices = value
+	}
+	if value, ok := _c.mutation.MinCheckInterval(); ok {
+		_spec.SetField(namespace.FieldMinCheckInterval, field.TypeInt, value)
+		_node.MinCheckInterval = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(namespace.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(namespace.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// NamespaceCreateBulk is the builder for creating many Namespace entities in bulk.
+type NamespaceCreateBulk struct {
+	config
+	err      error
+	builders []*NamespaceCreate
+}
+
+// Save creates the Namespace entities in the database.
+func (_c *NamespaceCreateBulk) Save(ctx context.Context) ([]*Namespace, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Namespace, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*NamespaceMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *NamespaceCreateBulk) SaveX(ctx context.Context) []*Namespace {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *NamespaceCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *NamespaceCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,400 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"watchdog/ent/namespace"
+	"watchdog/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// NamespaceUpdate is the builder for updating Namespace entities.
+type NamespaceUpdate struct {
+	config
+	hooks    []Hook
+	mutation *NamespaceMutation
+}
+
+// Where appends a list predicates to the NamespaceUpdate builder.
+func (_u *NamespaceUpdate) Where(ps ...predicate.Namespace) *NamespaceUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *NamespaceUpdate) SetDescription(v string) *NamespaceUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *NamespaceUpdate) SetNillableDescription(v *string) *NamespaceUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// SetMaxServices sets the "max_services" field.
+func (_u *NamespaceUpdate) SetMaxServices(v int) *NamespaceUpdate {
+	_u.mutation.ResetMaxServices()
+	_u.mutation.SetMaxServices(v)
+	return _u
+}
+
+// SetNillableMaxServices sets the "max_services" field if the given value is not nil.
+func (_u *NamespaceUpdate) SetNillableMaxServices(v *int) *NamespaceUpdate {
+	if v != nil {
+		_u.SetMaxServices(*v)
+	}
+	return _u
+}
+
+// AddMaxServices adds value to the "max_services" field.
+func (_u *NamespaceUpdate) AddMaxServices(v int) *NamespaceUpdate {
+	_u.mutation.AddMaxServices(v)
+	return _u
+}
+
+// SetMinCheckInterval sets the "min_check_interval" field.
+func (_u *NamespaceUpdate) SetMinCheckInterval(v int) *NamespaceUpdate {
+	_u.mutation.ResetMinCheckInterval()
+	_u.mutation.SetMinCheckInterval(v)
+	return _u
+}
+
+// SetNillableMinCheckInterval sets the "min_check_interval" field if the given value is not nil.
+func (_u *NamespaceUpdate) SetNillableMinCheckInterval(v *int) *NamespaceUpdate {
+	if v != nil {
+		_u.SetMinCheckInterval(*v)
+	}
+	return _u
+}
+
+// AddMinCheckInterval adds value to the "min_check_interval" field.
+func (_u *NamespaceUpdate) AddMinCheckInterval(v int) *NamespaceUpdate {
+	_u.mutation.AddMinCheckInterval(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *NamespaceUpdate) SetUpdatedAt(v time.Time) *NamespaceUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the NamespaceMutation object of the builder.
+func (_u *NamespaceUpdate) Mutation() *NamespaceMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *NamespaceUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *NamespaceUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *NamespaceUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *NamespaceUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *NamespaceUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := namespace.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *NamespaceUpdate) check() error {
+	if v, ok := _u.mutation.Description(); ok {
+		if err := namespace.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "Namespace.description": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MaxServices(); ok {
+		if err := namespace.MaxServicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_services", err: fmt.Errorf(`ent: validator failed for field "Namespace.max_services": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinCheckInterval(); ok {
+		if err := namespace.MinCheckIntervalValidator(v); err != nil {
+			return &ValidationError{Name: "min_check_interval", err: fmt.Errorf(`ent: validator failed for field "Namespace.min_check_interval": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *NamespaceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(namespace.Table, namespace.Columns, sqlgraph.NewFieldSpec(namespace.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(namespace.FieldDescription, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.MaxServices(); ok {
+		_spec.SetField(namespace.FieldMaxServices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxServices(); ok {
+		_spec.AddField(namespace.FieldMaxServices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MinCheckInterval(); ok {
+		_spec.SetField(namespace.FieldMinCheckInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinCheckInterval(); ok {
+		_spec.AddField(namespace.FieldMinCheckInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(namespace.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{namespace.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// NamespaceUpdateOne is the builder for updating a single Namespace entity.
+type NamespaceUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *NamespaceMutation
+}
+
+// SetDescription sets the "description" field.
+func (_u *NamespaceUpdateOne) SetDescription(v string) *NamespaceUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *NamespaceUpdateOne) SetNillableDescription(v *string) *NamespaceUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// SetMaxServices sets the "max_services" field.
+func (_u *NamespaceUpdateOne) SetMaxServices(v int) *NamespaceUpdateOne {
+	_u.mutation.ResetMaxServices()
+	_u.mutation.SetMaxServices(v)
+	return _u
+}
+
+// SetNillableMaxServices sets the "max_services" field if the given value is not nil.
+func (_u *NamespaceUpdateOne) SetNillableMaxServices(v *int) *NamespaceUpdateOne {
+	if v != nil {
+		_u.SetMaxServices(*v)
+	}
+	return _u
+}
+
+// AddMaxServices adds value to the "max_services" field.
+func (_u *NamespaceUpdateOne) AddMaxServices(v int) *NamespaceUpdateOne {
+	_u.mutation.AddMaxServices(v)
+	return _u
+}
+
+// SetMinCheckInterval sets the "min_check_interval" field.
+func (_u *NamespaceUpdateOne) SetMinCheckInterval(v int) *NamespaceUpdateOne {
+	_u.mutation.ResetMinCheckInterval()
+	_u.mutation.SetMinCheckInterval(v)
+	return _u
+}
+
+// SetNillableMinCheckInterval sets the "min_check_interval" field if the given value is not nil.
+func (_u *NamespaceUpdateOne) SetNillableMinCheckInterval(v *int) *NamespaceUpdateOne {
+	if v != nil {
+		_u.SetMinCheckInterval(*v)
+	}
+	return _u
+}
+
+// AddMinCheckInterval adds value to the "min_check_interval" field.
+func (_u *NamespaceUpdateOne) AddMinCheckInterval(v int) *NamespaceUpdateOne {
+	_u.mutation.AddMinCheckInterval(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *NamespaceUpdateOne) SetUpdatedAt(v time.Time) *NamespaceUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the NamespaceMutation object of the builder.
+func (_u *NamespaceUpdateOne) Mutation() *NamespaceMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the NamespaceUpdate builder.
+func (_u *NamespaceUpdateOne) Where(ps ...predicate.Namespace) *NamespaceUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *NamespaceUpdateOne) Select(field string, fields ...string) *NamespaceUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Namespace entity.
+func (_u *NamespaceUpdateOne) Save(ctx context.Context) (*Namespace, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *NamespaceUpdateOne) SaveX(ctx context.Context) *Namespace {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *NamespaceUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *NamespaceUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *NamespaceUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := namespace.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *NamespaceUpdateOne) check() error {
+	if v, ok := _u.mutation.Description(); ok {
+		if err := namespace.DescriptionValidator(v); err != nil {
+			return &ValidationError{Name: "description", err: fmt.Errorf(`ent: validator failed for field "Namespace.description": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MaxServices(); ok {
+		if err := namespace.MaxServicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_services", err: fmt.Errorf(`ent: validator failed for field "Namespace.max_services": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinCheckInterval(); ok {
+		if err := namespace.MinCheckIntervalValidator(v); err != nil {
+			return &ValidationError{Name: "min_check_interval", err: fmt.Errorf(`ent: validator failed for field "Namespace.min_check_interval": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *NamespaceUpdateOne) sqlSave(ctx context.Context) (_node *Namespace, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(namespace.Table, namespace.Columns, sqlgraph.NewFieldSpec(namespace.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Namespace.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, namespace.FieldID)
+		for _, f := range fields {
+			if !namespace.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != namespace.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(namespace.FieldDescription, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.MaxServices(); ok {
+		_spec.SetField(namespace.FieldMaxServices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxServices(); ok {
+		_spec.AddField(namespace.FieldMaxServices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MinCheckInterval(); ok {
+		_spec.SetField(namespace.FieldMinCheckInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinCheckInterval(); ok {
+		_spec.AddField(namespace.FieldMinCheckInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(namespace.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &Namespace{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{namespace.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -4,18 +4,320 @@ package ent
 
 import (
 	"time"
+	"watchdog/ent/auditevent"
+	"watchdog/ent/audithead"
+	"watchdog/ent/checkresult"
+	"watchdog/ent/incident"
+	"watchdog/ent/lease"
+	"watchdog/ent/member"
+	"watchdog/ent/namespace"
+	"watchdog/ent/schedule"
 	"watchdog/ent/schema"
 	"watchdog/ent/service"
+	"watchdog/ent/statusupdate"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescEntityID is the schema descriptor for entity_id field.
+	auditeventDescEntityID := auditeventFields[3].Descriptor()
+	// auditevent.DefaultEntityID holds the default value on creation for the entity_id field.
+	auditevent.DefaultEntityID = auditeventDescEntityID.Default.(int64)
+	// auditeventDescNamespace is the schema descriptor for namespace field.
+	auditeventDescNamespace := auditeventFields[4].Descriptor()
+	// auditevent.NamespaceValidator is a validator for the "namespace" field. It is called by the builders before save.
+	auditevent.NamespaceValidator = auditeventDescNamespace.Validators[0].(func(string) error)
+	// auditeventDescActor is the schema descriptor for actor field.
+	auditeventDescActor := auditeventFields[6].Descriptor()
+	// auditevent.ActorValidator is a validator for the "actor" field. It is called by the builders before save.
+	auditevent.ActorValidator = auditeventDescActor.Validators[0].(func(string) error)
+	// auditeventDescPeer is the schema descriptor for peer field.
+	auditeventDescPeer := auditeventFields[7].Descriptor()
+	// auditevent.DefaultPeer holds the default value on creation for the peer field.
+	auditevent.DefaultPeer = auditeventDescPeer.Default.(string)
+	// auditevent.PeerValidator is a validator for the "peer" field. It is called by the builders before save.
+	auditevent.PeerValidator = auditeventDescPeer.Validators[0].(func(string) error)
+	// auditeventDescRequestID is the schema descriptor for request_id field.
+	auditeventDescRequestID := auditeventFields[8].Descriptor()
+	// auditevent.DefaultRequestID holds the default value on creation for the request_id field.
+	auditevent.DefaultRequestID = auditeventDescRequestID.Default.(string)
+	// auditevent.RequestIDValidator is a validator for the "request_id" field. It is called by the builders before save.
+	auditevent.RequestIDValidator = auditeventDescRequestID.Validators[0].(func(string) error)
+	// auditeventDescPrevHash is the schema descriptor for prev_hash field.
+	auditeventDescPrevHash := auditeventFields[12].Descriptor()
+	// auditevent.DefaultPrevHash holds the default value on creation for the prev_hash field.
+	auditevent.DefaultPrevHash = auditeventDescPrevHash.Default.(string)
+	// auditevent.PrevHashValidator is a validator for the "prev_hash" field. It is called by the builders before save.
+	auditevent.PrevHashValidator = auditeventDescPrevHash.Validators[0].(func(string) error)
+	// auditeventDescHash is the schema descriptor for hash field.
+	auditeventDescHash := auditeventFields[13].Descriptor()
+	// auditevent.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	auditevent.HashValidator = func() func(string) error {
+		validators := auditeventDescHash.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(hash string) error {
+			for _, fn := range fns {
+				if err := fn(hash); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[14].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	// auditeventDescID is the schema descriptor for id field.
+	auditeventDescID := auditeventFields[0].Descriptor()
+	// auditevent.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	auditevent.IDValidator = auditeventDescID.Validators[0].(func(int64) error)
+	auditheadFields := schema.AuditHead{}.Fields()
+	_ = auditheadFields
+	// auditheadDescHash is the schema descriptor for hash field.
+	auditheadDescHash := auditheadFields[1].Descriptor()
+	// audithead.DefaultHash holds the default value on creation for the hash field.
+	audithead.DefaultHash = auditheadDescHash.Default.(string)
+	// audithead.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	audithead.HashValidator = auditheadDescHash.Validators[0].(func(string) error)
+	// auditheadDescID is the schema descriptor for id field.
+	auditheadDescID := auditheadFields[0].Descriptor()
+	// audithead.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	audithead.IDValidator = auditheadDescID.Validators[0].(func(int64) error)
+	checkresultFields := schema.CheckResult{}.Fields()
+	_ = checkresultFields
+	// checkresultDescStatus is the schema descriptor for status field.
+	checkresultDescStatus := checkresultFields[2].Descriptor()
+	// checkresult.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	checkresult.StatusValidator = checkresultDescStatus.Validators[0].(func(string) error)
+	// checkresultDescMessage is the schema descriptor for message field.
+	checkresultDescMessage := checkresultFields[3].Descriptor()
+	// checkresult.DefaultMessage holds the default value on creation for the message field.
+	checkresult.DefaultMessage = checkresultDescMessage.Default.(string)
+	// checkresult.MessageValidator is a validator for the "message" field. It is called by the builders before save.
+	checkresult.MessageValidator = checkresultDescMessage.Validators[0].(func(string) error)
+	// checkresultDescLatencyMs is the schema descriptor for latency_ms field.
+	checkresultDescLatencyMs := checkresultFields[4].Descriptor()
+	// checkresult.DefaultLatencyMs holds the default value on creation for the latency_ms field.
+	checkresult.DefaultLatencyMs = checkresultDescLatencyMs.Default.(int64)
+	// checkresult.LatencyMsValidator is a validator for the "latency_ms" field. It is called by the builders before save.
+	checkresult.LatencyMsValidator = checkresultDescLatencyMs.Validators[0].(func(int64) error)
+	// checkresultDescCheckedAt is the schema descriptor for checked_at field.
+	checkresultDescCheckedAt := checkresultFields[5].Descriptor()
+	// checkresult.DefaultCheckedAt holds the default value on creation for the checked_at field.
+	checkresult.DefaultCheckedAt = checkresultDescCheckedAt.Default.(func() time.Time)
+	// checkresultDescID is the schema descriptor for id field.
+	checkresultDescID := checkresultFields[0].Descriptor()
+	// checkresult.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	checkresult.IDValidator = checkresultDescID.Validators[0].(func(int64) error)
+	incidentFields := schema.Incident{}.Fields()
+	_ = incidentFields
+	// incidentDescTitle is the schema descriptor for title field.
+	incidentDescTitle := incidentFields[1].Descriptor()
+	// incident.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	incident.TitleValidator = func() func(string) error {
+		validators := incidentDescTitle.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(title string) error {
+			for _, fn := range fns {
+				if err := fn(title); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// incidentDescCreatedAt is the schema descriptor for created_at field.
+	incidentDescCreatedAt := incidentFields[5].Descriptor()
+	// incident.DefaultCreatedAt holds the default value on creation for the created_at field.
+	incident.DefaultCreatedAt = incidentDescCreatedAt.Default.(func() time.Time)
+	// incidentDescUpdatedAt is the schema descriptor for updated_at field.
+	incidentDescUpdatedAt := incidentFields[6].Descriptor()
+	// incident.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	incident.DefaultUpdatedAt = incidentDescUpdatedAt.Default.(func() time.Time)
+	// incident.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	incident.UpdateDefaultUpdatedAt = incidentDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// incidentDescID is the schema descriptor for id field.
+	incidentDescID := incidentFields[0].Descriptor()
+	// incident.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	incident.IDValidator = incidentDescID.Validators[0].(func(int64) error)
+	leaseFields := schema.Lease{}.Fields()
+	_ = leaseFields
+	// leaseDescName is the schema descriptor for name field.
+	leaseDescName := leaseFields[1].Descriptor()
+	// lease.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	lease.NameValidator = func() func(string) error {
+		validators := leaseDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// leaseDescHolder is the schema descriptor for holder field.
+	leaseDescHolder := leaseFields[2].Descriptor()
+	// lease.HolderValidator is a validator for the "holder" field. It is called by the builders before save.
+	lease.HolderValidator = func() func(string) error {
+		validators := leaseDescHolder.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(holder string) error {
+			for _, fn := range fns {
+				if err := fn(holder); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// leaseDescAcquiredAt is the schema descriptor for acquired_at field.
+	leaseDescAcquiredAt := leaseFields[3].Descriptor()
+	// lease.DefaultAcquiredAt holds the default value on creation for the acquired_at field.
+	lease.DefaultAcquiredAt = leaseDescAcquiredAt.Default.(func() time.Time)
+	// leaseDescID is the schema descriptor for id field.
+	leaseDescID := leaseFields[0].Descriptor()
+	// lease.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	lease.IDValidator = leaseDescID.Validators[0].(func(int64) error)
+	memberFields := schema.Member{}.Fields()
+	_ = memberFields
+	// memberDescInstance is the schema descriptor for instance field.
+	memberDescInstance := memberFields[1].Descriptor()
+	// member.InstanceValidator is a validator for the "instance" field. It is called by the builders before save.
+	member.InstanceValidator = func() func(string) error {
+		validators := memberDescInstance.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(instance string) error {
+			for _, fn := range fns {
+				if err := fn(instance); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// memberDescJoinedAt is the schema descriptor for joined_at field.
+	memberDescJoinedAt := memberFields[2].Descriptor()
+	// member.DefaultJoinedAt holds the default value on creation for the joined_at field.
+	member.DefaultJoinedAt = memberDescJoinedAt.Default.(func() time.Time)
+	// memberDescSeenAt is the schema descriptor for seen_at field.
+	memberDescSeenAt := memberFields[3].Descriptor()
+	// member.DefaultSeenAt holds the default value on creation for the seen_at field.
+	member.DefaultSeenAt = memberDescSeenAt.Default.(func() time.Time)
+	// memberDescID is the schema descriptor for id field.
+	memberDescID := memberFields[0].Descriptor()
+	// member.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	member.IDValidator = memberDescID.Validators[0].(func(int64) error)
+	namespaceFields := schema.Namespace{}.Fields()
+	_ = namespaceFields
+	// namespaceDescName is the schema descriptor for name field.
+	namespaceDescName := namespaceFields[1].Descriptor()
+	// namespace.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	namespace.NameValidator = func() func(string) error {
+		validators := namespaceDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+			validators[2].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// namespaceDescDescription is the schema descriptor for description field.
+	namespaceDescDescription := namespaceFields[2].Descriptor()
+	// namespace.DefaultDescription holds the default value on creation for the description field.
+	namespace.DefaultDescription = namespaceDescDescription.Default.(string)
+	// namespace.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	namespace.DescriptionValidator = namespaceDescDescription.Validators[0].(func(string) error)
+	// namespaceDescMaxServices is the schema descriptor for max_services field.
+	namespaceDescMaxServices := namespaceFields[3].Descriptor()
+	// namespace.DefaultMaxServices holds the default value on creation for the max_services field.
+	namespace.DefaultMaxServices = namespaceDescMaxServices.Default.(int)
+	// namespace.MaxServicesValidator is a validator for the "max_services" field. It is called by the builders before save.
+	namespace.MaxServicesValidator = namespaceDescMaxServices.Validators[0].(func(int) error)
+	// namespaceDescMinCheckInterval is the schema descriptor for min_check_interval field.
+	namespaceDescMinCheckInterval := namespaceFields[4].Descriptor()
+	// namespace.DefaultMinCheckInterval holds the default value on creation for the min_check_interval field.
+	namespace.DefaultMinCheckInterval = namespaceDescMinCheckInterval.Default.(int)
+	// namespace.MinCheckIntervalValidator is a validator for the "min_check_interval" field. It is called by the builders before save.
+	namespace.MinCheckIntervalValidator = namespaceDescMinCheckInterval.Validators[0].(func(int) error)
+	// namespaceDescCreatedAt is the schema descriptor for created_at field.
+	namespaceDescCreatedAt := namespaceFields[5].Descriptor()
+	// namespace.DefaultCreatedAt holds the default value on creation for the created_at field.
+	namespace.DefaultCreatedAt = namespaceDescCreatedAt.Default.(func() time.Time)
+	// namespaceDescUpdatedAt is the schema descriptor for updated_at field.
+	namespaceDescUpdatedAt := namespaceFields[6].Descriptor()
+	// namespace.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	namespace.DefaultUpdatedAt = namespaceDescUpdatedAt.Default.(func() time.Time)
+	// namespace.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	namespace.UpdateDefaultUpdatedAt = namespaceDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// namespaceDescID is the schema descriptor for id field.
+	namespaceDescID := namespaceFields[0].Descriptor()
+	// namespace.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	namespace.IDValidator = namespaceDescID.Validators[0].(func(int64) error)
+	scheduleFields := schema.Schedule{}.Fields()
+	_ = scheduleFields
+	// scheduleDescOwner is the schema descriptor for owner field.
+	scheduleDescOwner := scheduleFields[3].Descriptor()
+	// schedule.DefaultOwner holds the default value on creation for the owner field.
+	schedule.DefaultOwner = scheduleDescOwner.Default.(string)
+	// schedule.OwnerValidator is a validator for the "owner" field. It is called by the builders before save.
+	schedule.OwnerValidator = scheduleDescOwner.Validators[0].(func(string) error)
+	// scheduleDescID is the schema descriptor for id field.
+	scheduleDescID := scheduleFields[0].Descriptor()
+	// schedule.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	schedule.IDValidator = scheduleDescID.Validators[0].(func(int64) error)
 	serviceFields := schema.Service{}.Fields()
 	_ = serviceFields
+	// serviceDescNamespace is the schema descriptor for namespace field.
+	serviceDescNamespace := serviceFields[1].Descriptor()
+	// service.DefaultNamespace holds the default value on creation for the namespace field.
+	service.DefaultNamespace = serviceDescNamespace.Default.(string)
+	// service.NamespaceValidator is a validator for the "namespace" field. It is called by the builders before save.
+	service.NamespaceValidator = func() func(string) error {
+		validators := serviceDescNamespace.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(namespace string) error {
+			for _, fn := range fns {
+				if err := fn(namespace); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
 	// serviceDescName is the schema descriptor for name field.
-	serviceDescName := serviceFields[1].Descriptor()
+	serviceDescName := serviceFields[2].Descriptor()
 	// service.NameValidator is a validator for the "name" field. It is called by the builders before save.
 	service.NameValidator = func() func(string) error {
 		validators := serviceDescName.Validators
@@ -33,7 +335,7 @@ func init() {
 		}
 	}()
 	// serviceDescEndpoint is the schema descriptor for endpoint field.
-	serviceDescEndpoint := serviceFields[2].Descriptor()
+	serviceDescEndpoint := serviceFields[3].Descriptor()
 	// service.EndpointValidator is a validator for the "endpoint" field. It is called by the builders before save.
 	service.EndpointValidator = func() func(string) error {
 		validators := serviceDescEndpoint.Validators
@@ -51,21 +353,27 @@ func init() {
 		}
 	}()
 	// serviceDescStatus is the schema descriptor for status field.
-	serviceDescStatus := serviceFields[4].Descriptor()
+	serviceDescStatus := serviceFields[5].Descriptor()
 	// service.DefaultStatus holds the default value on creation for the status field.
 	service.DefaultStatus = serviceDescStatus.Default.(string)
 	// service.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	service.StatusValidator = serviceDescStatus.Validators[0].(func(string) error)
+	// serviceDescCheckInterval is the schema descriptor for check_interval field.
+	serviceDescCheckInterval := serviceFields[7].Descriptor()
+	// service.DefaultCheckInterval holds the default value on creation for the check_interval field.
+	service.DefaultCheckInterval = serviceDescCheckInterval.Default.(int)
+	// service.CheckIntervalValidator is a validator for the "check_interval" field. It is called by the builders before save.
+	service.CheckIntervalValidator = serviceDescCheckInterval.Validators[0].(func(int) error)
 	// serviceDescLastHeartbeat is the schema descriptor for last_heartbeat field.
-	serviceDescLastHeartbeat := serviceFields[5].Descriptor()
+	serviceDescLastHeartbeat := serviceFields[8].Descriptor()
 	// service.DefaultLastHeartbeat holds the default value on creation for the last_heartbeat field.
 	service.DefaultLastHeartbeat = serviceDescLastHeartbeat.Default.(func() time.Time)
 	// serviceDescCreatedAt is the schema descriptor for created_at field.
-	serviceDescCreatedAt := serviceFields[6].Descriptor()
+	serviceDescCreatedAt := serviceFields[9].Descriptor()
 	// service.DefaultCreatedAt holds the default value on creation for the created_at field.
 	service.DefaultCreatedAt = serviceDescCreatedAt.Default.(func() time.Time)
 	// serviceDescUpdatedAt is the schema descriptor for updated_at field.
-	serviceDescUpdatedAt := serviceFields[7].Descriptor()
+	serviceDescUpdatedAt := serviceFields[10].Descriptor()
 	// service.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	service.DefaultUpdatedAt = serviceDescUpdatedAt.Default.(func() time.Time)
 	// service.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -74,4 +382,32 @@ func init() {
 	serviceDescID := serviceFields[0].Descriptor()
 	// service.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	service.IDValidator = serviceDescID.Validators[0].(func(int64) error)
+	statusupdateFields := schema.StatusUpdate{}.Fields()
+	_ = statusupdateFields
+	// statusupdateDescMessage is the schema descriptor for message field.
+	statusupdateDescMessage := statusupdateFields[3].Descriptor()
+	// statusupdate.MessageValidator is a validator for the "message" field. It is called by the builders before save.
+	statusupdate.MessageValidator = func() func(string) error {
+		validators := statusupdateDescMessage.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(message string) error {
+			for _, fn := range fns {
+				if err := fn(message); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// statusupdateDescCreatedAt is the schema descriptor for created_at field.
+	statusupdateDescCreatedAt := statusupdateFields[4].Descriptor()
+	// statusupdate.DefaultCreatedAt holds the default value on creation for the created_at field.
+	statusupdate.DefaultCreatedAt = statusupdateDescCreatedAt.Default.(func() time.Time)
+	// statusupdateDescID is the schema descriptor for id field.
+	statusupdateDescID := statusupdateFields[0].Descriptor()
+	// statusupdate.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	statusupdate.IDValidator = statusupdateDescID.Validators[0].(func(int64) error)
 }
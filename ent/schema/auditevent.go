@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+// Events are append-only and hash-chained: each hash covers the event
+// content and the hash of the previous event.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key, also the order of the hash chain
+		field.Int64("id").
+			Positive().
+			Comment("Audit event unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.Int64("service_id").
+			Immutable().
+			Comment("ID of the mutated service, kept after the service is deleted, 0 for other entities"),
+
+		field.Enum("entity").
+			Values("service", "namespace", "incident", "status_update").
+			Default("service").
+			Immutable().
+			Comment("Kind of the mutated entity"),
+
+		field.Int64("entity_id").
+			Default(0).
+			Immutable().
+			Comment("ID of the mutated namespace, incident or status update, 0 for services"),
+
+		field.String("namespace").
+			MaxLen(63).
+			Immutable().
+			Comment("Namespace of the mutated service, the name of a mutated namespace, empty for incidents"),
+
+		field.Enum("action").
+			Values("create", "update", "delete").
+			Immutable().
+			Comment("Kind of mutation"),
+
+		field.String("actor").
+			MaxLen(255).
+			Immutable().
+			Comment("Identity of the caller"),
+
+		field.String("peer").
+			MaxLen(255).
+			Default("").
+			Immutable().
+			Comment("Network address of the caller"),
+
+		field.String("request_id").
+			MaxLen(64).
+			Default("").
+			Immutable().
+			Comment("Request ID of the call that caused the mutation"),
+
+		field.JSON("before", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("Entity fields before the mutation, empty for creates"),
+
+		field.JSON("after", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("Entity fields after the mutation, empty for deletes"),
+
+		field.JSON("changes", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("Changed fields mapped to their old and new values"),
+
+		field.String("prev_hash").
+			MaxLen(64).
+			Default("").
+			Immutable().
+			Comment("Hash of the previous audit event, empty for the first event"),
+
+		field.String("hash").
+			MaxLen(64).
+			NotEmpty().
+			Immutable().
+			Comment("SHA-256 over the event content and prev_hash"),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the mutation happened").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+			}),
+	}
+}
+
+// Edges of the AuditEvent.
+func (AuditEvent) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		// Each hash links exactly one successor
+		index.Fields("hash").
+			Unique(),
+
+		// Indexes for the ListAuditEvents filters
+		index.Fields("service_id"),
+		index.Fields("actor"),
+		index.Fields("namespace", "created_at"),
+		index.Fields("created_at"),
+	}
+}
+
+// Annotations of the AuditEvent.
+func (AuditEvent) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "audit_events",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// AuditHead holds the schema definition for the AuditHead entity.
+// Its single row holds the hash of the latest audit event and is locked by
+// every writer, so events are appended one after another even while the
+// audit_events table is empty.
+type AuditHead struct {
+	ent.Schema
+}
+
+// Fields of the AuditHead.
+func (AuditHead) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Positive().
+			Immutable().
+			Comment("Always 1, there is one chain"),
+
+		field.String("hash").
+			MaxLen(64).
+			Default("").
+			Comment("Hash of the latest audit event, empty before the first event"),
+	}
+}
+
+// Edges of the AuditHead.
+func (AuditHead) Edges() []ent.Edge {
+	return nil
+}
+
+// Annotations of the AuditHead.
+func (AuditHead) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "audit_head",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// CheckResult holds the schema definition for the CheckResult entity.
+// One row is written for every health check of a service.
+type CheckResult struct {
+	ent.Schema
+}
+
+// Fields of the CheckResult.
+func (CheckResult) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Check result unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.Int64("service_id").
+			Immutable().
+			Comment("ID of the checked service"),
+
+		field.String("status").
+			MaxLen(50).
+			Immutable().
+			Comment("Outcome of the check, e.g. healthy or unhealthy"),
+
+		field.String("message").
+			MaxLen(1000).
+			Default("").
+			Immutable().
+			Comment("Error or detail reported by the check"),
+
+		field.Int64("latency_ms").
+			NonNegative().
+			Default(0).
+			Immutable().
+			Comment("Duration of the check in milliseconds"),
+
+		field.Time("checked_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the check ran").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+			}),
+
+		field.JSON("locations", []LocationResult{}).
+			Optional().
+			Immutable().
+			Comment("Outcome at every probe location of a multi-location check"),
+	}
+}
+
+// LocationResult is the outcome of a check at one probe location. Status is
+// "unknown" when the location could not run the check, e.g. its agent was
+// not connected.
+type LocationResult struct {
+	Location  string `json:"location"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Edges of the CheckResult.
+func (CheckResult) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the CheckResult.
+func (CheckResult) Indexes() []ent.Index {
+	return []ent.Index{
+		// Recent history of a service
+		index.Fields("service_id", "checked_at"),
+
+		// Retention cleanup by age
+		index.Fields("checked_at"),
+	}
+}
+
+// Annotations of the CheckResult.
+func (CheckResult) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "check_results",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Incident holds the schema definition for the Incident entity.
+// Incidents are published on the public status page.
+type Incident struct {
+	ent.Schema
+}
+
+// Fields of the Incident.
+func (Incident) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Incident unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.String("title").
+			MaxLen(255).
+			NotEmpty().
+			Comment("Customer-facing summary of the incident"),
+
+		field.Enum("status").
+			Values("investigating", "identified", "monitoring", "resolved").
+			Default("investigating").
+			Comment("Current stage of the incident, from the latest update"),
+
+		field.Enum("impact").
+			Values("none", "minor", "major", "critical").
+			Default("minor").
+			Comment("Severity shown on the status page"),
+
+		field.JSON("components", []string{}).
+			Optional().
+			Comment("Names of the affected status page components"),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the incident was opened").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+			}),
+
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("When the incident was last updated").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+				Options: "ON UPDATE CURRENT_TIMESTAMP",
+			}),
+
+		field.Time("resolved_at").
+			Optional().
+			Nillable().
+			Comment("When the incident was resolved, NULL while open"),
+	}
+}
+
+// Edges of the Incident.
+func (Incident) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Incident.
+func (Incident) Indexes() []ent.Index {
+	return []ent.Index{
+		// Incident history of the status page
+		index.Fields("created_at"),
+
+		// Open incidents
+		index.Fields("resolved_at"),
+	}
+}
+
+// Annotations of the Incident.
+func (Incident) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "incidents",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
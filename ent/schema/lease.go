@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Lease holds the schema definition for the Lease entity.
+// A lease names the replica that holds a role until it expires.
+type Lease struct {
+	ent.Schema
+}
+
+// Fields of the Lease.
+func (Lease) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Lease unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.String("name").
+			MaxLen(63).
+			NotEmpty().
+			Immutable().
+			Comment("Role the lease grants, e.g. scheduler"),
+
+		field.String("holder").
+			MaxLen(255).
+			NotEmpty().
+			Comment("Instance that holds the lease"),
+
+		field.Time("acquired_at").
+			Default(time.Now).
+			Comment("When the current holder acquired the lease"),
+
+		field.Time("expires_at").
+			Comment("When the lease lapses unless the holder renews it"),
+	}
+}
+
+// Edges of the Lease.
+func (Lease) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Lease.
+func (Lease) Indexes() []ent.Index {
+	return []ent.Index{
+		// One holder per role
+		index.Fields("name").
+			Unique(),
+	}
+}
+
+// Annotations of the Lease.
+func (Lease) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "leases",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Member holds the schema definition for the Member entity.
+// Members are the replicas sharing the scheduled checks.
+type Member struct {
+	ent.Schema
+}
+
+// Fields of the Member.
+func (Member) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Member unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.String("instance").
+			MaxLen(255).
+			NotEmpty().
+			Immutable().
+			Comment("Name of the replica"),
+
+		field.Time("joined_at").
+			Default(time.Now).
+			Comment("When the replica joined, reset when it rejoins after expiring"),
+
+		field.Time("seen_at").
+			Default(time.Now).
+			Comment("Latest heartbeat of the replica"),
+	}
+}
+
+// Edges of the Member.
+func (Member) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Member.
+func (Member) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("instance").
+			Unique(),
+	}
+}
+
+// Annotations of the Member.
+func (Member) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "members",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
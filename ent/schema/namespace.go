@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"regexp"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Namespace holds the schema definition for the Namespace entity.
+type Namespace struct {
+	ent.Schema
+}
+
+// Fields of the Namespace.
+func (Namespace) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Namespace unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.String("name").
+			MaxLen(63).
+			NotEmpty().
+			Match(regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)).
+			Immutable().
+			Comment("Namespace name, referenced by services"),
+
+		field.String("description").
+			MaxLen(500).
+			Default("").
+			Comment("Free-form description of the owning team or project"),
+
+		field.Int("max_services").
+			NonNegative().
+			Default(0).
+			Comment("Maximum number of services in the namespace, 0 means unlimited"),
+
+		field.Int("min_check_interval").
+			NonNegative().
+			Default(0).
+			Comment("Smallest check interval in seconds services may use, 0 means no limit"),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the namespace was created").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+			}),
+
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("When the namespace record was last updated").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+				Options: "ON UPDATE CURRENT_TIMESTAMP",
+			}),
+	}
+}
+
+// Edges of the Namespace.
+func (Namespace) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Namespace.
+func (Namespace) Indexes() []ent.Index {
+	return []ent.Index{
+		// Namespace names are referenced by services and must be unique
+		index.Fields("name").
+			Unique(),
+	}
+}
+
+// Annotations of the Namespace.
+func (Namespace) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "namespaces",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
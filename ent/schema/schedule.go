@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Schedule holds the schema definition for the Schedule entity.
+// A schedule is claimed by the replica running the next check of a service,
+// so the check runs once even while replicas disagree about ownership.
+type Schedule struct {
+	ent.Schema
+}
+
+// Fields of the Schedule.
+func (Schedule) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Schedule unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.Int64("service_id").
+			Immutable().
+			Comment("ID of the scheduled service"),
+
+		field.Time("next_check_at").
+			Comment("When the next check is due, or when the claim on a running check lapses"),
+
+		field.String("owner").
+			MaxLen(255).
+			Default("").
+			Comment("Replica that last claimed the check"),
+	}
+}
+
+// Edges of the Schedule.
+func (Schedule) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Schedule.
+func (Schedule) Indexes() []ent.Index {
+	return []ent.Index{
+		// One schedule per service
+		index.Fields("service_id").
+			Unique(),
+	}
+}
+
+// Annotations of the Schedule.
+func (Schedule) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "schedules",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
@@ -26,6 +26,12 @@ func (Service) Fields() []ent.Field {
 				Options: "AUTO_INCREMENT",
 			}),
 
+		field.String("namespace").
+			MaxLen(63).
+			NotEmpty().
+			Default("default").
+			Comment("Namespace the service belongs to"),
+
 		field.String("name").
 			MaxLen(255).
 			NotEmpty().
@@ -58,6 +64,15 @@ func (Service) Fields() []ent.Field {
 			Default("active").
 			Comment("Current service status"),
 
+		field.JSON("labels", map[string]string{}).
+			Optional().
+			Comment("Free-form key/value labels, e.g. env=prod"),
+
+		field.Int("check_interval").
+			Positive().
+			Default(60).
+			Comment("Seconds between health checks"),
+
 		field.Time("last_heartbeat").
 			Default(time.Now).
 			Comment("Timestamp of last heartbeat/update").
@@ -93,10 +108,13 @@ func (Service) Edges() []ent.Edge {
 // Indexes of the Service.
 func (Service) Indexes() []ent.Index {
 	return []ent.Index{
-		// Unique constraint on name and endpoint combination
-		index.Fields("name", "endpoint").
+		// Unique constraint on namespace, name and endpoint combination
+		index.Fields("namespace", "name", "endpoint").
 			Unique(),
 
+		// Index on namespace for per-namespace listing and quota counts
+		index.Fields("namespace"),
+
 		// Index on type for filtering services by type
 		index.Fields("type"),
 
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// StatusUpdate holds the schema definition for the StatusUpdate entity.
+// Status updates form the timeline of an incident.
+type StatusUpdate struct {
+	ent.Schema
+}
+
+// Fields of the StatusUpdate.
+func (StatusUpdate) Fields() []ent.Field {
+	return []ent.Field{
+		// Auto-incrementing primary key
+		field.Int64("id").
+			Positive().
+			Comment("Status update unique identifier (auto-increment)").
+			Annotations(entsql.Annotation{
+				Options: "AUTO_INCREMENT",
+			}),
+
+		field.Int64("incident_id").
+			Immutable().
+			Comment("ID of the updated incident"),
+
+		field.Enum("status").
+			Values("investigating", "identified", "monitoring", "resolved").
+			Immutable().
+			Comment("Stage of the incident when the update was posted"),
+
+		field.String("message").
+			MaxLen(5000).
+			NotEmpty().
+			Immutable().
+			Comment("Customer-facing update text"),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the update was posted").
+			Annotations(entsql.Annotation{
+				Default: "CURRENT_TIMESTAMP",
+			}),
+	}
+}
+
+// Edges of the StatusUpdate.
+func (StatusUpdate) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the StatusUpdate.
+func (StatusUpdate) Indexes() []ent.Index {
+	return []ent.Index{
+		// Timeline of an incident
+		index.Fields("incident_id", "created_at"),
+	}
+}
+
+// Annotations of the StatusUpdate.
+func (StatusUpdate) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "incident_updates",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_unicode_ci",
+			Options:   "ENGINE=InnoDB",
+		},
+	}
+}
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +19,8 @@ type Service struct {
 	// ID of the ent.
 	// Service unique identifier (auto-increment)
 	ID int64 `json:"id,omitempty"`
+	// Namespace the service belongs to
+	Namespace string `json:"namespace,omitempty"`
 	// Service name
 	Name string `json:"name,omitempty"`
 	// Service endpoint URL
@@ -26,6 +29,10 @@ type Service struct {
 	Type service.Type `json:"type,omitempty"`
 	// Current service status
 	Status string `json:"status,omitempty"`
+	// Free-form key/value labels, e.g. env=prod
+	Labels map[string]string `json:"labels,omitempty"`
+	// Seconds between health checks
+	CheckInterval int `json:"check_interval,omitempty"`
 	// Timestamp of last heartbeat/update
 	LastHeartbeat time.Time `json:"last_heartbeat,omitempty"`
 	// When the service was first registered
@@ -40,9 +47,11 @@ func (*Service) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case service.FieldID:
+		case service.FieldLabels:
+			values[i] = new([]byte)
+		case service.FieldID, service.FieldCheckInterval:
 			values[i] = new(sql.NullInt64)
-		case service.FieldName, service.FieldEndpoint, service.FieldType, service.FieldStatus:
+		case service.FieldNamespace, service.FieldName, service.FieldEndpoint, service.FieldType, service.FieldStatus:
 			values[i] = new(sql.NullString)
 		case service.FieldLastHeartbeat, service.FieldCreatedAt, service.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -67,6 +76,12 @@ func (_m *Service) assignValues(columns []string, values []any) error {
 				return fmt.Errorf("unexpected type %T for field id", value)
 			}
 			_m.ID = int64(value.Int64)
+		case service.FieldNamespace:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field namespace", values[i])
+			} else if value.Valid {
+				_m.Namespace = value.String
+			}
 		case service.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field name", values[i])
@@ -91,6 +106,20 @@ func (_m *Service) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Status = value.String
 			}
+		case service.FieldLabels:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field labels", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Labels); err != nil {
+					return fmt.Errorf("unmarshal field labels: %w", err)
+				}
+			}
+		case service.FieldCheckInterval:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field check_interval", values[i])
+			} else if value.Valid {
+				_m.CheckInterval = int(value.Int64)
+			}
 		case service.FieldLastHeartbeat:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field last_heartbeat", values[i])
@@ -145,6 +174,9 @@ func (_m *Service) String() string {
 	var builder strings.Builder
 	builder.WriteString("Service(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("namespace=")
+	builder.WriteString(_m.Namespace)
+	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(_m.Name)
 	builder.WriteString(", ")
@@ -157,6 +189,12 @@ func (_m *Service) String() string {
 	builder.WriteString("status=")
 	builder.WriteString(_m.Status)
 	builder.WriteString(", ")
+	builder.WriteString("labels=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Labels))
+	builder.WriteString(", ")
+	builder.WriteString("check_interval=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CheckInterval))
+	builder.WriteString(", ")
 	builder.WriteString("last_heartbeat=")
 	builder.WriteString(_m.LastHeartbeat.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -14,6 +14,8 @@ const (
 	Label = "service"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldNamespace holds the string denoting the namespace field in the database.
+	FieldNamespace = "namespace"
 	// FieldName holds the string denoting the name field in the database.
 	FieldName = "name"
 	// FieldEndpoint holds the string denoting the endpoint field in the database.
@@ -22,6 +24,10 @@ const (
 	FieldType = "type"
 	// FieldStatus holds the string denoting the status field in the database.
 	FieldStatus = "status"
+	// FieldLabels holds the string denoting the labels field in the database.
+	FieldLabels = "labels"
+	// FieldCheckInterval holds the string denoting the check_interval field in the database.
+	FieldCheckInterval = "check_interval"
 	// FieldLastHeartbeat holds the string denoting the last_heartbeat field in the database.
 	FieldLastHeartbeat = "last_heartbeat"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
@@ -35,10 +41,13 @@ const (
 // Columns holds all SQL columns for service fields.
 var Columns = []string{
 	FieldID,
+	FieldNamespace,
 	FieldName,
 	FieldEndpoint,
 	FieldType,
 	FieldStatus,
+	FieldLabels,
+	FieldCheckInterval,
 	FieldLastHeartbeat,
 	FieldCreatedAt,
 	FieldUpdatedAt,
@@ -55,6 +64,10 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultNamespace holds the default value on creation for the "namespace" field.
+	DefaultNamespace string
+	// NamespaceValidator is a validator for the "namespace" field. It is called by the builders before save.
+	NamespaceValidator func(string) error
 	// NameValidator is a validator for the "name" field. It is called by the builders before save.
 	NameValidator func(string) error
 	// EndpointValidator is a validator for the "endpoint" field. It is called by the builders before save.
@@ -63,6 +76,10 @@ var (
 	DefaultStatus string
 	// StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	StatusValidator func(string) error
+	// DefaultCheckInterval holds the default value on creation for the "check_interval" field.
+	DefaultCheckInterval int
+	// CheckIntervalValidator is a validator for the "check_interval" field. It is called by the builders before save.
+	CheckIntervalValidator func(int) error
 	// DefaultLastHeartbeat holds the default value on creation for the "last_heartbeat" field.
 	DefaultLastHeartbeat func() time.Time
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
@@ -118,6 +135,11 @@ func ByID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldID, opts...).ToFunc()
 }
 
+// ByNamespace orders the results by the namespace field.
+func ByNamespace(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNamespace, opts...).ToFunc()
+}
+
 // ByName orders the results by the name field.
 func ByName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldName, opts...).ToFunc()
@@ -138,6 +160,11 @@ func ByStatus(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStatus, opts...).ToFunc()
 }
 
+// ByCheckInterval orders the results by the check_interval field.
+func ByCheckInterval(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCheckInterval, opts...).ToFunc()
+}
+
 // ByLastHeartbeat orders the results by the last_heartbeat field.
 func ByLastHeartbeat(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldLastHeartbeat, opts...).ToFunc()
@@ -54,6 +54,11 @@ func IDLTE(id int64) predicate.Service {
 	return predicate.Service(sql.FieldLTE(FieldID, id))
 }
 
+// Namespace applies equality check predicate on the "namespace" field. It's identical to NamespaceEQ.
+func Namespace(v string) predicate.Service {
+	return predicate.Service(sql.FieldEQ(FieldNamespace, v))
+}
+
 // Name applies equality check predicate on the "name" field. It's identical to NameEQ.
 func Name(v string) predicate.Service {
 	return predicate.Service(sql.FieldEQ(FieldName, v))
@@ -69,6 +74,11 @@ func Status(v string) predicate.Service {
 	return predicate.Service(sql.FieldEQ(FieldStatus, v))
 }
 
+// CheckInterval applies equality check predicate on the "check_interval" field. It's identical to CheckIntervalEQ.
+func CheckInterval(v int) predicate.Service {
+	return predicate.Service(sql.FieldEQ(FieldCheckInterval, v))
+}
+
 // LastHeartbeat applies equality check predicate on the "last_heartbeat" field. It's identical to LastHeartbeatEQ.
 func LastHeartbeat(v time.Time) predicate.Service {
 	return predicate.Service(sql.FieldEQ(FieldLastHeartbeat, v))
@@ -84,6 +94,71 @@ func UpdatedAt(v time.Time) predicate.Service {
 	return predicate.Service(sql.FieldEQ(FieldUpdatedAt, v))
 }
 
+// NamespaceEQ applies the EQ predicate on the "namespace" field.
+func NamespaceEQ(v string) predicate.Service {
+	return predicate.Service(sql.FieldEQ(FieldNamespace, v))
+}
+
+// NamespaceNEQ applies the NEQ predicate on the "namespace" field.
+func NamespaceNEQ(v string) predicate.Service {
+	return predicate.Service(sql.FieldNEQ(FieldNamespace, v))
+}
+
+// NamespaceIn applies the In predicate on the "namespace" field.
+func NamespaceIn(vs ...string) predicate.Service {
+	return predicate.Service(sql.FieldIn(FieldNamespace, vs...))
+}
+
+// NamespaceNotIn applies the NotIn predicate on the "namespace" field.
+func NamespaceNotIn(vs ...string) predicate.Service {
+	return predicate.Service(sql.FieldNotIn(FieldNamespace, vs...))
+}
+
+// NamespaceGT applies the GT predicate on the "namespace" field.
+func NamespaceGT(v string) predicate.Service {
+	return predicate.Service(sql.FieldGT(FieldNamespace, v))
+}
+
+// NamespaceGTE applies the GTE predicate on the "namespace" field.
+func NamespaceGTE(v string) predicate.Service {
+	return predicate.Service(sql.FieldGTE(FieldNamespace, v))
+}
+
+// NamespaceLT applies the LT predicate on the "namespace" field.
+func NamespaceLT(v string) predicate.Service {
+	return predicate.Service(sql.FieldLT(FieldNamespace, v))
+}
+
+// NamespaceLTE applies the LTE predicate on the "namespace" field.
+func NamespaceLTE(v string) predicate.Service {
+	return predicate.Service(sql.FieldLTE(FieldNamespace, v))
+}
+
+// NamespaceContains applies the Contains predicate on the "namespace" field.
+func NamespaceContains(v string) predicate.Service {
+	return predicate.Service(sql.FieldContains(FieldNamespace, v))
+}
+
+// NamespaceHasPrefix applies the HasPrefix predicate on the "namespace" field.
+func NamespaceHasPrefix(v string) predicate.Service {
+	return predicate.Service(sql.FieldHasPrefix(FieldNamespace, v))
+}
+
+// NamespaceHasSuffix applies the HasSuffix predicate on the "namespace" field.
+func NamespaceHasSuffix(v string) predicate.Service {
+	return predicate.Service(sql.FieldHasSuffix(FieldNamespace, v))
+}
+
+// NamespaceEqualFold applies the EqualFold predicate on the "namespace" field.
+func NamespaceEqualFold(v string) predicate.Service {
+	return predicate.Service(sql.FieldEqualFold(FieldNamespace, v))
+}
+
+// NamespaceContainsFold applies the ContainsFold predicate on the "namespace" field.
+func NamespaceContainsFold(v string) predicate.Service {
+	return predicate.Service(sql.FieldContainsFold(FieldNamespace, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Service {
 	return predicate.Service(sql.FieldEQ(FieldName, v))
@@ -299,6 +374,56 @@ func StatusContainsFold(v string) predicate.Service {
 	return predicate.Service(sql.FieldContainsFold(FieldStatus, v))
 }
 
+// LabelsIsNil applies the IsNil predicate on the "labels" field.
+func LabelsIsNil() predicate.Service {
+	return predicate.Service(sql.FieldIsNull(FieldLabels))
+}
+
+// LabelsNotNil applies the NotNil predicate on the "labels" field.
+func LabelsNotNil() predicate.Service {
+	return predicate.Service(sql.FieldNotNull(FieldLabels))
+}
+
+// CheckIntervalEQ applies the EQ predicate on the "check_interval" field.
+func CheckIntervalEQ(v int) predicate.Service {
+	return predicate.Service(sql.FieldEQ(FieldCheckInterval, v))
+}
+
+// CheckIntervalNEQ applies the NEQ predicate on the "check_interval" field.
+func CheckIntervalNEQ(v int) predicate.Service {
+	return predicate.Service(sql.FieldNEQ(FieldCheckInterval, v))
+}
+
+// CheckIntervalIn applies the In predicate on the "check_interval" field.
+func CheckIntervalIn(vs ...int) predicate.Service {
+	return predicate.Service(sql.FieldIn(FieldCheckInterval, vs...))
+}
+
+// CheckIntervalNotIn applies the NotIn predicate on the "check_interval" field.
+func CheckIntervalNotIn(vs ...int) predicate.Service {
+	return predicate.Service(sql.FieldNotIn(FieldCheckInterval, vs...))
+}
+
+// CheckIntervalGT applies the GT predicate on the "check_interval" field.
+func CheckIntervalGT(v int) predicate.Service {
+	return predicate.Service(sql.FieldGT(FieldCheckInterval, v))
+}
+
+// CheckIntervalGTE applies the GTE predicate on the "check_interval" field.
+func CheckIntervalGTE(v int) predicate.Service {
+	return predicate.Service(sql.FieldGTE(FieldCheckInterval, v))
+}
+
+// CheckIntervalLT applies the LT predicate on the "check_interval" field.
+func CheckIntervalLT(v int) predicate.Service {
+	return predicate.Service(sql.FieldLT(FieldCheckInterval, v))
+}
+
+// CheckIntervalLTE applies the LTE predicate on the "check_interval" field.
+func CheckIntervalLTE(v int) predicate.Service {
+	return predicate.Service(sql.FieldLTE(FieldCheckInterval, v))
+}
+
 // LastHeartbeatEQ applies the EQ predicate on the "last_heartbeat" field.
 func LastHeartbeatEQ(v time.Time) predicate.Service {
 	return predicate.Service(sql.FieldEQ(FieldLastHeartbeat, v))
@@ -20,6 +20,20 @@ type ServiceCreate struct {
 	hooks    []Hook
 }
 
+// SetNamespace sets the "namespace" field.
+func (_c *ServiceCreate) SetNamespace(v string) *ServiceCreate {
+	_c.mutation.SetNamespace(v)
+	return _c
+}
+
+// SetNillableNamespace sets the "namespace" field if the given value is not nil.
+func (_c *ServiceCreate) SetNillableNamespace(v *string) *ServiceCreate {
+	if v != nil {
+		_c.SetNamespace(*v)
+	}
+	return _c
+}
+
 // SetName sets the "name" field.
 func (_c *ServiceCreate) SetName(v string) *ServiceCreate {
 	_c.mutation.SetName(v)
@@ -60,6 +74,26 @@ func (_c *ServiceCreate) SetNillableStatus(v *string) *ServiceCreate {
 	return _c
 }
 
+// SetLabels sets the "labels" field.
+func (_c *ServiceCreate) SetLabels(v map[string]string) *ServiceCreate {
+	_c.mutation.SetLabels(v)
+	return _c
+}
+
+// SetCheckInterval sets the "check_interval" field.
+func (_c *ServiceCreate) SetCheckInterval(v int) *ServiceCreate {
+	_c.mutation.SetCheckInterval(v)
+	return _c
+}
+
+// SetNillableCheckInterval sets the "check_interval" field if the given value is not nil.
+func (_c *ServiceCreate) SetNillableCheckInterval(v *int) *ServiceCreate {
+	if v != nil {
+		_c.SetCheckInterval(*v)
+	}
+	return _c
+}
+
 // SetLastHeartbeat sets the "last_heartbeat" field.
 func (_c *ServiceCreate) SetLastHeartbeat(v time.Time) *ServiceCreate {
 	_c.mutation.SetLastHeartbeat(v)
@@ -143,6 +177,10 @@ func (_c *ServiceCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (_c *ServiceCreate) defaults() {
+	if _, ok := _c.mutation.Namespace(); !ok {
+		v := service.DefaultNamespace
+		_c.mutation.SetNamespace(v)
+	}
 	if _, ok := _c.mutation.GetType(); !ok {
 		v := service.DefaultType
 		_c.mutation.SetType(v)
@@ -151,6 +189,10 @@ func (_c *ServiceCreate) defaults() {
 		v := service.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.CheckInterval(); !ok {
+		v := service.DefaultCheckInterval
+		_c.mutation.SetCheckInterval(v)
+	}
 	if _, ok := _c.mutation.LastHeartbeat(); !ok {
 		v := service.DefaultLastHeartbeat()
 		_c.mutation.SetLastHeartbeat(v)
@@ -167,6 +209,14 @@ func (_c *ServiceCreate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (_c *ServiceCreate) check() error {
+	if _, ok := _c.mutation.Namespace(); !ok {
+		return &ValidationError{Name: "namespace", err: errors.New(`ent: missing required field "Service.namespace"`)}
+	}
+	if v, ok := _c.mutation.Namespace(); ok {
+		if err := service.NamespaceValidator(v); err != nil {
+			return &ValidationError{Name: "namespace", err: fmt.Errorf(`ent: validator failed for field "Service.namespace": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Service.name"`)}
 	}
@@ -199,6 +249,14 @@ func (_c *ServiceCreate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Service.status": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.CheckInterval(); !ok {
+		return &ValidationError{Name: "check_interval", err: errors.New(`ent: missing required field "Service.check_interval"`)}
+	}
+	if v, ok := _c.mutation.CheckInterval(); ok {
+		if err := service.CheckIntervalValidator(v); err != nil {
+			return &ValidationError{Name: "check_interval", err: fmt.Errorf(`ent: validator failed for field "Service.check_interval": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.LastHeartbeat(); !ok {
 		return &ValidationError{Name: "last_heartbeat", err: errors.New(`ent: missing required field "Service.last_heartbeat"`)}
 	}
@@ -245,6 +303,10 @@ func (_c *ServiceCreate) createSpec() (*Service, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = id
 	}
+	if value, ok := _c.mutation.Namespace(); ok {
+		_spec.SetField(service.FieldNamespace, field.TypeString, value)
+		_node.Namespace = value
+	}
 	if value, ok := _c.mutation.Name(); ok {
 		_spec.SetField(service.FieldName, field.TypeString, value)
 		_node.Name = value
@@ -261,6 +323,14 @@ func (_c *ServiceCreate) createSpec() (*Service, *sqlgraph.CreateSpec) {
 		_spec.SetField(service.FieldStatus, field.TypeString, value)
 		_node.Status = value
 	}
+	if value, ok := _c.mutation.Labels(); ok {
+		_spec.SetField(service.FieldLabels, field.TypeJSON, value)
+		_node.Labels = value
+	}
+	if value, ok := _c.mutation.CheckInterval(); ok {
+		_spec.SetField(service.FieldCheckInterval, field.TypeInt, value)
+		_node.CheckInterval = value
+	}
 	if value, ok := _c.mutation.LastHeartbeat(); ok {
 		_spec.SetField(service.FieldLastHeartbeat, field.TypeTime, value)
 		_node.LastHeartbeat = value
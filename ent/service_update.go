@@ -28,6 +28,20 @@ func (_u *ServiceUpdate) Where(ps ...predicate.Service) *ServiceUpdate {
 	return _u
 }
 
+// SetNamespace sets the "namespace" field.
+func (_u *ServiceUpdate) SetNamespace(v string) *ServiceUpdate {
+	_u.mutation.SetNamespace(v)
+	return _u
+}
+
+// SetNillableNamespace sets the "namespace" field if the given value is not nil.
+func (_u *ServiceUpdate) SetNillableNamespace(v *string) *ServiceUpdate {
+	if v != nil {
+		_u.SetNamespace(*v)
+	}
+	return _u
+}
+
 // SetName sets the "name" field.
 func (_u *ServiceUpdate) SetName(v string) *ServiceUpdate {
 	_u.mutation.SetName(v)
@@ -84,6 +98,39 @@ func (_u *ServiceUpdate) SetNillableStatus(v *string) *ServiceUpdate {
 	return _u
 }
 
+// SetLabels sets the "labels" field.
+func (_u *ServiceUpdate) SetLabels(v map[string]string) *ServiceUpdate {
+	_u.mutation.SetLabels(v)
+	return _u
+}
+
+// ClearLabels clears the value of the "labels" field.
+func (_u *ServiceUpdate) ClearLabels() *ServiceUpdate {
+	_u.mutation.ClearLabels()
+	return _u
+}
+
+// SetCheckInterval sets the "check_interval" field.
+func (_u *ServiceUpdate) SetCheckInterval(v int) *ServiceUpdate {
+	_u.mutation.ResetCheckInterval()
+	_u.mutation.SetCheckInterval(v)
+	return _u
+}
+
+// SetNillableCheckInterval sets the "check_interval" field if the given value is not nil.
+func (_u *ServiceUpdate) SetNillableCheckInterval(v *int) *ServiceUpdate {
+	if v != nil {
+		_u.SetCheckInterval(*v)
+	}
+	return _u
+}
+
+// AddCheckInterval adds value to the "check_interval" field.
+func (_u *ServiceUpdate) AddCheckInterval(v int) *ServiceUpdate {
+	_u.mutation.AddCheckInterval(v)
+	return _u
+}
+
 // SetLastHeartbeat sets the "last_heartbeat" field.
 func (_u *ServiceUpdate) SetLastHeartbeat(v time.Time) *ServiceUpdate {
 	_u.mutation.SetLastHeartbeat(v)
@@ -147,6 +194,11 @@ func (_u *ServiceUpdate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *ServiceUpdate) check() error {
+	if v, ok := _u.mutation.Namespace(); ok {
+		if err := service.NamespaceValidator(v); err != nil {
+			return &ValidationError{Name: "namespace", err: fmt.Errorf(`ent: validator failed for field "Service.namespace": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.Name(); ok {
 		if err := service.NameValidator(v); err != nil {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Service.name": %w`, err)}
@@ -167,6 +219,11 @@ func (_u *ServiceUpdate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Service.status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.CheckInterval(); ok {
+		if err := service.CheckIntervalValidator(v); err != nil {
+			return &ValidationError{Name: "check_interval", err: fmt.Errorf(`ent: validator failed for field "Service.check_interval": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -182,6 +239,9 @@ func (_u *ServiceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.Namespace(); ok {
+		_spec.SetField(service.FieldNamespace, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Name(); ok {
 		_spec.SetField(service.FieldName, field.TypeString, value)
 	}
@@ -194,6 +254,18 @@ func (_u *ServiceUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.Status(); ok {
 		_spec.SetField(service.FieldStatus, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Labels(); ok {
+		_spec.SetField(service.FieldLabels, field.TypeJSON, value)
+	}
+	if _u.mutation.LabelsCleared() {
+		_spec.ClearField(service.FieldLabels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.CheckInterval(); ok {
+		_spec.SetField(service.FieldCheckInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedCheckInterval(); ok {
+		_spec.AddField(service.FieldCheckInterval, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.LastHeartbeat(); ok {
 		_spec.SetField(service.FieldLastHeartbeat, field.TypeTime, value)
 	}
@@ -220,6 +292,20 @@ type ServiceUpdateOne struct {
 	mutation *ServiceMutation
 }
 
+// SetNamespace sets the "namespace" field.
+func (_u *ServiceUpdateOne) SetNamespace(v string) *ServiceUpdateOne {
+	_u.mutation.SetNamespace(v)
+	return _u
+}
+
+// SetNillableNamespace sets the "namespace" field if the given value is not nil.
+func (_u *ServiceUpdateOne) SetNillableNamespace(v *string) *ServiceUpdateOne {
+	if v != nil {
+		_u.SetNamespace(*v)
+	}
+	return _u
+}
+
 // SetName sets the "name" field.
 func (_u *ServiceUpdateOne) SetName(v string) *ServiceUpdateOne {
 	_u.mutation.SetName(v)
@@ -276,6 +362,39 @@ func (_u *ServiceUpdateOne) SetNillableStatus(v *string) *ServiceUpdateOne {
 	return _u
 }
 
+// SetLabels sets the "labels" field.
+func (_u *ServiceUpdateOne) SetLabels(v map[string]string) *ServiceUpdateOne {
+	_u.mutation.SetLabels(v)
+	return _u
+}
+
+// ClearLabels clears the value of the "labels" field.
+func (_u *ServiceUpdateOne) ClearLabels() *ServiceUpdateOne {
+	_u.mutation.ClearLabels()
+	return _u
+}
+
+// SetCheckInterval sets the "check_interval" field.
+func (_u *ServiceUpdateOne) SetCheckInterval(v int) *ServiceUpdateOne {
+	_u.mutation.ResetCheckInterval()
+	_u.mutation.SetCheckInterval(v)
+	return _u
+}
+
+// SetNillableCheckInterval sets the "check_interval" field if the given value is not nil.
+func (_u *ServiceUpdateOne) SetNillableCheckInterval(v *int) *ServiceUpdateOne {
+	if v != nil {
+		_u.SetCheckInterval(*v)
+	}
+	return _u
+}
+
+// AddCheckInterval adds value to the "check_interval" field.
+func (_u *ServiceUpdateOne) AddCheckInterval(v int) *ServiceUpdateOne {
+	_u.mutation.AddCheckInterval(v)
+	return _u
+}
+
 // SetLastHeartbeat sets the "last_heartbeat" field.
 func (_u *ServiceUpdateOne) SetLastHeartbeat(v time.Time) *ServiceUpdateOne {
 	_u.mutation.SetLastHeartbeat(v)
@@ -352,6 +471,11 @@ func (_u *ServiceUpdateOne) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *ServiceUpdateOne) check() error {
+	if v, ok := _u.mutation.Namespace(); ok {
+		if err := service.NamespaceValidator(v); err != nil {
+			return &ValidationError{Name: "namespace", err: fmt.Errorf(`ent: validator failed for field "Service.namespace": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.Name(); ok {
 		if err := service.NameValidator(v); err != nil {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Service.name": %w`, err)}
@@ -372,6 +496,11 @@ func (_u *ServiceUpdateOne) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Service.status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.CheckInterval(); ok {
+		if err := service.CheckIntervalValidator(v); err != nil {
+			return &ValidationError{Name: "check_interval", err: fmt.Errorf(`ent: validator failed for field "Service.check_interval": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -404,6 +533,9 @@ func (_u *ServiceUpdateOne) sqlSave(ctx context.Context) (_node *Service, err er
 			}
 		}
 	}
+	if value, ok := _u.mutation.Namespace(); ok {
+		_spec.SetField(service.FieldNamespace, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Name(); ok {
 		_spec.SetField(service.FieldName, field.TypeString, value)
 	}
@@ -416,6 +548,18 @@ func (_u *ServiceUpdateOne) sqlSave(ctx context.Context) (_node *Service, err er
 	if value, ok := _u.mutation.Status(); ok {
 		_spec.SetField(service.FieldStatus, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Labels(); ok {
+		_spec.SetField(service.FieldLabels, field.TypeJSON, value)
+	}
+	if _u.mutation.LabelsCleared() {
+		_spec.ClearField(service.FieldLabels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.CheckInterval(); ok {
+		_spec.SetField(service.FieldCheckInterval, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedCheckInterval(); ok {
+		_spec.AddField(service.FieldCheckInterval, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.LastHeartbeat(); ok {
 		_spec.SetField(service.FieldLastHeartbeat, field.TypeTime, value)
 	}
@@ -0,0 +1,140 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"watchdog/ent/statusupdate"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// StatusUpdate is the model entity for the StatusUpdate schema.
+type StatusUpdate struct {
+	config `json:"-"`
+	// ID of the ent.
+	// Status update unique identifier (auto-increment)
+	ID int64 `json:"id,omitempty"`
+	// ID of the updated incident
+	IncidentID int64 `json:"incident_id,omitempty"`
+	// Stage of the incident when the update was posted
+	Status statusupdate.Status `json:"status,omitempty"`
+	// Customer-facing update text
+	Message string `json:"message,omitempty"`
+	// When the update was posted
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*StatusUpdate) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case statusupdate.FieldID, statusupdate.FieldIncidentID:
+			values[i] = new(sql.NullInt64)
+		case statusupdate.FieldStatus, statusupdate.FieldMessage:
+			values[i] = new(sql.NullString)
+		case statusupdate.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the StatusUpdate fields.
+func (_m *StatusUpdate) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case statusupdate.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case statusupdate.FieldIncidentID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field incident_id", values[i])
+			} else if value.Valid {
+				_m.IncidentID = value.Int64
+			}
+		case statusupdate.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = statusupdate.Status(value.String)
+			}
+		case statusupdate.FieldMessage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field message", values[i])
+			} else if value.Valid {
+				_m.Message = value.String
+			}
+		case statusupdate.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the StatusUpdate.
+// This includes values selected through modifiers, order, etc.
+func (_m *StatusUpdate) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this StatusUpdate.
+// Note that you need to call StatusUpdate.Unwrap() before calling this method if this StatusUpdate
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *StatusUpdate) Update() *StatusUpdateUpdateOne {
+	return NewStatusUpdateClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the StatusUpdate entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *StatusUpdate) Unwrap() *StatusUpdate {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: StatusUpdate is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *StatusUpdate) String() string {
+	var builder strings.Builder
+	builder.WriteString("StatusUpdate(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("incident_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.IncidentID))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Status))
+	builder.WriteString(", ")
+	builder.WriteString("message=")
+	builder.WriteString(_m.Message)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// StatusUpdates is a parsable slice of StatusUpdate.
+type StatusUpdates []*StatusUpdate
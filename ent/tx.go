@@ -12,8 +12,26 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// AuditEvent is the client for interacting with the AuditEvent builders.
+	AuditEvent *AuditEventClient
+	// AuditHead is the client for interacting with the AuditHead builders.
+	AuditHead *AuditHeadClient
+	// CheckResult is the client for interacting with the CheckResult builders.
+	CheckResult *CheckResultClient
+	// Incident is the client for interacting with the Incident builders.
+	Incident *IncidentClient
+	// Lease is the client for interacting with the Lease builders.
+	Lease *LeaseClient
+	// Member is the client for interacting with the Member builders.
+	Member *MemberClient
+	// Namespace is the client for interacting with the Namespace builders.
+	Namespace *NamespaceClient
+	// Schedule is the client for interacting with the Schedule builders.
+	Schedule *ScheduleClient
 	// Service is the client for interacting with the Service builders.
 	Service *ServiceClient
+	// StatusUpdate is the client for interacting with the StatusUpdate builders.
+	StatusUpdate *StatusUpdateClient
 
 	// lazily loaded.
 	client     *Client
@@ -145,7 +163,16 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.AuditEvent = NewAuditEventClient(tx.config)
+	tx.AuditHead = NewAuditHeadClient(tx.config)
+	tx.CheckResult = NewCheckResultClient(tx.config)
+	tx.Incident = NewIncidentClient(tx.config)
+	tx.Lease = NewLeaseClient(tx.config)
+	tx.Member = NewMemberClient(tx.config)
+	tx.Namespace = NewNamespaceClient(tx.config)
+	tx.Schedule = NewScheduleClient(tx.config)
 	tx.Service = NewServiceClient(tx.config)
+	tx.StatusUpdate = NewStatusUpdateClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -155,7 +182,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Service.QueryXXX(), the query will be executed
+// applies a query, for example: AuditEvent.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -0,0 +1,216 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"watchdog/api"
+	"watchdog/client"
+	"watchdog/logging"
+)
+
+var logger = logging.For("federation")
+
+// Unknown is the status of the services of a child that cannot be reached
+const Unknown = "unknown"
+
+// ClusterUp is 1 while the latest poll of a child cluster succeeded
+var ClusterUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "watchdog_federation_cluster_up",
+	Help: "Whether the latest poll of a federated child cluster succeeded.",
+}, []string{"cluster"})
+
+// Config configures the child clusters a parent watchdog aggregates
+type Config struct {
+	// Clusters maps cluster names to the gRPC addresses of their watchdog
+	Clusters map[string]string
+	// Token is sent to every child, it must be an admin token there
+	Token string
+	// TLS connects to the children over TLS
+	TLS bool
+	// PlaintextToken allows sending the token without TLS
+	PlaintextToken bool
+	// PollInterval is how often every child is listed
+	PollInterval time.Duration
+}
+
+// Cluster is the latest view of a child cluster
+type Cluster struct {
+	Name      string
+	Address   string
+	Reachable bool
+	// Error is why the latest poll failed, empty when reachable
+	Error string
+	// SyncedAt is when the services were last listed, zero if never
+	SyncedAt time.Time
+	// Services carry IDs prefixed with the cluster name, and the unknown
+	// status while the cluster is unreachable
+	Services []*api.ServiceInfo
+}
+
+// Federation polls the services of child watchdogs for a merged view
+type Federation struct {
+	clusters []*cluster
+	interval time.Duration
+}
+
+type cluster struct {
+	name    string
+	address string
+	client  *client.Client
+
+	mu        sync.RWMutex
+	services  []*api.ServiceInfo
+	reachable bool
+	err       string
+	syncedAt  time.Time
+}
+
+// New creates a federation of the configured clusters, without connecting
+// to them yet
+func New(cfg Config) (*Federation, error) {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+
+	opts := []client.Option{
+		client.WithToken(cfg.Token),
+		// Polls are retried on the next interval
+		client.WithRetry(client.RetryPolicy{MaxAttempts: 1}),
+	}
+	if cfg.TLS {
+		opts = append(opts, client.WithTLS(nil))
+	}
+	if cfg.PlaintextToken {
+		opts = append(opts, client.WithPlaintextToken())
+	}
+
+	f := &Federation{interval: cfg.PollInterval}
+	for name, address := range cfg.Clusters {
+		c, err := client.New(address, opts...)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("cluster %s: %w", name, err)
+		}
+		f.clusters = append(f.clusters, &cluster{
+			name:    name,
+			address: address,
+			client:  c,
+			err:     "not polled yet",
+		})
+	}
+	slices.SortFunc(f.clusters, func(a, b *cluster) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return f, nil
+}
+
+// Close closes the connections to the children
+func (f *Federation) Close() {
+	for _, c := range f.clusters {
+		c.client.Close()
+	}
+}
+
+// Run polls every child until ctx is cancelled
+func (f *Federation) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, c := range f.clusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.run(ctx, f.interval)
+		}()
+	}
+	wg.Wait()
+}
+
+// Clusters returns the latest view of every child, by name
+func (f *Federation) Clusters() []Cluster {
+	clusters := make([]Cluster, len(f.clusters))
+	for i, c := range f.clusters {
+		clusters[i] = c.snapshot()
+	}
+	return clusters
+}
+
+func (c *cluster) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll lists the services of every namespace of the child
+func (c *cluster) poll(ctx context.Context) {
+	resp, err := c.client.API().ListServices(ctx, &api.ListServicesRequest{AllNamespaces: true})
+	if ctx.Err() != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		if c.reachable {
+			logger.Warn("child cluster unreachable", "cluster", c.name, "address", c.address, "error", err)
+		}
+		c.reachable = false
+		c.err = status.Convert(err).Message()
+		ClusterUp.WithLabelValues(c.name).Set(0)
+		return
+	}
+
+	if !c.reachable {
+		logger.Info("child cluster reachable", "cluster", c.name, "address", c.address, "services", len(resp.Services))
+	}
+	c.reachable = true
+	c.err = ""
+	c.syncedAt = time.Now()
+	c.services = resp.Services
+	ClusterUp.WithLabelValues(c.name).Set(1)
+}
+
+// snapshot copies the view of the cluster, prefixing service IDs with the
+// cluster name and marking services unknown while it is unreachable
+func (c *cluster) snapshot() Cluster {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	services := make([]*api.ServiceInfo, len(c.services))
+	for i, svc := range c.services {
+		svc = proto.Clone(svc).(*api.ServiceInfo)
+		svc.Id = c.name + "/" + svc.Id
+		if !c.reachable {
+			svc.Status = Unknown
+			svc.LastCheckStatus = Unknown
+		}
+		services[i] = svc
+	}
+
+	return Cluster{
+		Name:      c.name,
+		Address:   c.address,
+		Reachable: c.reachable,
+		Error:     c.err,
+		SyncedAt:  c.syncedAt,
+		Services:  services,
+	}
+}
@@ -0,0 +1,149 @@
+package federation
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/client"
+)
+
+func TestSnapshot(t *testing.T) {
+	services := func() []*api.ServiceInfo {
+		return []*api.ServiceInfo{
+			{Id: "1", Name: "api", Status: "active", LastCheckStatus: "healthy"},
+			{Id: "2", Name: "db", Status: "maintenance", LastCheckStatus: "unhealthy"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		reachable bool
+		want      []*api.ServiceInfo
+	}{
+		{
+			name:      "reachable",
+			reachable: true,
+			want: []*api.ServiceInfo{
+				{Id: "eu/1", Name: "api", Status: "active", LastCheckStatus: "healthy"},
+				{Id: "eu/2", Name: "db", Status: "maintenance", LastCheckStatus: "unhealthy"},
+			},
+		},
+		{
+			name: "unreachable",
+			want: []*api.ServiceInfo{
+				{Id: "eu/1", Name: "api", Status: Unknown, LastCheckStatus: Unknown},
+				{Id: "eu/2", Name: "db", Status: Unknown, LastCheckStatus: Unknown},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cluster{name: "eu", address: "eu:50051", services: services(), reachable: tt.reachable}
+			got := c.snapshot()
+
+			if got.Name != "eu" || got.Address != "eu:50051" || got.Reachable != tt.reachable {
+				t.Errorf("snapshot() = %+v", got)
+			}
+			if len(got.Services) != len(tt.want) {
+				t.Fatalf("snapshot() has %d services, want %d", len(got.Services), len(tt.want))
+			}
+			for i, want := range tt.want {
+				svc := got.Services[i]
+				if svc.Id != want.Id || svc.Name != want.Name || svc.Status != want.Status || svc.LastCheckStatus != want.LastCheckStatus {
+					t.Errorf("service %d = %v, want %v", i, svc, want)
+				}
+			}
+
+			// The polled services are copied, not modified
+			for i, svc := range services() {
+				if c.services[i].Id != svc.Id || c.services[i].Status != svc.Status {
+					t.Errorf("polled service %d modified to %v", i, c.services[i])
+				}
+			}
+		})
+	}
+}
+
+// childServer lists services, or fails while err is set
+type childServer struct {
+	api.UnimplementedWatchdogServiceServer
+	services []*api.ServiceInfo
+	err      error
+}
+
+func (s *childServer) ListServices(ctx context.Context, req *api.ListServicesRequest) (*api.ListServicesResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &api.ListServicesResponse{Services: s.services}, nil
+}
+
+func TestPoll(t *testing.T) {
+	child := &childServer{}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	api.RegisterWatchdogServiceServer(s, child)
+	go s.Serve(listener)
+	defer s.Stop()
+
+	conn, err := client.New(listener.Addr().String(), client.WithRetry(client.RetryPolicy{MaxAttempts: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := &cluster{name: "eu", address: listener.Addr().String(), client: conn, err: "not polled yet"}
+
+	steps := []struct {
+		name      string
+		services  []*api.ServiceInfo
+		err       error
+		reachable bool
+		wantErr   string
+		status    string
+	}{
+		{
+			name:      "up",
+			services:  []*api.ServiceInfo{{Id: "1", Status: "active", LastCheckStatus: "healthy"}},
+			reachable: true,
+			status:    "active",
+		},
+		{
+			name:    "down keeps the last services as unknown",
+			err:     status.Error(codes.Unavailable, "child is restarting"),
+			wantErr: "child is restarting",
+			status:  Unknown,
+		},
+		{
+			name:      "back up",
+			services:  []*api.ServiceInfo{{Id: "1", Status: "draining", LastCheckStatus: "healthy"}},
+			reachable: true,
+			status:    "draining",
+		},
+	}
+
+	for _, step := range steps {
+		child.services, child.err = step.services, step.err
+		c.poll(context.Background())
+
+		got := c.snapshot()
+		if got.Reachable != step.reachable || got.Error != step.wantErr {
+			t.Errorf("%s: reachable = %v, error = %q, want %v, %q", step.name, got.Reachable, got.Error, step.reachable, step.wantErr)
+		}
+		if step.reachable && got.SyncedAt.IsZero() {
+			t.Errorf("%s: not synced", step.name)
+		}
+		if len(got.Services) != 1 || got.Services[0].Id != "eu/1" || got.Services[0].Status != step.status {
+			t.Errorf("%s: services = %v, want eu/1 with status %s", step.name, got.Services, step.status)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"watchdog/api"
+	"watchdog/logging"
+	"watchdog/server"
+)
+
+var logger = logging.For("gateway")
+
+// OpenAPIPath is where the generated OpenAPI document is served
+const OpenAPIPath = "/openapi.json"
+
+// maxBodySize limits request bodies, which are small JSON messages
+const maxBodySize = 1 << 20
+
+var (
+	marshalOptions = protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// Gateway serves the WatchdogService RPCs as an HTTP/JSON API
+type Gateway struct {
+	routes  []route
+	caller  *server.HTTPCaller
+	openapi []byte
+}
+
+// New creates a gateway calling the RPC handlers of srv through caller
+func New(srv api.WatchdogServiceServer, caller *server.HTTPCaller) *Gateway {
+	g := &Gateway{
+		routes: newRoutes(srv),
+		caller: caller,
+	}
+
+	openapi, err := buildOpenAPI(g.routes)
+	if err != nil {
+		// The document is derived from static descriptors, so this is a programming error
+		logger.Error("failed to build OpenAPI document", "error", err)
+	}
+	g.openapi = openapi
+
+	return g
+}
+
+// ServeHTTP dispatches a request to the matching route
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == OpenAPIPath {
+		if r.Method != http.MethodGet {
+			WriteError(w, status.Error(codes.Unimplemented, "method not allowed"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(g.openapi)
+		return
+	}
+
+	pathMatched := false
+	for _, rt := range g.routes {
+		params, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method != r.Method {
+			continue
+		}
+
+		g.serveRoute(w, r, rt, params)
+		return
+	}
+
+	if pathMatched {
+		w.Header().Set("Allow", g.allowedMethods(r.URL.Path))
+		WriteJSONStatus(w, http.StatusMethodNotAllowed, status.New(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	WriteError(w, status.Errorf(codes.NotFound, "no route for %s %s", r.Method, r.URL.Path))
+}
+
+// allowedMethods lists the methods of every route matching path
+func (g *Gateway) allowedMethods(path string) string {
+	var methods []string
+	for _, rt := range g.routes {
+		if _, ok := rt.match(path); ok {
+			methods = append(methods, rt.method)
+		}
+	}
+	return strings.Join(methods, ", ")
+}
+
+func (g *Gateway) serveRoute(w http.ResponseWriter, r *http.Request, rt route, params map[string]string) {
+	req := rt.newRequest()
+
+	if rt.body {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			WriteError(w, status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err))
+			return
+		}
+		if len(body) > 0 {
+			if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+				WriteError(w, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+				return
+			}
+		}
+	} else if err := bindQuery(req, r.URL.Query()); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	// Path parameters win over body and query values
+	for name, value := range params {
+		if err := setField(req, name, value); err != nil {
+			WriteError(w, err)
+			return
+		}
+	}
+
+	resp, err := g.caller.Invoke(w, r, rt.rpc, req, func(ctx context.Context, req any) (any, error) {
+		return rt.call(ctx, req.(proto.Message))
+	})
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	data, err := marshalOptions.Marshal(resp.(proto.Message))
+	if err != nil {
+		WriteError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// bindQuery sets scalar request fields from query parameters
+func bindQuery(req proto.Message, query map[string][]string) error {
+	for name, values := range query {
+		if len(values) == 0 {
+			continue
+		}
+		if err := setField(req, name, values[len(values)-1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setField parses value into the scalar field with the given proto name
+func setField(req proto.Message, name, value string) error {
+	msg := req.ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		fd = msg.Descriptor().Fields().ByJSONName(name)
+	}
+	if fd == nil || fd.IsList() || fd.IsMap() || fd.Message() != nil {
+		return status.Errorf(codes.InvalidArgument, "unknown parameter %q", name)
+	}
+
+	// Reuse the protojson scalar parsing by decoding a one-field document.
+	// protojson accepts quoted numbers and enum names, but not quoted bools.
+	encoded, _ := json.Marshal(value)
+	if fd.Kind() == protoreflect.BoolKind {
+		encoded = []byte(value)
+	}
+
+	tmp := msg.New().Interface()
+	if err := unmarshalOptions.Unmarshal([]byte(`{"`+string(fd.Name())+`":`+string(encoded)+`}`), tmp); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid value for %s: %q", name, value)
+	}
+	msg.Set(fd, tmp.ProtoReflect().Get(fd))
+
+	return nil
+}
+
+// WriteError renders a gRPC error as a google.rpc.Status JSON body, with
+// the HTTP status matching its code
+func WriteError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	WriteJSONStatus(w, HTTPStatusFromCode(st.Code()), st)
+}
+
+// WriteJSONStatus renders st as a google.rpc.Status JSON body with httpStatus
+func WriteJSONStatus(w http.ResponseWriter, httpStatus int, st *status.Status) {
+	data, err := marshalOptions.Marshal(st.Proto())
+	if err != nil {
+		data = []byte(`{"code":13,"message":"failed to encode error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(data)
+}
+
+// HTTPStatusFromCode maps a gRPC code to the matching HTTP status, following
+// https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.Unknown:
+		return http.StatusInternalServerError
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Internal:
+		return http.StatusInternalServerError
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DataLoss:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// rpcFunc calls an RPC handler with a request of the matching type
+type rpcFunc func(ctx context.Context, req proto.Message) (proto.Message, error)
@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// trailerFrame flags the length-prefixed frame carrying the trailers
+	// at the end of a gRPC-Web response body
+	trailerFrame = 0x80
+)
+
+// grpcWebHeaders are the request headers browsers may send on gRPC-Web calls
+var grpcWebHeaders = []string{"authorization", "content-type", "x-request-id", "x-grpc-web", "x-user-agent", "grpc-timeout"}
+
+// grpcWebExposedHeaders are the response headers scripts of other origins may read
+var grpcWebExposedHeaders = []string{"grpc-status", "grpc-message", "x-request-id"}
+
+// GRPCWeb serves gRPC-Web requests, including server streaming, from the
+// native gRPC server and passes every other request on to next. Cross-origin
+// calls are allowed from allowedOrigins only, where "*" allows any origin.
+//
+// A gRPC-Web call is translated into the HTTP/2 gRPC request served by
+// grpc.Server.ServeHTTP, so it runs through the interceptors and stats
+// handlers of native calls. The trailers are then appended to the body as
+// the protocol requires.
+func GRPCWeb(grpcServer *grpc.Server, allowedOrigins []string, next http.Handler) http.Handler {
+	allowed := func(origin string) bool {
+		return slices.Contains(allowedOrigins, "*") || slices.Contains(allowedOrigins, origin)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case isGRPCWebPreflight(r):
+			servePreflight(w, r, allowed(r.Header.Get("Origin")))
+		case isGRPCWeb(r):
+			if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(r, origin) {
+				if !allowed(origin) {
+					http.Error(w, "origin not allowed", http.StatusForbidden)
+					return
+				}
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(grpcWebExposedHeaders, ", "))
+				w.Header().Add("Vary", "Origin")
+			}
+			serveGRPCWeb(grpcServer, w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func isGRPCWeb(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), grpcWebContentType)
+}
+
+// isGRPCWebPreflight reports whether r is the CORS preflight of a gRPC-Web
+// call, which browsers send before cross-origin calls
+func isGRPCWebPreflight(r *http.Request) bool {
+	if r.Method != http.MethodOptions || r.Header.Get("Origin") == "" || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		if strings.EqualFold(strings.TrimSpace(header), "x-grpc-web") {
+			return true
+		}
+	}
+	return false
+}
+
+func servePreflight(w http.ResponseWriter, r *http.Request, allowed bool) {
+	if !allowed {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(grpcWebHeaders, ", "))
+	w.Header().Set("Access-Control-Max-Age", "600")
+	w.Header().Add("Vary", "Origin")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sameOrigin reports whether origin is the host the request was sent to,
+// browsers also send an Origin header on same-origin calls
+func sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// serveGRPCWeb serves one gRPC-Web call from the gRPC server
+func serveGRPCWeb(grpcServer *grpc.Server, w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+	prefix := grpcWebContentType
+	if text {
+		prefix = grpcWebTextContentType
+	}
+
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2.0"
+	// "+proto" and other subtypes carry over
+	req.Header.Set("Content-Type", "application/grpc"+strings.TrimPrefix(contentType, prefix))
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	if text {
+		req.Body = io.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	}
+
+	resp := &grpcWebResponse{w: w, header: make(http.Header), contentPrefix: prefix, text: text}
+	grpcServer.ServeHTTP(resp, req)
+	resp.finish()
+}
+
+// grpcWebResponse turns the HTTP/2 response of the gRPC server into a
+// gRPC-Web response
+type grpcWebResponse struct {
+	w      http.ResponseWriter
+	header http.Header
+	// contentPrefix replaces "application/grpc" in the content type
+	contentPrefix string
+	// text base64-encodes the body for application/grpc-web-text
+	text        bool
+	wroteHeader bool
+}
+
+func (g *grpcWebResponse) Header() http.Header {
+	return g.header
+}
+
+func (g *grpcWebResponse) WriteHeader(code int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+
+	trailers := g.declaredTrailers()
+	for key, values := range g.header {
+		if key == "Trailer" || trailers[key] || strings.HasPrefix(key, http.TrailerPrefix) {
+			continue
+		}
+		if key == "Content-Type" && len(values) > 0 {
+			if subtype, found := strings.CutPrefix(values[0], "application/grpc"); found {
+				values = []string{g.contentPrefix + subtype}
+			}
+		}
+		g.w.Header()[key] = values
+	}
+	g.w.WriteHeader(code)
+}
+
+func (g *grpcWebResponse) Write(data []byte) (int, error) {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.text {
+		// Every write is padded on its own, clients decode the body in
+		// groups of four characters
+		if _, err := io.WriteString(g.w, base64.StdEncoding.EncodeToString(data)); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	return g.w.Write(data)
+}
+
+func (g *grpcWebResponse) Flush() {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	if f, ok := g.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// declaredTrailers are the keys announced in the Trailer header
+func (g *grpcWebResponse) declaredTrailers() map[string]bool {
+	trailers := make(map[string]bool)
+	for _, value := range g.header.Values("Trailer") {
+		for _, key := range strings.Split(value, ",") {
+			trailers[http.CanonicalHeaderKey(strings.TrimSpace(key))] = true
+		}
+	}
+	return trailers
+}
+
+// finish writes the trailers set by the gRPC server as the last frame
+func (g *grpcWebResponse) finish() {
+	var block bytes.Buffer
+	trailers := g.declaredTrailers()
+	for key, values := range g.header {
+		name, found := strings.CutPrefix(key, http.TrailerPrefix)
+		if !found && !trailers[key] {
+			continue
+		}
+		for _, value := range values {
+			block.WriteString(strings.ToLower(name) + ": " + value + "\r\n")
+		}
+	}
+	if block.Len() == 0 {
+		return
+	}
+
+	frame := make([]byte, 5, 5+block.Len())
+	frame[0] = trailerFrame
+	binary.BigEndian.PutUint32(frame[1:], uint32(block.Len()))
+	frame = append(frame, block.Bytes()...)
+	if _, err := g.Write(frame); err != nil {
+		logger.Debug("failed to write gRPC-Web trailers", "error", err)
+	}
+	g.Flush()
+}
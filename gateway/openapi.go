@@ -0,0 +1,189 @@
+package gateway
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// buildOpenAPI generates an OpenAPI 3 document from the routes and the
+// descriptors of their request and response messages
+func buildOpenAPI(routes []route) ([]byte, error) {
+	schemas := map[string]any{}
+	paths := map[string]map[string]any{}
+
+	addSchema(schemas, (&statuspb.Status{}).ProtoReflect().Descriptor())
+
+	for _, rt := range routes {
+		request := rt.newRequest().ProtoReflect().Descriptor()
+		reply := rt.newReply().ProtoReflect().Descriptor()
+		addSchema(schemas, request)
+		addSchema(schemas, reply)
+
+		pathParams := rt.pathParams()
+		var parameters []any
+		for _, name := range pathParams {
+			parameters = append(parameters, map[string]any{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(request.Fields().ByName(protoreflect.Name(name))),
+			})
+		}
+
+		operation := map[string]any{
+			"operationId": rt.rpc,
+			"summary":     rt.summary,
+			"tags":        []string{"WatchdogService"},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "A successful response",
+					"content":     jsonContent(reply),
+				},
+				"default": map[string]any{
+					"description": "An error response",
+					"content":     jsonContent((&statuspb.Status{}).ProtoReflect().Descriptor()),
+				},
+			},
+		}
+
+		if rt.body {
+			operation["requestBody"] = map[string]any{
+				"content": jsonContent(request),
+			}
+		} else {
+			// Remaining scalar fields are accepted as query parameters
+			fields := request.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if slices.Contains(pathParams, string(fd.Name())) || fd.IsList() || fd.IsMap() || fd.Message() != nil {
+					continue
+				}
+				parameters = append(parameters, map[string]any{
+					"name":   string(fd.Name()),
+					"in":     "query",
+					"schema": fieldSchema(fd),
+				})
+			}
+		}
+
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if paths[rt.template] == nil {
+			paths[rt.template] = map[string]any{}
+		}
+		paths[rt.template][strings.ToLower(rt.method)] = operation
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Watchdog API",
+			"version": "v1",
+			"description": "HTTP/JSON mapping of the watchdog.WatchdogService gRPC API. " +
+				"Send the admin or API token as `Authorization: Bearer <token>`.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}, "", "  ")
+}
+
+// jsonContent references the schema of a message as an application/json body
+func jsonContent(md protoreflect.MessageDescriptor) map[string]any {
+	return map[string]any{
+		"application/json": map[string]any{
+			"schema": map[string]any{"$ref": schemaRef(md)},
+		},
+	}
+}
+
+func schemaRef(md protoreflect.MessageDescriptor) string {
+	return "#/components/schemas/" + string(md.FullName())
+}
+
+// addSchema adds the schema of a message and of every message it references
+func addSchema(schemas map[string]any, md protoreflect.MessageDescriptor) {
+	name := string(md.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	properties := map[string]any{}
+	schemas[name] = map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[string(fd.Name())] = fieldSchema(fd)
+
+		if fd.IsMap() {
+			if value := fd.MapValue().Message(); value != nil {
+				addSchema(schemas, value)
+			}
+		} else if fd.Message() != nil && fd.Message().FullName() != "google.protobuf.Any" {
+			addSchema(schemas, fd.Message())
+		}
+	}
+}
+
+// fieldSchema describes a field the way protojson encodes it
+func fieldSchema(fd protoreflect.FieldDescriptor) map[string]any {
+	if fd.IsMap() {
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": singularSchema(fd.MapValue()),
+		}
+	}
+	if fd.IsList() {
+		return map[string]any{
+			"type":  "array",
+			"items": singularSchema(fd),
+		}
+	}
+	return singularSchema(fd)
+}
+
+func singularSchema(fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64-bit integers as strings
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		var values []string
+		enumValues := fd.Enum().Values()
+		for i := 0; i < enumValues.Len(); i++ {
+			values = append(values, string(enumValues.Get(i).Name()))
+		}
+		return map[string]any{"type": "string", "enum": values}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.Message().FullName() == "google.protobuf.Any" {
+			return map[string]any{"type": "object"}
+		}
+		return map[string]any{"$ref": schemaRef(fd.Message())}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"watchdog/api"
+)
+
+// route maps an HTTP method and path template onto an RPC. Templates are
+// slash-separated segments where "{field}" binds a request field and may be
+// followed by a custom verb, e.g. "/v1/services/{service_id}:check".
+type route struct {
+	method     string
+	template   string
+	rpc        string
+	summary    string
+	body       bool
+	newRequest func() proto.Message
+	newReply   func() proto.Message
+	call       rpcFunc
+}
+
+// unary adapts a typed RPC handler to a route
+func unary[Req any, Resp proto.Message, PReq interface {
+	*Req
+	proto.Message
+}](method, template, rpc, summary string, body bool, handler func(context.Context, PReq) (Resp, error)) route {
+	return route{
+		method:     method,
+		template:   template,
+		rpc:        rpc,
+		summary:    summary,
+		body:       body,
+		newRequest: func() proto.Message { return PReq(new(Req)) },
+		newReply: func() proto.Message {
+			var resp Resp
+			return resp.ProtoReflect().Type().New().Interface()
+		},
+		call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return handler(ctx, req.(PReq))
+		},
+	}
+}
+
+// newRoutes lists the HTTP mapping of every WatchdogService RPC
+func newRoutes(srv api.WatchdogServiceServer) []route {
+	return []route{
+		unary(http.MethodGet, "/v1/health", "GetHealth",
+			"Health of the watchdog service", false, srv.GetHealth),
+		unary(http.MethodGet, "/v1/services", "ListServices",
+			"List registered services", false, srv.ListServices),
+		unary(http.MethodPost, "/v1/services", "RegisterService",
+			"Register a service", true, srv.RegisterService),
+		unary(http.MethodGet, "/v1/services/{service_id}", "GetService",
+			"Get a service", false, srv.GetService),
+		unary(http.MethodPatch, "/v1/services/{service_id}", "UpdateService",
+			"Update a service", true, srv.UpdateService),
+		unary(http.MethodDelete, "/v1/services/{service_id}", "UnregisterService",
+			"Unregister a service", false, srv.UnregisterService),
+		unary(http.MethodPost, "/v1/services/{service_id}:check", "CheckServiceHealth",
+			"Check the health of a service now", true, srv.CheckServiceHealth),
+		unary(http.MethodPost, "/v1/services/{service_id}:heartbeat", "Heartbeat",
+			"Record a heartbeat of a self-registered service", true, srv.Heartbeat),
+		unary(http.MethodGet, "/v1/services/{service_id}/results", "ListCheckResults",
+			"List recent check results of a service", false, srv.ListCheckResults),
+		unary(http.MethodGet, "/v1/namespaces", "ListNamespaces",
+			"List namespaces", false, srv.ListNamespaces),
+		unary(http.MethodPost, "/v1/namespaces", "CreateNamespace",
+			"Create a namespace", true, srv.CreateNamespace),
+		unary(http.MethodPatch, "/v1/namespaces/{name}", "UpdateNamespace",
+			"Update the quotas of a namespace", true, srv.UpdateNamespace),
+		unary(http.MethodDelete, "/v1/namespaces/{name}", "DeleteNamespace",
+			"Delete an empty namespace", false, srv.DeleteNamespace),
+		unary(http.MethodGet, "/v1/audit-events", "ListAuditEvents",
+			"List audit events", false, srv.ListAuditEvents),
+		unary(http.MethodPost, "/v1/audit-events:verify", "VerifyAuditLog",
+			"Verify the audit hash chain", true, srv.VerifyAuditLog),
+		unary(http.MethodGet, "/v1/incidents", "ListIncidents",
+			"List status page incidents", false, srv.ListIncidents),
+		unary(http.MethodPost, "/v1/incidents", "CreateIncident",
+			"Open a status page incident", true, srv.CreateIncident),
+		unary(http.MethodPost, "/v1/incidents/{incident_id}/updates", "PostIncidentUpdate",
+			"Post an update to the timeline of an incident", true, srv.PostIncidentUpdate),
+		unary(http.MethodGet, "/v1/shards", "ListShards",
+			"List replicas and the services each one checks", false, srv.ListShards),
+		unary(http.MethodGet, "/v1/federation/services", "ListFederatedServices",
+			"List the services of every federated cluster", false, srv.ListFederatedServices),
+	}
+}
+
+// match reports whether path fits the route template and returns the bound parameters
+func (rt route) match(path string) (map[string]string, bool) {
+	templateSegments := strings.Split(strings.Trim(rt.template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range templateSegments {
+		value, err := url.PathUnescape(pathSegments[i])
+		if err != nil {
+			return nil, false
+		}
+
+		name, verb := splitVerb(segment)
+		if !strings.HasPrefix(name, "{") {
+			if segment != value {
+				return nil, false
+			}
+			continue
+		}
+
+		if verb != "" {
+			var found bool
+			value, found = strings.CutSuffix(value, ":"+verb)
+			if !found {
+				return nil, false
+			}
+		} else if strings.Contains(value, ":") {
+			// A custom verb on a route without one belongs to another route
+			return nil, false
+		}
+		if value == "" {
+			return nil, false
+		}
+
+		params[strings.Trim(name, "{}")] = value
+	}
+
+	return params, true
+}
+
+// pathParams lists the parameter names of the route template
+func (rt route) pathParams() []string {
+	var params []string
+	for _, segment := range strings.Split(strings.Trim(rt.template, "/"), "/") {
+		if name, _ := splitVerb(segment); strings.HasPrefix(name, "{") {
+			params = append(params, strings.Trim(name, "{}"))
+		}
+	}
+	return params
+}
+
+// splitVerb splits "{field}:verb" into "{field}" and "verb"
+func splitVerb(segment string) (string, string) {
+	if end := strings.Index(segment, "}"); end >= 0 {
+		if verb, found := strings.CutPrefix(segment[end+1:], ":"); found {
+			return segment[:end+1], verb
+		}
+		return segment, ""
+	}
+	return segment, ""
+}
@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"watchdog/api"
+)
+
+func TestRouteMatch(t *testing.T) {
+	tests := []struct {
+		template string
+		path     string
+		want     map[string]string
+	}{
+		{template: "/v1/services", path: "/v1/services", want: map[string]string{}},
+		{template: "/v1/services", path: "/v1/services/", want: map[string]string{}},
+		{template: "/v1/services", path: "/v1/namespaces", want: nil},
+		{template: "/v1/services/{service_id}", path: "/v1/services/42", want: map[string]string{"service_id": "42"}},
+		{template: "/v1/services/{service_id}", path: "/v1/services", want: nil},
+		{template: "/v1/services/{service_id}", path: "/v1/services/42/results", want: nil},
+		{template: "/v1/services/{service_id}", path: "/v1/services/42:check", want: nil},
+		{template: "/v1/services/{service_id}:check", path: "/v1/services/42:check", want: map[string]string{"service_id": "42"}},
+		{template: "/v1/services/{service_id}:check", path: "/v1/services/42:heartbeat", want: nil},
+		{template: "/v1/services/{service_id}:check", path: "/v1/services/42", want: nil},
+		{template: "/v1/services/{service_id}:check", path: "/v1/services/:check", want: nil},
+		{template: "/v1/services/{service_id}/results", path: "/v1/services/42/results", want: map[string]string{"service_id": "42"}},
+		{template: "/v1/namespaces/{name}", path: "/v1/namespaces/team%20a", want: map[string]string{"name": "team a"}},
+		{template: "/v1/namespaces/{name}", path: "/v1/namespaces/%zz", want: nil},
+		{template: "/v1/audit-events:verify", path: "/v1/audit-events:verify", want: map[string]string{}},
+		{template: "/v1/audit-events:verify", path: "/v1/audit-events", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template+" "+tt.path, func(t *testing.T) {
+			got, ok := route{template: tt.template}.match(tt.path)
+			if ok != (tt.want != nil) {
+				t.Fatalf("match(%q) ok = %v, want %v", tt.path, ok, tt.want != nil)
+			}
+			if ok && !maps.Equal(got, tt.want) {
+				t.Errorf("match(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRoutesAreUnambiguous checks that every path of a route template is
+// matched by that route alone among the routes of its method
+func TestRoutesAreUnambiguous(t *testing.T) {
+	routes := newRoutes(api.UnimplementedWatchdogServiceServer{})
+	for _, rt := range routes {
+		path := rt.template
+		for _, param := range rt.pathParams() {
+			path = strings.Replace(path, "{"+param+"}", "1", 1)
+		}
+
+		var matched []string
+		for _, other := range routes {
+			if other.method != rt.method {
+				continue
+			}
+			if _, ok := other.match(path); ok {
+				matched = append(matched, other.rpc)
+			}
+		}
+		if !slices.Equal(matched, []string{rt.rpc}) {
+			t.Errorf("%s %s matches %q, want only %s", rt.method, path, matched, rt.rpc)
+		}
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	tests := []struct {
+		template string
+		want     []string
+	}{
+		{template: "/v1/services", want: nil},
+		{template: "/v1/services/{service_id}", want: []string{"service_id"}},
+		{template: "/v1/services/{service_id}:check", want: []string{"service_id"}},
+		{template: "/v1/incidents/{incident_id}/updates", want: []string{"incident_id"}},
+	}
+
+	for _, tt := range tests {
+		if got := (route{template: tt.template}).pathParams(); !slices.Equal(got, tt.want) {
+			t.Errorf("pathParams(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
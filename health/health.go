@@ -0,0 +1,251 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"watchdog/api"
+	"watchdog/logging"
+)
+
+const (
+	// LivezPath reports whether the process is running
+	LivezPath = "/livez"
+	// ReadyzPath reports whether the process can serve requests
+	ReadyzPath = "/readyz"
+)
+
+const (
+	// interval is how often readiness is evaluated
+	interval = 5 * time.Second
+	// pingTimeout bounds the database ping
+	pingTimeout = 2 * time.Second
+	// schedulerTimeout is how long the scheduler may go without a pass
+	// before it is considered stuck
+	schedulerTimeout = 30 * time.Second
+)
+
+var logger = logging.For("health")
+
+// Database is what readiness needs to know about the database
+type Database interface {
+	Ping(ctx context.Context) error
+	Migrated() bool
+}
+
+// Scheduler reports when the scheduler last ran
+type Scheduler interface {
+	LastTick() time.Time
+	// Running is false on replicas standing by for leadership
+	Running() bool
+}
+
+// result is the outcome of one readiness check
+type result struct {
+	name string
+	err  error
+}
+
+// Checker evaluates the readiness of the process in the background and
+// publishes it through the grpc.health.v1 service and /readyz, so probes
+// never wait on the database
+type Checker struct {
+	db        Database
+	scheduler Scheduler
+	server    *grpchealth.Server
+
+	mu        sync.RWMutex
+	results   []result
+	evaluated time.Time
+	shutdown  bool
+}
+
+// New creates a checker that is not serving until its first evaluation
+func New(db Database) *Checker {
+	c := &Checker{
+		db:      db,
+		server:  grpchealth.NewServer(),
+		results: []result{{name: "startup", err: fmt.Errorf("readiness not evaluated yet")}},
+	}
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// WatchScheduler makes readiness depend on the scheduler making progress
+func (c *Checker) WatchScheduler(s Scheduler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scheduler = s
+}
+
+// Register adds the grpc.health.v1 service to a gRPC server
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+}
+
+// Run evaluates readiness until ctx is cancelled
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.evaluate(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports NOT_SERVING from now on, so load balancers stop sending
+// new requests while in-flight ones drain
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shutdown = true
+	c.server.Shutdown()
+}
+
+func (c *Checker) evaluate(ctx context.Context) {
+	c.mu.RLock()
+	scheduler := c.scheduler
+	c.mu.RUnlock()
+
+	results := []result{{name: "migrations"}, {name: "database"}}
+	if !c.db.Migrated() {
+		results[0].err = fmt.Errorf("schema migration has not completed")
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	results[1].err = c.db.Ping(pingCtx)
+	cancel()
+
+	if scheduler != nil && scheduler.Running() {
+		check := result{name: "scheduler"}
+		if last := scheduler.LastTick(); last.IsZero() {
+			check.err = fmt.Errorf("scheduler has not started")
+		} else if age := time.Since(last); age > schedulerTimeout {
+			check.err = fmt.Errorf("no scheduling pass for %s", age.Round(time.Second))
+		}
+		results = append(results, check)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evaluated = time.Now()
+	if c.shutdown {
+		return
+	}
+
+	previous := c.results
+	c.results = results
+	if ready(results) {
+		c.setStatus(healthpb.HealthCheckResponse_SERVING)
+		if !ready(previous) {
+			logger.InfoContext(ctx, "ready to serve")
+		}
+		return
+	}
+
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// Only log checks that start failing, not every evaluation of an outage
+	failing := make(map[string]bool)
+	for _, r := range previous {
+		failing[r.name] = r.err != nil
+	}
+	for _, r := range results {
+		if r.err != nil && !failing[r.name] {
+			logger.WarnContext(ctx, "readiness check failed", "check", r.name, "error", r.err)
+		}
+	}
+}
+
+// Alive reports whether the process is still making progress: readiness
+// keeps being evaluated and the scheduler, if watched, keeps scheduling.
+// Database outages do not count, restarting would not fix them: the ping
+// and every scheduling pass give up on a hung database instead of waiting.
+func (c *Checker) Alive() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.evaluated.IsZero() && time.Since(c.evaluated) > 3*interval+pingTimeout {
+		return fmt.Errorf("readiness not evaluated for %s", time.Since(c.evaluated).Round(time.Second))
+	}
+	if c.scheduler != nil {
+		if last := c.scheduler.LastTick(); !last.IsZero() && time.Since(last) > schedulerTimeout {
+			return fmt.Errorf("no scheduling pass for %s", time.Since(last).Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// setStatus publishes the status for the whole server and the watchdog service
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	c.server.SetServingStatus("", status)
+	c.server.SetServingStatus(api.WatchdogService_ServiceDesc.ServiceName, status)
+}
+
+// ServeLivez answers as long as the process can handle HTTP requests
+func (c *Checker) ServeLivez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprintln(w, "ok")
+}
+
+// ServeReadyz reports the latest readiness evaluation, listing every check
+// in the style of the Kubernetes API server
+func (c *Checker) ServeReadyz(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	results := c.results
+	shutdown := c.shutdown
+	c.mu.RUnlock()
+
+	var body strings.Builder
+	for _, check := range results {
+		if check.err != nil {
+			fmt.Fprintf(&body, "[-]%s failed: %v\n", check.name, check.err)
+		} else {
+			fmt.Fprintf(&body, "[+]%s ok\n", check.name)
+		}
+	}
+
+	status := http.StatusOK
+	switch {
+	case shutdown:
+		status = http.StatusServiceUnavailable
+		body.WriteString("[-]shutdown: shutting down\n")
+	case !ready(results):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusOK {
+		body.WriteString("readyz check passed\n")
+	} else {
+		body.WriteString("readyz check failed\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body.String()))
+}
+
+func ready(results []result) bool {
+	for _, r := range results {
+		if r.err != nil {
+			return false
+		}
+	}
+	return true
+}
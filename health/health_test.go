@@ -0,0 +1,178 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakeDatabase struct {
+	pingErr  error
+	migrated bool
+}
+
+func (d *fakeDatabase) Ping(ctx context.Context) error {
+	return d.pingErr
+}
+
+func (d *fakeDatabase) Migrated() bool {
+	return d.migrated
+}
+
+type fakeScheduler struct {
+	lastTick time.Time
+	running  bool
+}
+
+func (s *fakeScheduler) LastTick() time.Time {
+	return s.lastTick
+}
+
+func (s *fakeScheduler) Running() bool {
+	return s.running
+}
+
+// state is what the checker publishes after an evaluation
+type state struct {
+	serving healthpb.HealthCheckResponse_ServingStatus
+	readyz  int
+}
+
+func published(t *testing.T, c *Checker) state {
+	t.Helper()
+	resp, err := c.server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	c.ServeReadyz(rec, httptest.NewRequest(http.MethodGet, ReadyzPath, nil))
+	return state{serving: resp.Status, readyz: rec.Code}
+}
+
+var (
+	serving    = state{serving: healthpb.HealthCheckResponse_SERVING, readyz: http.StatusOK}
+	notServing = state{serving: healthpb.HealthCheckResponse_NOT_SERVING, readyz: http.StatusServiceUnavailable}
+)
+
+func TestReadinessTransitions(t *testing.T) {
+	type step struct {
+		name  string
+		setup func(db *fakeDatabase, s *fakeScheduler)
+		want  state
+	}
+
+	ok := func(db *fakeDatabase, s *fakeScheduler) {
+		*db = fakeDatabase{migrated: true}
+		*s = fakeScheduler{lastTick: time.Now(), running: true}
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "startup",
+			steps: []step{
+				{name: "migrating", setup: func(db *fakeDatabase, s *fakeScheduler) { ok(db, s); db.migrated = false }, want: notServing},
+				{name: "scheduler not started", setup: func(db *fakeDatabase, s *fakeScheduler) { ok(db, s); s.lastTick = time.Time{} }, want: notServing},
+				{name: "ready", setup: ok, want: serving},
+			},
+		},
+		{
+			name: "database outage",
+			steps: []step{
+				{name: "ready", setup: ok, want: serving},
+				{name: "ping fails", setup: func(db *fakeDatabase, s *fakeScheduler) { ok(db, s); db.pingErr = errors.New("connection refused") }, want: notServing},
+				{name: "recovered", setup: ok, want: serving},
+			},
+		},
+		{
+			name: "stuck scheduler",
+			steps: []step{
+				{name: "ready", setup: ok, want: serving},
+				{name: "no pass", setup: func(db *fakeDatabase, s *fakeScheduler) {
+					ok(db, s)
+					s.lastTick = time.Now().Add(-2 * schedulerTimeout)
+				}, want: notServing},
+				{name: "standing by", setup: func(db *fakeDatabase, s *fakeScheduler) {
+					ok(db, s)
+					s.lastTick = time.Now().Add(-2 * schedulerTimeout)
+					s.running = false
+				}, want: serving},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sched := &fakeDatabase{}, &fakeScheduler{}
+			c := New(db)
+			c.WatchScheduler(sched)
+			if got := published(t, c); got != notServing {
+				t.Fatalf("before the first evaluation: %+v, want %+v", got, notServing)
+			}
+
+			for _, step := range tt.steps {
+				step.setup(db, sched)
+				c.evaluate(context.Background())
+				if got := published(t, c); got != step.want {
+					t.Errorf("%s: %+v, want %+v", step.name, got, step.want)
+				}
+			}
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	db := &fakeDatabase{migrated: true}
+	c := New(db)
+	c.evaluate(context.Background())
+	if got := published(t, c); got != serving {
+		t.Fatalf("before shutdown: %+v, want %+v", got, serving)
+	}
+
+	c.Shutdown()
+	if got := published(t, c); got != notServing {
+		t.Errorf("after shutdown: %+v, want %+v", got, notServing)
+	}
+
+	// Later evaluations of a healthy process keep it out of rotation
+	c.evaluate(context.Background())
+	if got := published(t, c); got != notServing {
+		t.Errorf("evaluated after shutdown: %+v, want %+v", got, notServing)
+	}
+	if err := c.Alive(); err != nil {
+		t.Errorf("Alive() after shutdown = %v, want nil", err)
+	}
+}
+
+func TestAlive(t *testing.T) {
+	tests := []struct {
+		name      string
+		evaluated time.Time
+		lastTick  time.Time
+		wantErr   bool
+	}{
+		{name: "not evaluated yet"},
+		{name: "evaluated", evaluated: time.Now(), lastTick: time.Now()},
+		{name: "evaluation stalled", evaluated: time.Now().Add(-time.Minute), lastTick: time.Now(), wantErr: true},
+		{name: "scheduler stalled", evaluated: time.Now(), lastTick: time.Now().Add(-time.Minute), wantErr: true},
+		{name: "scheduler not started", evaluated: time.Now()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(&fakeDatabase{migrated: true})
+			c.WatchScheduler(&fakeScheduler{lastTick: tt.lastTick, running: true})
+			c.evaluated = tt.evaluated
+			if err := c.Alive(); (err != nil) != tt.wantErr {
+				t.Errorf("Alive() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+var logger = logging.For("leader")
+
+// releaseTimeout bounds releasing the lease on shutdown
+const releaseTimeout = 2 * time.Second
+
+// Leading is 1 while this replica holds the scheduler lease
+var Leading = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "watchdog_leader",
+	Help: "Whether this replica is the leader running the scheduler.",
+})
+
+// Leases is what the elector needs from the database
+type Leases interface {
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*database.LeaseRecord, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// Elector campaigns for the scheduler lease so that a single replica among
+// those sharing a database runs the scheduler
+type Elector struct {
+	db       Leases
+	identity string
+	ttl      time.Duration
+	renew    time.Duration
+}
+
+// New creates an elector for the replica named identity. The leader renews
+// its lease every third of ttl, and another replica takes over at most ttl
+// after the leader stopped renewing it.
+func New(db Leases, identity string, ttl time.Duration) *Elector {
+	if ttl < 3*time.Second {
+		ttl = 3 * time.Second
+	}
+
+	return &Elector{
+		db:       db,
+		identity: identity,
+		ttl:      ttl,
+		renew:    ttl / 3,
+	}
+}
+
+// Run campaigns until ctx is cancelled and runs lead while this replica is
+// the leader. The context passed to lead is cancelled when leadership is
+// lost, and Run waits for lead to return before campaigning again.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	ticker := time.NewTicker(e.renew)
+	defer ticker.Stop()
+
+	var (
+		leadCtx context.Context
+		stop    context.CancelFunc
+		done    chan struct{}
+		// expiry stops lead once ttl-renew passed since the last renewal,
+		// before another replica may take the lease over, even while a
+		// renewal is still in flight
+		expiry *time.Timer
+	)
+	stepDown := func(reason string) {
+		if stop == nil {
+			return
+		}
+		expiry.Stop()
+		stop()
+		<-done
+		leadCtx, stop, done, expiry = nil, nil, nil, nil
+		Leading.Set(0)
+		logger.Warn("stepped down as leader", "instance", e.identity, "reason", reason)
+	}
+
+	for {
+		start := time.Now()
+		lease, err := e.acquire(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				logger.Warn("failed to renew leader lease", "error", err)
+			}
+			if stop != nil && leadCtx.Err() != nil {
+				stepDown("lease could not be renewed")
+			}
+		case lease.Holder == e.identity:
+			// The lease may be held again after lead was stopped by expiry
+			if stop != nil && leadCtx.Err() != nil {
+				stepDown("lease renewed too late")
+			}
+			remaining := e.ttl - e.renew - time.Since(start)
+			if stop == nil {
+				leadCtx, stop = context.WithCancel(ctx)
+				done = make(chan struct{})
+				go func(ctx context.Context) {
+					defer close(done)
+					lead(ctx)
+				}(leadCtx)
+				expiry = time.AfterFunc(remaining, stop)
+				Leading.Set(1)
+				logger.Info("became leader", "instance", e.identity)
+			} else {
+				expiry.Reset(remaining)
+			}
+		default:
+			stepDown("lease taken by " + lease.Holder)
+		}
+
+		select {
+		case <-ctx.Done():
+			if stop != nil {
+				expiry.Stop()
+				stop()
+				<-done
+				Leading.Set(0)
+				e.release()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// acquire takes or renews the lease, giving up once the next renewal is due
+func (e *Elector) acquire(ctx context.Context) (*database.LeaseRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.renew)
+	defer cancel()
+	return e.db.AcquireLease(ctx, database.SchedulerLease, e.identity, e.ttl)
+}
+
+// release gives up the lease so another replica takes over right away
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := e.db.ReleaseLease(ctx, database.SchedulerLease, e.identity); err != nil {
+		logger.Warn("failed to release leader lease", "error", err)
+		return
+	}
+	logger.Info("released leader lease", "instance", e.identity)
+}
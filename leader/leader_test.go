@@ -0,0 +1,73 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"watchdog/database"
+)
+
+// stuckLeases grants the first lease, then blocks every renewal until
+// unblocked, ignoring its context like a hung database connection
+type stuckLeases struct {
+	calls     int
+	deadlines chan time.Duration
+	unblock   chan struct{}
+}
+
+func (l *stuckLeases) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*database.LeaseRecord, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		select {
+		case l.deadlines <- time.Until(deadline):
+		default:
+		}
+	}
+	l.calls++
+	if l.calls > 1 {
+		<-l.unblock
+		return nil, ctx.Err()
+	}
+	return &database.LeaseRecord{Name: name, Holder: holder}, nil
+}
+
+func (l *stuckLeases) ReleaseLease(ctx context.Context, name, holder string) error {
+	return nil
+}
+
+func TestRunStepsDownWhileRenewalHangs(t *testing.T) {
+	leases := &stuckLeases{deadlines: make(chan time.Duration, 1), unblock: make(chan struct{})}
+	e := New(leases, "replica-1", 3*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leading := make(chan time.Time, 1)
+	stopped := make(chan time.Time, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx, func(ctx context.Context) {
+			leading <- time.Now()
+			<-ctx.Done()
+			stopped <- time.Now()
+		})
+	}()
+
+	if deadline := <-leases.deadlines; deadline > e.renew {
+		t.Errorf("lease acquired with a %v deadline, want at most %v", deadline, e.renew)
+	}
+
+	start := <-leading
+	select {
+	case end := <-stopped:
+		// Leadership ends ttl-renew after the renewal, before the lease expires
+		if led := end.Sub(start); led < e.ttl-e.renew-100*time.Millisecond || led >= e.ttl {
+			t.Errorf("led for %v, want about %v", led, e.ttl-e.renew)
+		}
+	case <-time.After(e.ttl + time.Second):
+		t.Fatal("still leading after the lease expired")
+	}
+
+	cancel()
+	close(leases.unblock)
+	<-done
+}
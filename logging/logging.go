@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config configures the log output
+type Config struct {
+	// Level is the minimum level logged: debug, info, warn or error
+	Level string
+	// Format is "text" or "json"
+	Format string
+	// Levels overrides Level per component, e.g. {"database": "debug"}
+	Levels map[string]string
+}
+
+// root is the handler component loggers write to, replaced by Setup
+var root atomic.Pointer[rootHandler]
+
+type rootHandler struct {
+	handler slog.Handler
+	levels  map[string]slog.Level
+}
+
+func init() {
+	root.Store(&rootHandler{handler: slog.Default().Handler()})
+}
+
+// Setup configures the format and levels of every logger and makes the
+// standard log package write through it at info level
+func Setup(w io.Writer, cfg Config) error {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	levels := make(map[string]slog.Level, len(cfg.Levels))
+	minLevel := level
+	for component, name := range cfg.Levels {
+		componentLevel, err := parseLevel(name)
+		if err != nil {
+			return fmt.Errorf("component %s: %w", component, err)
+		}
+		levels[component] = componentLevel
+		minLevel = min(minLevel, componentLevel)
+	}
+
+	// The handler lets everything a component may log through, the
+	// component loggers apply the configured levels
+	options := &slog.HandlerOptions{Level: minLevel}
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		handler = slog.NewTextHandler(w, options)
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	handler = contextHandler{handler}
+	root.Store(&rootHandler{handler: handler, levels: levels})
+	slog.SetDefault(slog.New(levelHandler{Handler: handler, level: level}))
+	return nil
+}
+
+// For returns the logger of a component. Its records carry a "component"
+// attribute and follow the level configured for the component. Loggers may
+// be created before Setup is called.
+func For(component string) *slog.Logger {
+	return slog.New(componentHandler{
+		component: component,
+		wrap: func(h slog.Handler) slog.Handler {
+			return h.WithAttrs([]slog.Attr{slog.String("component", component)})
+		},
+	})
+}
+
+func parseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
+
+// componentHandler resolves the root handler on every record so component
+// loggers created at package initialization pick up the Setup configuration
+type componentHandler struct {
+	component string
+	wrap      func(slog.Handler) slog.Handler
+}
+
+func (h componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	r := root.Load()
+	if componentLevel, ok := r.levels[h.component]; ok {
+		return level >= componentLevel
+	}
+	return slog.Default().Enabled(ctx, level)
+}
+
+func (h componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.wrap(root.Load().handler).Handle(ctx, record)
+}
+
+func (h componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	wrap := h.wrap
+	return componentHandler{
+		component: h.component,
+		wrap:      func(next slog.Handler) slog.Handler { return wrap(next).WithAttrs(attrs) },
+	}
+}
+
+func (h componentHandler) WithGroup(name string) slog.Handler {
+	wrap := h.wrap
+	return componentHandler{
+		component: h.component,
+		wrap:      func(next slog.Handler) slog.Handler { return wrap(next).WithGroup(name) },
+	}
+}
+
+// levelHandler applies the default level to records of the default logger
+type levelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (h levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h levelHandler) WithGroup(name string) slog.Handler {
+	return levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// contextHandler adds the request ID and trace ID of the context to records
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
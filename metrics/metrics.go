@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"watchdog/agent"
+	"watchdog/database"
+	"watchdog/dnsserver"
+	"watchdog/federation"
+	"watchdog/leader"
+	"watchdog/probe"
+	"watchdog/scheduler"
+	"watchdog/shard"
+)
+
+// Path is where the metrics are served on the HTTP listener
+const Path = "/metrics"
+
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "watchdog_grpc_requests_total",
+		Help: "RPCs handled by the watchdog server, by method and status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "watchdog_grpc_request_duration_seconds",
+		Help:    "Latency of RPCs handled by the watchdog server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// NewRegistry creates a registry with the server, database, probe,
+// scheduler and agent metrics and the health of every service read from db
+func NewRegistry(db database.ServiceDB) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcRequests,
+		rpcDuration,
+		database.QueryDuration,
+		probe.Duration,
+		scheduler.QueueDepth,
+		scheduler.ChecksTotal,
+		agent.Connected,
+		leader.Leading,
+		shard.Members,
+		federation.ClusterUp,
+		dnsserver.Queries,
+		newServiceCollector(db),
+	)
+	return registry
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		Registry: registry,
+	})
+}
+
+// UnaryServerInterceptor counts and times unary RPCs
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts and times streaming RPCs
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeRPC(method string, start time.Time, err error) {
+	rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
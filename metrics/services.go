@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+var logger = logging.For("metrics")
+
+// collectTimeout bounds the database queries of a scrape
+const collectTimeout = 10 * time.Second
+
+// serviceCollector exports the health of every service on each scrape. It
+// is unchecked because service labels add label names per service.
+type serviceCollector struct {
+	db database.ServiceDB
+}
+
+func newServiceCollector(db database.ServiceDB) *serviceCollector {
+	return &serviceCollector{db: db}
+}
+
+// Describe sends nothing, which makes the collector unchecked
+func (c *serviceCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect reads services and their latest check from the database
+func (c *serviceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	services, err := c.db.ListServices(ctx, "")
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to collect service metrics", "error", err)
+		return
+	}
+
+	ids := make([]int64, len(services))
+	for i, service := range services {
+		ids[i] = service.ID
+	}
+	latest, err := c.db.LatestCheckResults(ctx, ids)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to collect service check metrics", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, service := range services {
+		identity := []string{"id", "namespace", "name", "type"}
+		identityValues := []string{strconv.FormatInt(service.ID, 10), service.Namespace, service.Name, string(service.Type)}
+
+		send := func(name, help string, value float64, names, values []string) {
+			desc := prometheus.NewDesc(name, help, names, nil)
+			metric, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, values...)
+			if err != nil {
+				logger.ErrorContext(ctx, "failed to export metric", "metric", name, "service_id", service.ID, "error", err)
+				return
+			}
+			ch <- metric
+		}
+
+		send("watchdog_service_heartbeat_age_seconds",
+			"Seconds since the service record was last updated.",
+			now.Sub(service.LastHeartbeat).Seconds(), identity, identityValues)
+		send("watchdog_service_check_interval_seconds",
+			"Configured seconds between health checks.",
+			float64(service.CheckInterval), identity, identityValues)
+
+		result, checked := latest[service.ID]
+		if !checked {
+			continue
+		}
+
+		labelNames, labelValues := serviceLabels(service.Labels)
+		up := 0.0
+		if result.Status == "healthy" {
+			up = 1
+		}
+		send("watchdog_service_up",
+			"Whether the latest health check of the service succeeded.",
+			up, append(slices.Clone(identity), labelNames...), append(slices.Clone(identityValues), labelValues...))
+		send("watchdog_service_last_check_timestamp_seconds",
+			"Unix time of the latest health check.",
+			float64(result.CheckedAt.Unix()), identity, identityValues)
+		send("watchdog_service_last_check_duration_seconds",
+			"Duration of the latest health check.",
+			float64(result.LatencyMs)/1000, identity, identityValues)
+	}
+}
+
+// serviceLabels turns service labels into Prometheus labels prefixed with
+// "label_", e.g. app.kubernetes.io/name becomes label_app_kubernetes_io_name
+func serviceLabels(labels map[string]string) ([]string, []string) {
+	var names, values []string
+	seen := make(map[string]bool)
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		name := "label_" + sanitizeLabelName(key)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+		values = append(values, labels[key])
+	}
+	return names, values
+}
+
+// sanitizeLabelName replaces characters Prometheus does not allow in label names
+func sanitizeLabelName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,108 @@
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// phaseTracer records the phases of an HTTP check as child spans of the
+// probe span: DNS lookup, connect, TLS handshake and time to first byte
+type phaseTracer struct {
+	ctx context.Context
+
+	mu      sync.Mutex
+	dns     trace.Span
+	connect map[string]trace.Span
+	tls     trace.Span
+	wait    trace.Span
+}
+
+func newPhaseTracer(ctx context.Context) *phaseTracer {
+	return &phaseTracer{ctx: ctx, connect: make(map[string]trace.Span)}
+}
+
+// clientTrace hooks the tracer into an HTTP request
+func (p *phaseTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.dns = p.start("dns", attribute.String("net.host.name", info.Host))
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			finish(p.dns, info.Err)
+			p.dns = nil
+		},
+		ConnectStart: func(network, addr string) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.connect[addr] = p.start("connect", attribute.String("net.peer.address", addr))
+		},
+		ConnectDone: func(network, addr string, err error) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			finish(p.connect[addr], err)
+			delete(p.connect, addr)
+		},
+		TLSHandshakeStart: func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.tls = p.start("tls")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			finish(p.tls, err)
+			p.tls = nil
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.wait = p.start("time to first byte")
+		},
+		GotFirstResponseByte: func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			finish(p.wait, nil)
+			p.wait = nil
+		},
+	}
+}
+
+// end closes phases left open by a failed request
+func (p *phaseTracer) end() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, span := range []trace.Span{p.dns, p.tls, p.wait} {
+		finish(span, nil)
+	}
+	for addr, span := range p.connect {
+		finish(span, nil)
+		delete(p.connect, addr)
+	}
+	p.dns, p.tls, p.wait = nil, nil, nil
+}
+
+func (p *phaseTracer) start(name string, attrs ...attribute.KeyValue) trace.Span {
+	_, span := tracer.Start(p.ctx, name, trace.WithAttributes(attrs...))
+	return span
+}
+
+func finish(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
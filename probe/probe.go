@@ -0,0 +1,205 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"watchdog/client"
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+// ErrUnsupportedServiceType is returned for service types without a check
+var ErrUnsupportedServiceType = errors.New("unsupported service type")
+
+// Duration observes every probe by service type and outcome
+var Duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "watchdog_probe_duration_seconds",
+	Help:    "Duration of service health checks.",
+	Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+}, []string{"type", "status"})
+
+var (
+	tracer = otel.Tracer("watchdog/probe")
+	logger = logging.For("probe")
+)
+
+// Result is the outcome of a single health check
+type Result struct {
+	Status    string
+	Message   string
+	Latency   time.Duration
+	CheckedAt time.Time
+	// Err is the check failure, nil for healthy services
+	Err error
+	// Locations holds the outcome at every location of a multi-location
+	// check, nil for checks run at one location
+	Locations []database.LocationResult
+}
+
+// Func runs the health check of a service. Run is the local implementation,
+// agent.Hub.Probe hands checks to remote agents.
+type Func func(ctx context.Context, service *database.ServiceRecord) Result
+
+// Run checks the health of a service with the check matching its type
+func Run(ctx context.Context, service *database.ServiceRecord) Result {
+	ctx, span := tracer.Start(ctx, "probe "+string(service.Type), trace.WithAttributes(
+		attribute.Int64("watchdog.service.id", service.ID),
+		attribute.String("watchdog.service.type", string(service.Type)),
+		attribute.String("watchdog.service.endpoint", service.Endpoint),
+	))
+	defer span.End()
+
+	start := time.Now()
+	healthStatus, err := check(ctx, service)
+	result := Result{
+		Status:    healthStatus,
+		Message:   "Service health checked successfully",
+		Latency:   time.Since(start),
+		CheckedAt: start,
+		Err:       err,
+	}
+
+	if errors.Is(err, ErrUnsupportedServiceType) {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return result
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		result.Status = "unhealthy"
+		result.Message = fmt.Sprintf("Service is unreachable: %v", err)
+	}
+
+	if err != nil {
+		logger.InfoContext(ctx, "probe failed", "service_id", service.ID, "type", service.Type, "endpoint", service.Endpoint, "latency", result.Latency, "error", err)
+	} else {
+		logger.DebugContext(ctx, "probe succeeded", "service_id", service.ID, "type", service.Type, "latency", result.Latency)
+	}
+
+	span.SetAttributes(attribute.String("watchdog.check.status", result.Status))
+	Duration.WithLabelValues(string(service.Type), result.Status).Observe(result.Latency.Seconds())
+	return result
+}
+
+// check runs the health check matching the service type
+func check(ctx context.Context, service *database.ServiceRecord) (string, error) {
+	switch service.Type {
+	case "SERVICE_TYPE_HTTP":
+		return checkHTTPHealth(ctx, service.Endpoint)
+	case "SERVICE_TYPE_GRPC":
+		return checkGRPCHealth(ctx, service.Endpoint)
+	case "SERVICE_TYPE_SYSTEMD":
+		return checkSystemdHealth(ctx, service.Endpoint)
+	case "SERVICE_TYPE_DATABASE", "SERVICE_TYPE_CACHE", "SERVICE_TYPE_QUEUE", "SERVICE_TYPE_STORAGE", "SERVICE_TYPE_EXTERNAL_API", "SERVICE_TYPE_MICROSERVICE", "SERVICE_TYPE_OTHER":
+		return checkDatabaseHealth(ctx, service.Endpoint)
+	default:
+		return "unhealthy", ErrUnsupportedServiceType
+	}
+}
+
+func checkHTTPHealth(ctx context.Context, endpoint string) (string, error) {
+	client := http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	phases := newPhaseTracer(ctx)
+	defer phases.end()
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, phases.clientTrace()), http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "unhealthy", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "unhealthy", err
+	}
+
+	defer resp.Body.Close()
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		return "unhealthy", fmt.Errorf("HTTP status code: %d", resp.StatusCode)
+	}
+
+	return "healthy", nil
+}
+
+func checkSystemdHealth(ctx context.Context, endpoint string) (string, error) {
+	ctx, span := tracer.Start(ctx, "systemctl is-active", trace.WithAttributes(
+		attribute.String("systemd.unit", endpoint),
+	))
+	defer span.End()
+
+	out, err := exec.CommandContext(ctx, "systemctl", "is-active", endpoint).Output()
+	if err != nil {
+		return "unhealthy", err
+	}
+	if strings.TrimSpace(string(out)) != "active" {
+		return "unhealthy", fmt.Errorf("systemd health check command returned: %s", string(out))
+	}
+
+	return "healthy", nil
+}
+
+// checkGRPCHealth calls grpc.health.v1.Health/Check for the whole server.
+// Endpoints are host:port or gRPC targets such as dns:///host:port, and
+// grpcs:// or https:// endpoints are checked over TLS.
+func checkGRPCHealth(ctx context.Context, endpoint string) (string, error) {
+	hostPort, err := client.HostPort(endpoint)
+	if err != nil {
+		return "unhealthy", err
+	}
+
+	ctx, span := tracer.Start(ctx, "grpc.health.v1.Health/Check", trace.WithAttributes(
+		attribute.String("server.address", hostPort),
+	))
+	defer span.End()
+
+	creds := insecure.NewCredentials()
+	if strings.HasPrefix(endpoint, "grpcs://") || strings.HasPrefix(endpoint, "https://") {
+		creds = credentials.NewTLS(nil)
+	}
+	conn, err := grpc.NewClient(hostPort, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return "unhealthy", err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return "unhealthy", err
+	}
+
+	span.SetAttributes(attribute.String("grpc.health.status", resp.GetStatus().String()))
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return "unhealthy", fmt.Errorf("gRPC health status: %s", resp.GetStatus())
+	}
+
+	return "healthy", nil
+}
+
+func checkDatabaseHealth(ctx context.Context, endpoint string) (string, error) {
+	return "healthy", nil
+}
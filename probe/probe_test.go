@@ -0,0 +1,80 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServer serves grpc.health.v1 on localhost, or no service at all
+// when status is nil
+func healthServer(t *testing.T, status *healthpb.HealthCheckResponse_ServingStatus) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer()
+	if status != nil {
+		h := health.NewServer()
+		h.SetServingStatus("", *status)
+		healthpb.RegisterHealthServer(s, h)
+	}
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestCheckGRPCHealth(t *testing.T) {
+	serving := healthpb.HealthCheckResponse_SERVING
+	notServing := healthpb.HealthCheckResponse_NOT_SERVING
+
+	tests := []struct {
+		name     string
+		status   *healthpb.HealthCheckResponse_ServingStatus
+		endpoint func(addr string) string
+		want     string
+	}{
+		{name: "serving", status: &serving, endpoint: func(addr string) string { return addr }, want: "healthy"},
+		{name: "dns target", status: &serving, endpoint: func(addr string) string { return "dns:///" + addr }, want: "healthy"},
+		{name: "grpc scheme", status: &serving, endpoint: func(addr string) string { return "grpc://" + addr }, want: "healthy"},
+		{name: "not serving", status: &notServing, endpoint: func(addr string) string { return addr }, want: "unhealthy"},
+		{name: "health not implemented", endpoint: func(addr string) string { return addr }, want: "unhealthy"},
+		{name: "no port", status: &serving, endpoint: func(string) string { return "localhost" }, want: "unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := healthServer(t, tt.status)
+			got, err := checkGRPCHealth(context.Background(), tt.endpoint(addr))
+			if got != tt.want {
+				t.Errorf("checkGRPCHealth() = %q, %v, want %q", got, err, tt.want)
+			}
+			if (err == nil) != (tt.want == "healthy") {
+				t.Errorf("checkGRPCHealth() error = %v with status %q", err, got)
+			}
+		})
+	}
+}
+
+func TestCheckGRPCHealthUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if got, err := checkGRPCHealth(ctx, addr); got != "unhealthy" || err == nil {
+		t.Errorf("checkGRPCHealth() = %q, %v, want unhealthy with an error", got, err)
+	}
+}
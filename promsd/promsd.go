@@ -0,0 +1,164 @@
+// Package promsd turns registered services into Prometheus scrape targets,
+// served for http_sd_configs or written to a file for file_sd_configs.
+// Every service becomes a target group with its details as
+// __meta_watchdog_* labels for relabeling.
+package promsd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/client"
+	"watchdog/gateway"
+	"watchdog/logging"
+	"watchdog/server"
+)
+
+var logger = logging.For("promsd")
+
+// Path is where the targets are served on the HTTP listener
+const Path = "/prometheus/targets"
+
+// metaPrefix starts the names of the labels carrying service details
+const metaPrefix = "__meta_watchdog_"
+
+// invalidLabelChars are replaced in label keys to form Prometheus label names
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Group is a target group in the http_sd and file_sd format
+type Group struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// Options select the services turned into targets
+type Options struct {
+	Selector client.Selector
+	// HealthyOnly keeps only the services that are active and whose latest
+	// check passed or that were not checked yet
+	HealthyOnly bool
+}
+
+// Targets returns a group per service whose endpoint has a host and port,
+// ordered by name and ID
+func Targets(services []client.Service, opts Options) []Group {
+	groups := []Group{}
+	for _, svc := range services {
+		if !opts.Selector.Matches(svc) || (opts.HealthyOnly && !svc.Healthy()) {
+			continue
+		}
+		target, err := client.HostPort(svc.Endpoint)
+		if err != nil {
+			continue
+		}
+		groups = append(groups, Group{Targets: []string{target}, Labels: labels(svc)})
+	}
+
+	slices.SortFunc(groups, func(a, b Group) int {
+		if c := strings.Compare(a.Labels[metaPrefix+"name"], b.Labels[metaPrefix+"name"]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Labels[metaPrefix+"service_id"], b.Labels[metaPrefix+"service_id"])
+	})
+	return groups
+}
+
+// labels are the meta labels of a service
+func labels(svc client.Service) map[string]string {
+	health := "unknown"
+	if svc.LastCheck != nil {
+		health = svc.LastCheck.Status
+	}
+	scheme := "http"
+	if strings.HasPrefix(svc.Endpoint, "https://") {
+		scheme = "https"
+	}
+
+	l := map[string]string{
+		metaPrefix + "service_id": svc.ID,
+		metaPrefix + "name":       svc.Name,
+		metaPrefix + "namespace":  svc.Namespace,
+		metaPrefix + "type":       string(svc.Type),
+		metaPrefix + "status":     svc.Status,
+		metaPrefix + "health":     health,
+		metaPrefix + "endpoint":   svc.Endpoint,
+		metaPrefix + "scheme":     scheme,
+	}
+	for key, value := range svc.Labels {
+		l[metaPrefix+"label_"+invalidLabelChars.ReplaceAllString(key, "_")] = value
+	}
+	return l
+}
+
+// Handler serves the targets for http_sd_configs. It lists the services
+// through the ListServices handler of srv, called through caller like the
+// HTTP gateway, with the same authorization and error bodies.
+// Query parameters: namespace, all_namespaces, selector and healthy.
+func Handler(srv api.WatchdogServiceServer, caller *server.HTTPCaller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			gateway.WriteJSONStatus(w, http.StatusMethodNotAllowed, status.New(codes.Unimplemented, "method not allowed"))
+			return
+		}
+
+		query := r.URL.Query()
+		selector, err := client.ParseSelector(query.Get("selector"))
+		if err != nil {
+			gateway.WriteError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		healthyOnly, err := boolParam(query, "healthy")
+		if err != nil {
+			gateway.WriteError(w, err)
+			return
+		}
+		allNamespaces, err := boolParam(query, "all_namespaces")
+		if err != nil {
+			gateway.WriteError(w, err)
+			return
+		}
+		opts := Options{Selector: selector, HealthyOnly: healthyOnly}
+
+		resp, err := server.CallHTTP(caller, w, r, "ListServices", srv.ListServices, &api.ListServicesRequest{
+			Namespace:     query.Get("namespace"),
+			AllNamespaces: allNamespaces,
+		})
+		if err != nil {
+			gateway.WriteError(w, err)
+			return
+		}
+
+		services := make([]client.Service, len(resp.Services))
+		for i, svc := range resp.Services {
+			services[i] = client.FromProto(svc)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(Targets(services, opts)); err != nil {
+			logger.Debug("failed to write targets", "error", err)
+		}
+	})
+}
+
+// boolParam parses an optional boolean query parameter
+func boolParam(query url.Values, name string) (bool, error) {
+	value := query.Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid %s parameter %q", name, value)
+	}
+	return b, nil
+}
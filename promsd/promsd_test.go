@@ -0,0 +1,96 @@
+package promsd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/server"
+)
+
+// fakeServer lists fixed services, or fails without a namespace
+type fakeServer struct {
+	api.UnimplementedWatchdogServiceServer
+}
+
+func (fakeServer) ListServices(ctx context.Context, req *api.ListServicesRequest) (*api.ListServicesResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.PermissionDenied, "namespace required")
+	}
+	return &api.ListServicesResponse{Services: []*api.ServiceInfo{
+		{Id: "2", Name: "web", Namespace: req.Namespace, Endpoint: "https://web:8443/health", Status: "active", Labels: map[string]string{"team-name": "edge"}},
+		{Id: "1", Name: "api", Namespace: req.Namespace, Endpoint: "10.0.0.1:9090", Status: "active", LastCheckStatus: "healthy", LastCheckedAt: 1},
+		{Id: "3", Name: "api", Namespace: req.Namespace, Endpoint: "10.0.0.3:9090", Status: "maintenance"},
+		{Id: "4", Name: "unix", Namespace: req.Namespace, Endpoint: "unix:///run/app.sock", Status: "active"},
+	}}, nil
+}
+
+func serve(t *testing.T, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	Handler(fakeServer{}, server.NewHTTPCaller()).ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	w := serve(t, http.MethodGet, Path+"?namespace=prod&healthy=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+
+	var groups []Group
+	if err := json.Unmarshal(w.Body.Bytes(), &groups); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("groups = %+v, want the active api and web services", groups)
+	}
+	apiGroup, web := groups[0], groups[1]
+	if apiGroup.Targets[0] != "10.0.0.1:9090" || apiGroup.Labels[metaPrefix+"health"] != "healthy" {
+		t.Errorf("api group = %+v", apiGroup)
+	}
+	if web.Targets[0] != "web:8443" || web.Labels[metaPrefix+"scheme"] != "https" ||
+		web.Labels[metaPrefix+"label_team_name"] != "edge" || web.Labels[metaPrefix+"health"] != "unknown" {
+		t.Errorf("web group = %+v", web)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+		code   codes.Code
+	}{
+		{name: "method", method: http.MethodPost, target: Path, want: http.StatusMethodNotAllowed, code: codes.Unimplemented},
+		{name: "selector", method: http.MethodGet, target: Path + "?namespace=prod&selector=prod", want: http.StatusBadRequest, code: codes.InvalidArgument},
+		{name: "healthy", method: http.MethodGet, target: Path + "?namespace=prod&healthy=maybe", want: http.StatusBadRequest, code: codes.InvalidArgument},
+		{name: "list", method: http.MethodGet, target: Path, want: http.StatusForbidden, code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, tt.method, tt.target)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			var body struct {
+				Code    codes.Code `json:"code"`
+				Message string     `json:"message"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q is not a JSON status: %v", w.Body, err)
+			}
+			if body.Code != tt.code || body.Message == "" {
+				t.Errorf("body = %+v, want code %v", body, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package registrar
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"watchdog/client"
+)
+
+// ConfigFromEnv reads the config from the environment:
+//
+//	WATCHDOG_ADDRESS             watchdog gRPC address, required
+//	WATCHDOG_TOKEN               API token
+//	WATCHDOG_NAMESPACE           namespace to register in
+//	WATCHDOG_TLS                 "true" to connect over TLS
+//	WATCHDOG_PLAINTEXT_TOKEN     "true" to send the token without TLS
+//	WATCHDOG_SERVICE_NAME        service name, required
+//	WATCHDOG_SERVICE_ENDPOINT    endpoint watchdog checks, required
+//	WATCHDOG_SERVICE_TYPE        service type, defaults to "http"
+//	WATCHDOG_CHECK_INTERVAL      check interval such as 30s
+//	WATCHDOG_LABELS              labels as key=value,key=value
+//	WATCHDOG_HEARTBEAT_INTERVAL  heartbeat interval such as 10s
+//	WATCHDOG_ON_SHUTDOWN         "unregister" or "drain"
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Address:   os.Getenv("WATCHDOG_ADDRESS"),
+		Token:     os.Getenv("WATCHDOG_TOKEN"),
+		Namespace: os.Getenv("WATCHDOG_NAMESPACE"),
+		Service: client.Registration{
+			Name:     os.Getenv("WATCHDOG_SERVICE_NAME"),
+			Endpoint: os.Getenv("WATCHDOG_SERVICE_ENDPOINT"),
+			Type:     client.ServiceType(strings.ToLower(os.Getenv("WATCHDOG_SERVICE_TYPE"))),
+		},
+		OnShutdown: ShutdownAction(os.Getenv("WATCHDOG_ON_SHUTDOWN")),
+	}
+	if cfg.Service.Type == "" {
+		cfg.Service.Type = client.TypeHTTP
+	}
+
+	if value := os.Getenv("WATCHDOG_TLS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid WATCHDOG_TLS: %w", err)
+		}
+		if enabled {
+			cfg.TLS = &tls.Config{}
+		}
+	}
+
+	if value := os.Getenv("WATCHDOG_PLAINTEXT_TOKEN"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid WATCHDOG_PLAINTEXT_TOKEN: %w", err)
+		}
+		cfg.PlaintextToken = enabled
+	}
+
+	var err error
+	if cfg.Service.CheckInterval, err = durationEnv("WATCHDOG_CHECK_INTERVAL"); err != nil {
+		return Config{}, err
+	}
+	if cfg.HeartbeatInterval, err = durationEnv("WATCHDOG_HEARTBEAT_INTERVAL"); err != nil {
+		return Config{}, err
+	}
+
+	if value := os.Getenv("WATCHDOG_LABELS"); value != "" {
+		cfg.Service.Labels = make(map[string]string)
+		for _, entry := range strings.Split(value, ",") {
+			key, val, found := strings.Cut(strings.TrimSpace(entry), "=")
+			if !found || key == "" {
+				return Config{}, fmt.Errorf("invalid WATCHDOG_LABELS entry %q, expected key=value", entry)
+			}
+			cfg.Service.Labels[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		}
+	}
+
+	return cfg, nil
+}
+
+func durationEnv(key string) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,284 @@
+// Package registrar registers a Go service with watchdog when it starts,
+// sends heartbeats with its readiness while it runs, and unregisters or
+// drains it when it stops.
+//
+//	r, err := registrar.New(registrar.Config{
+//		Address: "watchdog.internal:50051",
+//		Service: client.Registration{Name: "api", Endpoint: "http://api:8080/healthz", Type: client.TypeHTTP},
+//		Ready:   func(ctx context.Context) error { return db.PingContext(ctx) },
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM)
+//	defer stop()
+//	done := make(chan error, 1)
+//	go func() { done <- r.Run(ctx) }()
+//	// ... serve until ctx is done ...
+//	<-done // unregistered
+package registrar
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"watchdog/client"
+	"watchdog/logging"
+)
+
+var logger = logging.For("registrar")
+
+// ShutdownAction is what happens to the registration when the service stops
+type ShutdownAction string
+
+const (
+	// Unregister removes the service
+	Unregister ShutdownAction = "unregister"
+	// Drain keeps the service and sets its status to "draining"
+	Drain ShutdownAction = "drain"
+)
+
+// Config describes the service and how to reach watchdog
+type Config struct {
+	Address   string
+	Token     string
+	Namespace string
+	// TLS connects over TLS when set, plaintext otherwise
+	TLS *tls.Config
+	// PlaintextToken allows sending the token without TLS
+	PlaintextToken bool
+
+	Service client.Registration
+
+	// HeartbeatInterval defaults to 10 seconds
+	HeartbeatInterval time.Duration
+	// Ready reports local readiness with every heartbeat, nil is always ready
+	Ready func(ctx context.Context) error
+	// OnShutdown defaults to Unregister
+	OnShutdown ShutdownAction
+	// ShutdownTimeout bounds the unregister or drain call, defaults to 5 seconds
+	ShutdownTimeout time.Duration
+}
+
+const (
+	defaultHeartbeatInterval = 10 * time.Second
+	defaultShutdownTimeout   = 5 * time.Second
+	// retryBackoff is the first delay between registration attempts, it
+	// doubles up to the heartbeat interval
+	retryBackoff = time.Second
+)
+
+// Registrar keeps a service registered
+type Registrar struct {
+	cfg    Config
+	client *client.Client
+
+	mu        sync.Mutex
+	serviceID string
+
+	// failing and ready are only used by the Run goroutine to log changes
+	failing bool
+	ready   bool
+}
+
+// New validates the config and creates the client. Nothing is sent before Run.
+func New(cfg Config) (*Registrar, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("watchdog address is required")
+	}
+	if cfg.Service.Name == "" || cfg.Service.Endpoint == "" {
+		return nil, fmt.Errorf("service name and endpoint are required")
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+	switch cfg.OnShutdown {
+	case "":
+		cfg.OnShutdown = Unregister
+	case Unregister, Drain:
+	default:
+		return nil, fmt.Errorf("unknown shutdown action %q", cfg.OnShutdown)
+	}
+	cfg.Service.Namespace = ""
+
+	opts := []client.Option{
+		client.WithNamespace(cfg.Namespace),
+		client.WithTimeout(cfg.HeartbeatInterval),
+		// Run retries on its own schedule, a call must not outlast a heartbeat
+		client.WithRetry(client.RetryPolicy{MaxAttempts: 1}),
+	}
+	if cfg.Token != "" {
+		opts = append(opts, client.WithToken(cfg.Token))
+	}
+	if cfg.TLS != nil {
+		opts = append(opts, client.WithTLS(cfg.TLS))
+	}
+	if cfg.PlaintextToken {
+		opts = append(opts, client.WithPlaintextToken())
+	}
+
+	c, err := client.New(cfg.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Registrar{cfg: cfg, client: c, ready: true}, nil
+}
+
+// ServiceID returns the ID of the registration, empty until registered
+func (r *Registrar) ServiceID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.serviceID
+}
+
+func (r *Registrar) setServiceID(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.serviceID = id
+}
+
+// Run registers the service and sends heartbeats until ctx is done, then
+// unregisters or drains it and returns. Signals are left to the caller, who
+// cancels ctx on shutdown and waits for Run to return before exiting.
+// Watchdog being unreachable is not an error, Run keeps retrying.
+func (r *Registrar) Run(ctx context.Context) error {
+	defer r.client.Close()
+
+	r.loop(ctx)
+	r.shutdown()
+	return nil
+}
+
+// loop registers the service, backing off while that fails, then sends a
+// heartbeat every interval until ctx is done
+func (r *Registrar) loop(ctx context.Context) {
+	backoff := retryBackoff
+	for {
+		delay := r.cfg.HeartbeatInterval
+		if r.ServiceID() == "" {
+			if err := r.register(ctx); err != nil {
+				r.failed(ctx, "failed to register with watchdog", err)
+				delay = backoff
+				backoff = min(backoff*2, r.cfg.HeartbeatInterval)
+			} else {
+				backoff = retryBackoff
+				continue
+			}
+		} else if err := r.heartbeat(ctx); err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				// Unregistered by someone else, register again right away
+				logger.WarnContext(ctx, "service was removed from watchdog, registering again", "service_id", r.ServiceID())
+				r.setServiceID("")
+				continue
+			}
+			r.failed(ctx, "failed to send heartbeat to watchdog", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r *Registrar) register(ctx context.Context) error {
+	svc, err := r.client.EnsureRegistered(ctx, r.cfg.Service)
+	if err != nil {
+		return err
+	}
+
+	r.setServiceID(svc.ID)
+	r.recovered(ctx)
+	logger.InfoContext(ctx, "registered with watchdog", "service_id", svc.ID, "name", svc.Name, "namespace", svc.Namespace)
+	return nil
+}
+
+func (r *Registrar) heartbeat(ctx context.Context) error {
+	ready := r.checkReady(ctx)
+	if _, err := r.client.Heartbeat(ctx, r.ServiceID(), ready); err != nil {
+		return err
+	}
+	r.recovered(ctx)
+	return nil
+}
+
+// checkReady calls the readiness func, bounded by half the interval
+func (r *Registrar) checkReady(ctx context.Context) bool {
+	if r.cfg.Ready == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.HeartbeatInterval/2)
+	defer cancel()
+	err := r.cfg.Ready(ctx)
+
+	ready := err == nil
+	if ready != r.ready {
+		if ready {
+			logger.InfoContext(ctx, "service is ready again")
+		} else {
+			logger.WarnContext(ctx, "service is not ready", "error", err)
+		}
+		r.ready = ready
+	}
+	return ready
+}
+
+// failed logs the first failure of an outage, repeats are logged at debug
+func (r *Registrar) failed(ctx context.Context, msg string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	if r.failing {
+		logger.DebugContext(ctx, msg, "error", err)
+		return
+	}
+	r.failing = true
+	logger.WarnContext(ctx, msg+", retrying", "error", err)
+}
+
+func (r *Registrar) recovered(ctx context.Context) {
+	if r.failing {
+		r.failing = false
+		logger.InfoContext(ctx, "watchdog is reachable again")
+	}
+}
+
+// shutdown unregisters or drains the service, with its own deadline since
+// the context of Run is done by now
+func (r *Registrar) shutdown() {
+	serviceID := r.ServiceID()
+	if serviceID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.ShutdownTimeout)
+	defer cancel()
+
+	var err error
+	switch r.cfg.OnShutdown {
+	case Drain:
+		err = r.client.Update(ctx, serviceID, client.Update{Status: client.StatusDraining})
+	default:
+		err = r.client.Unregister(ctx, serviceID)
+		if errors.Is(err, client.ErrNotFound) {
+			err = nil
+		}
+	}
+	if err != nil {
+		logger.Warn("failed to deregister from watchdog", "service_id", serviceID, "action", string(r.cfg.OnShutdown), "error", err)
+		return
+	}
+
+	r.setServiceID("")
+	logger.Info("deregistered from watchdog", "service_id", serviceID, "action", string(r.cfg.OnShutdown))
+}
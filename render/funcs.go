@@ -0,0 +1,87 @@
+package render
+
+import (
+	"net"
+	"slices"
+	"sort"
+	"text/template"
+
+	"watchdog/client"
+)
+
+// funcs are the helpers available to templates, services is bound to the
+// registry being rendered
+func funcs(registry []client.Service) template.FuncMap {
+	return template.FuncMap{
+		// services returns the services matching all selectors, sorted by
+		// name and endpoint
+		"services": func(selectors ...string) ([]client.Service, error) {
+			var sels []client.Selector
+			for _, text := range selectors {
+				sel, err := client.ParseSelector(text)
+				if err != nil {
+					return nil, err
+				}
+				sels = append(sels, sel)
+			}
+
+			var matched []client.Service
+			for _, svc := range registry {
+				if !slices.ContainsFunc(sels, func(sel client.Selector) bool { return !sel.Matches(svc) }) {
+					matched = append(matched, svc)
+				}
+			}
+			return matched, nil
+		},
+		"healthy": func(services []client.Service) []client.Service {
+			return slices.DeleteFunc(slices.Clone(services), func(svc client.Service) bool { return !svc.Healthy() })
+		},
+		"byName": func(services []client.Service) map[string][]client.Service {
+			return groupBy(services, func(svc client.Service) string { return svc.Name })
+		},
+		"byLabel": func(key string, services []client.Service) map[string][]client.Service {
+			return groupBy(services, func(svc client.Service) string { return svc.Labels[key] })
+		},
+		"address": func(svc client.Service) (string, error) {
+			return client.HostPort(svc.Endpoint)
+		},
+		"host": func(svc client.Service) (string, error) {
+			host, _, err := splitEndpoint(svc.Endpoint)
+			return host, err
+		},
+		"port": func(svc client.Service) (string, error) {
+			_, port, err := splitEndpoint(svc.Endpoint)
+			return port, err
+		},
+	}
+}
+
+func groupBy(services []client.Service, key func(client.Service) string) map[string][]client.Service {
+	groups := make(map[string][]client.Service)
+	for _, svc := range services {
+		k := key(svc)
+		groups[k] = append(groups[k], svc)
+	}
+	return groups
+}
+
+func splitEndpoint(endpoint string) (string, string, error) {
+	hostPort, err := client.HostPort(endpoint)
+	if err != nil {
+		return "", "", err
+	}
+	return net.SplitHostPort(hostPort)
+}
+
+// sortServices orders services so renders of the same registry are identical
+func sortServices(services []client.Service) {
+	sort.SliceStable(services, func(i, j int) bool {
+		if services[i].Name != services[j].Name {
+			return services[i].Name < services[j].Name
+		}
+		if services[i].Endpoint != services[j].Endpoint {
+			return services[i].Endpoint < services[j].Endpoint
+		}
+		return services[i].ID < services[j].ID
+	})
+}
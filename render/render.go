@@ -0,0 +1,212 @@
+// Package render renders configuration files, such as HAProxy or nginx
+// upstream lists, from Go templates over the live registry. Files are
+// rewritten atomically only when their content changes, and a command such
+// as a reload runs afterwards.
+//
+//	{{range services "type=http,env=prod" | healthy}}
+//	server {{.Name}}-{{.ID}} {{address .}} check
+//	{{end}}
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"watchdog/client"
+	"watchdog/logging"
+)
+
+var logger = logging.For("render")
+
+// commandTimeout bounds every command run after a render
+const commandTimeout = time.Minute
+
+// Template is a template file, the file it renders to and the command run
+// when that file changes
+type Template struct {
+	Source      string
+	Destination string
+	// Command runs through sh -c after the destination changed, empty runs
+	// nothing
+	Command string
+}
+
+// Renderer renders a set of templates
+type Renderer struct {
+	templates []parsed
+	// failed are the commands that failed, retried with the next render
+	// since their destinations will not change again
+	failed []string
+}
+
+type parsed struct {
+	Template
+	tmpl *template.Template
+}
+
+// New parses the templates
+func New(templates []Template) (*Renderer, error) {
+	r := &Renderer{}
+	for _, t := range templates {
+		text, err := os.ReadFile(t.Source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		tmpl, err := template.New(filepath.Base(t.Source)).Funcs(funcs(nil)).Option("missingkey=zero").Parse(string(text))
+		if err != nil {
+			return nil, err
+		}
+		r.templates = append(r.templates, parsed{Template: t, tmpl: tmpl})
+	}
+	return r, nil
+}
+
+// Render renders every template over services, writes the destinations
+// whose content changed and runs their commands, each command once. A
+// template that fails to render leaves its destination unchanged.
+func (r *Renderer) Render(ctx context.Context, services []client.Service) error {
+	services = slices.Clone(services)
+	sortServices(services)
+
+	var errs []error
+	commands := r.failed
+	r.failed = nil
+	for _, t := range r.templates {
+		changed, err := t.render(services)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t.Source, err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+		logger.Info("rendered template", "source", t.Source, "destination", t.Destination)
+		if t.Command != "" && !slices.Contains(commands, t.Command) {
+			commands = append(commands, t.Command)
+		}
+	}
+
+	for _, command := range commands {
+		if err := run(ctx, command); err != nil {
+			errs = append(errs, err)
+			r.failed = append(r.failed, command)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// render renders the template and reports whether the destination changed
+func (t parsed) render(services []client.Service) (bool, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Funcs(funcs(services)).Execute(&buf, nil); err != nil {
+		return false, err
+	}
+
+	return WriteFile(t.Destination, buf.Bytes())
+}
+
+// WriteFile writes data to path unless it already holds it, and reports
+// whether it wrote
+func WriteFile(path string, data []byte) (bool, error) {
+	current, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(current, data) {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	return true, writeAtomic(path, data)
+}
+
+// writeAtomic replaces path through a rename, so readers never see a
+// partial file. The mode of an existing file is kept.
+func writeAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// run runs a command through the shell
+func run(ctx context.Context, command string) error {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("command %q failed: %w: %s", command, err, strings.TrimSpace(string(output)))
+	}
+	logger.Info("ran command", "command", command)
+	return nil
+}
+
+// Run renders every time the registry changes until ctx is cancelled. A
+// render waits until no update came for wait, but no longer than maxWait
+// after the first update, so bursts of changes cause one reload.
+func (r *Renderer) Run(ctx context.Context, updates <-chan []client.Service, wait, maxWait time.Duration) {
+	var (
+		latest   []client.Service
+		pending  bool
+		quiet    <-chan time.Time
+		deadline <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case services := <-updates:
+			latest = services
+			quiet = time.After(wait)
+			if !pending {
+				pending = true
+				deadline = time.After(maxWait)
+			}
+			continue
+		case <-quiet:
+		case <-deadline:
+		}
+
+		pending, quiet, deadline = false, nil, nil
+		if err := r.Render(ctx, latest); err != nil && ctx.Err() == nil {
+			logger.Error("failed to render templates", "error", err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package render
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"watchdog/client"
+)
+
+// setup writes a template into a temporary directory and returns a
+// renderer for it, the destination and a file the command appends to
+func setup(t *testing.T, text, command string) (*Renderer, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	source := filepath.Join(dir, "upstreams.tmpl")
+	if err := os.WriteFile(source, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	destination := filepath.Join(dir, "upstreams.conf")
+	runs := filepath.Join(dir, "runs")
+
+	r, err := New([]Template{{
+		Source:      source,
+		Destination: destination,
+		Command:     strings.ReplaceAll(command, "RUNS", runs),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, destination, runs
+}
+
+func read(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+var registry = []client.Service{
+	{ID: "2", Name: "web", Endpoint: "http://10.0.0.2/health", Status: client.StatusActive, Labels: map[string]string{"env": "prod"}},
+	{ID: "1", Name: "web", Endpoint: "10.0.0.1:8080", Status: client.StatusActive, Labels: map[string]string{"env": "prod"}},
+	{ID: "3", Name: "web", Endpoint: "10.0.0.3:8080", Status: client.StatusDraining, Labels: map[string]string{"env": "prod"}},
+	{ID: "4", Name: "web", Endpoint: "10.0.0.4:8080", Status: client.StatusActive, Labels: map[string]string{"env": "dev"}},
+	{ID: "5", Name: "db", Endpoint: "10.0.0.5:5432", Status: client.StatusActive, LastCheck: &client.CheckResult{Status: "unhealthy"}},
+}
+
+func TestRender(t *testing.T) {
+	text := `{{range services "name=web,env=prod" | healthy}}server {{.Name}}-{{.ID}} {{address .}}
+{{end}}{{range $name, $instances := byName (services)}}{{$name}}={{len $instances}}
+{{end}}`
+	r, destination, runs := setup(t, text, "echo run >> RUNS")
+
+	if err := r.Render(context.Background(), registry); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "server web-1 10.0.0.1:8080\nserver web-2 10.0.0.2:80\ndb=1\nweb=4\n"
+	if got := read(t, destination); got != want {
+		t.Errorf("rendered:\n%s\nwant:\n%s", got, want)
+	}
+	if got := read(t, runs); got != "run\n" {
+		t.Errorf("command ran %q, want once", got)
+	}
+
+	// The same registry in another order renders the same file, so the
+	// command does not run again
+	reversed := []client.Service{registry[4], registry[3], registry[2], registry[1], registry[0]}
+	if err := r.Render(context.Background(), reversed); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := read(t, runs); got != "run\n" {
+		t.Errorf("command ran %q after an unchanged render, want once", got)
+	}
+}
+
+func TestRenderErrorKeepsDestination(t *testing.T) {
+	r, destination, runs := setup(t, `{{range services "bad"}}{{end}}`, "echo run >> RUNS")
+	if err := os.WriteFile(destination, []byte("previous\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Render(context.Background(), registry); err == nil {
+		t.Fatal("Render() succeeded with an invalid selector")
+	}
+	if got := read(t, destination); got != "previous\n" {
+		t.Errorf("destination = %q, want it unchanged", got)
+	}
+	if got := read(t, runs); got != "" {
+		t.Errorf("command ran %q after a failed render", got)
+	}
+}
+
+func TestFailedCommandRunsAgain(t *testing.T) {
+	// The command fails until the marker file exists
+	r, destination, runs := setup(t, `{{len services}}`, "echo run >> RUNS && test -f RUNS.ok")
+
+	if err := r.Render(context.Background(), registry); err == nil {
+		t.Fatal("Render() succeeded with a failing command")
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(destination), "runs.ok"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Render(context.Background(), registry); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := read(t, runs); got != "run\nrun\n" {
+		t.Errorf("command ran %q, want twice", got)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+
+	wrote, err := WriteFile(path, []byte("a"))
+	if err != nil || !wrote {
+		t.Fatalf("WriteFile() = %v, %v, want a write", wrote, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if wrote, err := WriteFile(path, []byte("a")); err != nil || wrote {
+		t.Errorf("WriteFile() of the same content = %v, %v, want no write", wrote, err)
+	}
+	if wrote, err := WriteFile(path, []byte("b")); err != nil || !wrote {
+		t.Errorf("WriteFile() of new content = %v, %v, want a write", wrote, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want the mode of the replaced file", info.Mode().Perm())
+	}
+	if got := read(t, path); got != "b" {
+		t.Errorf("content = %q, want %q", got, "b")
+	}
+}
+
+func TestRunDebounces(t *testing.T) {
+	r, _, runs := setup(t, `{{len services}}`, "echo run >> RUNS")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan []client.Service)
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx, updates, 50*time.Millisecond, time.Second)
+		close(done)
+	}()
+
+	// A burst of changes renders once, with the last registry
+	for i := 1; i <= len(registry); i++ {
+		updates <- registry[:i]
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for read(t, runs) == "" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := read(t, runs); got != "run\n" {
+		t.Errorf("command ran %q, want once for the burst", got)
+	}
+	if got := read(t, filepath.Join(filepath.Dir(runs), "upstreams.conf")); got != "5" {
+		t.Errorf("rendered %q, want the last registry", got)
+	}
+}
@@ -0,0 +1,240 @@
+// Package resolver resolves watchdog:///<service-name> targets for grpc-go
+// to the endpoints of the registered instances of a service that are
+// healthy, and keeps client connections updated as instances come and go.
+//
+//	err := resolver.Register(resolver.Config{Address: "watchdog.internal:50051", Namespace: "payments"})
+//	if err != nil {
+//		return err
+//	}
+//	conn, err := grpc.NewClient("watchdog:///api",
+//		grpc.WithTransportCredentials(insecure.NewCredentials()),
+//		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin": {}}]}`))
+package resolver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/attributes"
+	grpcresolver "google.golang.org/grpc/resolver"
+
+	"watchdog/api"
+	"watchdog/client"
+	"watchdog/logging"
+)
+
+var logger = logging.For("resolver")
+
+// Scheme is the target scheme handled by the resolver
+const Scheme = "watchdog"
+
+const (
+	// retryBackoff is the first delay before watching again after the
+	// stream failed, it doubles up to maxBackoff
+	retryBackoff = time.Second
+	maxBackoff   = 30 * time.Second
+)
+
+// Config describes how to reach watchdog
+type Config struct {
+	Address string
+	Token   string
+	// Namespace the services are resolved in, a target may override it
+	// with ?namespace=<name>
+	Namespace string
+	// TLS connects over TLS when set, plaintext otherwise
+	TLS *tls.Config
+	// PlaintextToken allows sending the token without TLS
+	PlaintextToken bool
+}
+
+// Builder builds resolvers sharing one connection to watchdog
+type Builder struct {
+	client    *client.Client
+	namespace string
+}
+
+// NewBuilder creates a resolver builder for the watchdog described by cfg.
+// The connection is established when the first target is resolved.
+func NewBuilder(cfg Config) (*Builder, error) {
+	opts := []client.Option{client.WithToken(cfg.Token)}
+	if cfg.TLS != nil {
+		opts = append(opts, client.WithTLS(cfg.TLS))
+	}
+	if cfg.PlaintextToken {
+		opts = append(opts, client.WithPlaintextToken())
+	}
+
+	c, err := client.New(cfg.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{client: c, namespace: cfg.Namespace}, nil
+}
+
+// Register registers a builder for the watchdog scheme with grpc-go. Like
+// grpcresolver.Register, it must be called during initialization.
+func Register(cfg Config) error {
+	b, err := NewBuilder(cfg)
+	if err != nil {
+		return err
+	}
+	grpcresolver.Register(b)
+	return nil
+}
+
+// Scheme returns the scheme the builder handles
+func (b *Builder) Scheme() string {
+	return Scheme
+}
+
+// Close closes the connection to watchdog
+func (b *Builder) Close() error {
+	return b.client.Close()
+}
+
+// Build starts watching the service named by the target
+func (b *Builder) Build(target grpcresolver.Target, cc grpcresolver.ClientConn, opts grpcresolver.BuildOptions) (grpcresolver.Resolver, error) {
+	name := strings.TrimPrefix(target.Endpoint(), "/")
+	if name == "" {
+		return nil, fmt.Errorf("missing service name in target %q", target.URL.String())
+	}
+	namespace := b.namespace
+	if ns := target.URL.Query().Get("namespace"); ns != "" {
+		namespace = ns
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &watchResolver{
+		api:       b.client.API(),
+		cc:        cc,
+		name:      name,
+		namespace: namespace,
+		cancel:    cancel,
+	}
+	r.wg.Add(1)
+	go r.run(ctx)
+	return r, nil
+}
+
+// watchResolver follows the instances of one service
+type watchResolver struct {
+	api       api.WatchdogServiceClient
+	cc        grpcresolver.ClientConn
+	name      string
+	namespace string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// ResolveNow does nothing, changes are pushed by watchdog
+func (r *watchResolver) ResolveNow(grpcresolver.ResolveNowOptions) {}
+
+// Close stops watching the service
+func (r *watchResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// run watches the service until the resolver is closed, watching again with
+// backoff when the stream fails. The last addresses are kept meanwhile.
+func (r *watchResolver) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	backoff := retryBackoff
+	for {
+		received, err := r.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			backoff = retryBackoff
+		}
+		logger.Warn("service watch failed", "service", r.name, "namespace", r.namespace, "error", err, "retry_in", backoff)
+		r.cc.ReportError(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// watch pushes the instances of the service to the client connection for
+// every update, it reports whether any update was received
+func (r *watchResolver) watch(ctx context.Context) (bool, error) {
+	stream, err := r.api.WatchServices(ctx, &api.WatchServicesRequest{Namespace: r.namespace, Name: r.name})
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		addresses := r.addresses(resp.Services)
+		if err := r.cc.UpdateState(grpcresolver.State{Addresses: addresses}); err != nil {
+			logger.Debug("client connection rejected addresses", "service", r.name, "error", err)
+		}
+	}
+}
+
+// addresses returns the healthy instances of the service, sorted so
+// identical updates compare equal. Instances sharing an address are listed
+// once.
+func (r *watchResolver) addresses(services []*api.ServiceInfo) []grpcresolver.Address {
+	var addresses []grpcresolver.Address
+	for _, svc := range services {
+		if svc.Name != r.name || !client.FromProto(svc).Healthy() {
+			continue
+		}
+		addr, err := client.HostPort(svc.Endpoint)
+		if err != nil {
+			logger.Debug("skipping instance without a usable address", "service_id", svc.Id, "endpoint", svc.Endpoint, "error", err)
+			continue
+		}
+		addresses = append(addresses, grpcresolver.Address{
+			Addr:               addr,
+			BalancerAttributes: attributes.New(labelsKey{}, labels(svc.Labels)),
+		})
+	}
+
+	slices.SortFunc(addresses, func(a, b grpcresolver.Address) int {
+		return strings.Compare(a.Addr, b.Addr)
+	})
+	return slices.CompactFunc(addresses, func(a, b grpcresolver.Address) bool {
+		return a.Addr == b.Addr
+	})
+}
+
+// labelsKey is the attribute key of the labels of an instance
+type labelsKey struct{}
+
+// labels are compared by value, as attributes require
+type labels map[string]string
+
+func (l labels) Equal(o any) bool {
+	other, ok := o.(labels)
+	return ok && maps.Equal(l, other)
+}
+
+// Labels returns the labels of the instance behind an address or endpoint,
+// for custom balancers. It takes the BalancerAttributes of an address or
+// the Attributes of an endpoint.
+func Labels(attrs *attributes.Attributes) map[string]string {
+	l, _ := attrs.Value(labelsKey{}).(labels)
+	return l
+}
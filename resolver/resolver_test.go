@@ -0,0 +1,28 @@
+package resolver
+
+import (
+	"slices"
+	"testing"
+
+	"watchdog/api"
+)
+
+func TestAddresses(t *testing.T) {
+	r := &watchResolver{name: "api"}
+	services := []*api.ServiceInfo{
+		{Id: "3", Name: "api", Endpoint: "http://10.0.0.2:8080", Status: "active"},
+		{Id: "1", Name: "api", Endpoint: "10.0.0.1:8080", Status: "active"},
+		{Id: "2", Name: "api", Endpoint: "http://10.0.0.1:8080/healthz", Status: "active"},
+		{Id: "4", Name: "api", Endpoint: "10.0.0.3:8080", Status: "draining"},
+		{Id: "5", Name: "web", Endpoint: "10.0.0.4:8080", Status: "active"},
+	}
+
+	var got []string
+	for _, addr := range r.addresses(services) {
+		got = append(got, addr.Addr)
+	}
+	want := []string{"10.0.0.1:8080", "10.0.0.2:8080"}
+	if !slices.Equal(got, want) {
+		t.Errorf("addresses = %v, want %v", got, want)
+	}
+}
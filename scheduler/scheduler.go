@@ -0,0 +1,402 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchdog/database"
+	"watchdog/logging"
+	"watchdog/probe"
+)
+
+var logger = logging.For("scheduler")
+
+// tick is how often the scheduler looks for services that are due
+const tick = 5 * time.Second
+
+// passTimeout bounds the database calls of one scheduling pass, so a hung
+// database fails the pass instead of stalling the loop that liveness and
+// the systemd watchdog ping depend on
+const passTimeout = 10 * time.Second
+
+// claimTimeout is how long a sharded check stays claimed by the replica
+// running it. If that replica dies, another one runs the check afterwards.
+const claimTimeout = 2 * time.Minute
+
+const (
+	// pruneInterval is how often check results older than the retention
+	// are deleted
+	pruneInterval = time.Hour
+	// pruneBatch is how many check results one delete removes at most, so
+	// a large backlog does not hold locks for long
+	pruneBatch = 1000
+	// pruneTimeout bounds one pruning pass, the rest waits for the next
+	pruneTimeout = 5 * time.Minute
+)
+
+var (
+	// QueueDepth is the number of due checks waiting for a worker
+	QueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "watchdog_scheduler_queue_depth",
+		Help: "Number of due health checks waiting for a worker.",
+	})
+
+	// ChecksTotal counts the checks run by the scheduler
+	ChecksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "watchdog_scheduler_checks_total",
+		Help: "Health checks run by the scheduler, by outcome.",
+	}, []string{"status"})
+)
+
+var tracer = otel.Tracer("watchdog/scheduler")
+
+// Shard limits a scheduler to the services this replica owns
+type Shard interface {
+	Owns(serviceID int64) bool
+	Instance() string
+}
+
+// Scheduler checks every service once per check interval and records the
+// results in the check history
+type Scheduler struct {
+	db      database.ServiceDB
+	probe   probe.Func
+	workers int
+	queue   chan database.ServiceRecord
+	shard   Shard
+	// retention is how long check results are kept, zero keeps them all
+	retention time.Duration
+
+	mu        sync.Mutex
+	nextCheck map[int64]time.Time
+	pending   map[int64]bool
+
+	// lastTick is the Unix nanoseconds of the latest scheduling pass
+	lastTick atomic.Int64
+	running  atomic.Bool
+}
+
+// New creates a scheduler running checks with run on the given number of
+// workers
+func New(db database.ServiceDB, run probe.Func, workers int) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Scheduler{
+		db:        db,
+		probe:     run,
+		workers:   workers,
+		queue:     make(chan database.ServiceRecord, workers*16),
+		nextCheck: make(map[int64]time.Time),
+		pending:   make(map[int64]bool),
+	}
+}
+
+// UseShard makes the scheduler check only the services owned by shard and
+// claim each check in the database before running it, so a service moving
+// between replicas is checked once. Call it before Run.
+func (s *Scheduler) UseShard(shard Shard) {
+	s.shard = shard
+}
+
+// KeepResults makes the scheduler delete check results older than
+// retention while it runs. Call it before Run.
+func (s *Scheduler) KeepResults(retention time.Duration) {
+	s.retention = retention
+}
+
+// Run schedules checks until ctx is cancelled. It may be called again
+// afterwards, e.g. when this replica becomes the leader again.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.running.Store(true)
+	defer s.reset()
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.worker(ctx)
+		}()
+	}
+	if s.retention > 0 {
+		// Pruning runs beside the scheduling passes so a slow delete never
+		// delays them
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.pruneLoop(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		s.lastTick.Store(time.Now().UnixNano())
+		passCtx, cancel := context.WithTimeout(ctx, passTimeout)
+		err := s.enqueueDue(passCtx)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "failed to load services", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reset forgets the schedule once Run returns, since another replica may
+// run the checks until the next Run
+func (s *Scheduler) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.queue) > 0 {
+		<-s.queue
+	}
+	clear(s.nextCheck)
+	clear(s.pending)
+	QueueDepth.Set(0)
+	s.lastTick.Store(0)
+	s.running.Store(false)
+}
+
+// Running reports whether Run is scheduling checks
+func (s *Scheduler) Running() bool {
+	return s.running.Load()
+}
+
+// LastTick returns when the scheduler last looked for due services, zero
+// while Run is not running
+func (s *Scheduler) LastTick() time.Time {
+	tick := s.lastTick.Load()
+	if tick == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, tick)
+}
+
+// enqueueDue queues every service whose check interval has elapsed
+func (s *Scheduler) enqueueDue(ctx context.Context) error {
+	services, err := s.db.ListServices(ctx, "")
+	if err != nil {
+		return err
+	}
+	if s.shard != nil {
+		// Services owned by other replicas are forgotten below
+		services = slices.DeleteFunc(services, func(service database.ServiceRecord) bool {
+			return !s.shard.Owns(service.ID)
+		})
+	}
+
+	// Services seen for the first time continue from their last recorded check
+	var unknown []int64
+	s.mu.Lock()
+	for _, service := range services {
+		if _, ok := s.nextCheck[service.ID]; !ok {
+			unknown = append(unknown, service.ID)
+		}
+	}
+	s.mu.Unlock()
+
+	latest, err := s.db.LatestCheckResults(ctx, unknown)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := make(map[int64]bool, len(services))
+	for _, service := range services {
+		current[service.ID] = true
+
+		next, ok := s.nextCheck[service.ID]
+		if !ok {
+			next = now
+			if result, found := latest[service.ID]; found {
+				next = result.CheckedAt.Add(interval(service))
+			}
+			s.nextCheck[service.ID] = next
+		}
+
+		if s.pending[service.ID] || now.Before(next) {
+			continue
+		}
+
+		select {
+		case s.queue <- service:
+			s.pending[service.ID] = true
+		default:
+			// Workers are saturated, the service stays due for the next tick
+		}
+	}
+
+	// Forget unregistered services
+	for id := range s.nextCheck {
+		if !current[id] {
+			delete(s.nextCheck, id)
+		}
+	}
+
+	QueueDepth.Set(float64(len(s.queue)))
+	return nil
+}
+
+func (s *Scheduler) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case service := <-s.queue:
+			QueueDepth.Set(float64(len(s.queue)))
+			s.check(ctx, service)
+		}
+	}
+}
+
+// check probes a service, records the result and schedules the next check
+func (s *Scheduler) check(ctx context.Context, service database.ServiceRecord) {
+	ctx, span := tracer.Start(ctx, "scheduler.check", trace.WithAttributes(
+		attribute.Int64("watchdog.service.id", service.ID),
+	))
+	defer span.End()
+
+	var next time.Time
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.pending, service.ID)
+		if next.IsZero() {
+			next = time.Now().Add(interval(service))
+		}
+		if _, ok := s.nextCheck[service.ID]; ok {
+			s.nextCheck[service.ID] = next
+		}
+	}()
+
+	if s.shard != nil {
+		due, claimed, err := s.db.ClaimCheck(ctx, service.ID, s.shard.Instance(), claimTimeout)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.ErrorContext(ctx, "failed to claim check", "service_id", service.ID, "error", err)
+			}
+			next = time.Now()
+			return
+		}
+		if !claimed {
+			// Checked by the previous owner, or still running there
+			next = due
+			return
+		}
+		defer func() {
+			// A check cut short by shutdown is due again right away
+			after := interval(service)
+			if ctx.Err() != nil {
+				after = 0
+			}
+			s.schedule(context.WithoutCancel(ctx), service.ID, after)
+		}()
+	}
+
+	result := s.probe(ctx, &service)
+	if errors.Is(result.Err, probe.ErrUnsupportedServiceType) {
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	ChecksTotal.WithLabelValues(result.Status).Inc()
+
+	err := s.db.RecordCheckResult(ctx, database.CheckResultRecord{
+		ServiceID: service.ID,
+		Status:    result.Status,
+		Message:   result.Message,
+		LatencyMs: result.Latency.Milliseconds(),
+		CheckedAt: result.CheckedAt,
+		Locations: result.Locations,
+	})
+	if err != nil && ctx.Err() == nil {
+		logger.ErrorContext(ctx, "failed to record check result", "service_id", service.ID, "error", err)
+	}
+}
+
+// pruneLoop deletes expired check results once per pruneInterval until ctx
+// is cancelled
+func (s *Scheduler) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		s.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune deletes the check results that are older than the retention, in
+// batches of pruneBatch for at most pruneTimeout
+func (s *Scheduler) prune(parent context.Context) {
+	ctx, cancel := context.WithTimeout(parent, pruneTimeout)
+	defer cancel()
+
+	before := time.Now().Add(-s.retention)
+	total := 0
+	for {
+		deleted, err := s.db.PruneCheckResults(ctx, before, pruneBatch)
+		total += deleted
+		if err != nil {
+			switch {
+			case ctx.Err() == nil:
+				logger.ErrorContext(ctx, "failed to prune check results", "error", err)
+			case parent.Err() == nil:
+				logger.WarnContext(ctx, "pruning timed out, the rest is deleted next time", "timeout", pruneTimeout)
+			}
+			break
+		}
+		if deleted < pruneBatch {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.InfoContext(ctx, "pruned check results", "deleted", total, "retention", s.retention)
+	}
+}
+
+// schedule makes the next check of a claimed service due after the given
+// delay
+func (s *Scheduler) schedule(ctx context.Context, serviceID int64, after time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := s.db.ScheduleCheck(ctx, serviceID, s.shard.Instance(), after); err != nil {
+		logger.ErrorContext(ctx, "failed to schedule check", "service_id", serviceID, "error", err)
+	}
+}
+
+// interval is the check interval of a service
+func interval(service database.ServiceRecord) time.Duration {
+	if service.CheckInterval <= 0 {
+		return time.Minute
+	}
+	return time.Duration(service.CheckInterval) * time.Second
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"watchdog/database"
+)
+
+// expiredResults deletes from a backlog of expired check results
+type expiredResults struct {
+	database.ServiceDB
+	backlog int
+	batches []int
+}
+
+func (r *expiredResults) PruneCheckResults(ctx context.Context, before time.Time, limit int) (int, error) {
+	deleted := min(limit, r.backlog)
+	r.backlog -= deleted
+	r.batches = append(r.batches, deleted)
+	return deleted, nil
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name    string
+		backlog int
+		batches []int
+	}{
+		{name: "nothing expired", backlog: 0, batches: []int{0}},
+		{name: "one batch", backlog: 10, batches: []int{10}},
+		{name: "full batches", backlog: 2 * pruneBatch, batches: []int{pruneBatch, pruneBatch, 0}},
+		{name: "backlog", backlog: 2*pruneBatch + 1, batches: []int{pruneBatch, pruneBatch, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &expiredResults{backlog: tt.backlog}
+			s := New(db, nil, 1)
+			s.KeepResults(24 * time.Hour)
+			s.prune(context.Background())
+
+			if db.backlog != 0 {
+				t.Errorf("%d expired results left", db.backlog)
+			}
+			if len(db.batches) != len(tt.batches) {
+				t.Fatalf("deleted in batches %v, want %v", db.batches, tt.batches)
+			}
+			for i := range tt.batches {
+				if db.batches[i] != tt.batches[i] {
+					t.Errorf("deleted in batches %v, want %v", db.batches, tt.batches)
+				}
+			}
+		})
+	}
+}
+
+// hungDatabase blocks listing services until the call is cancelled
+type hungDatabase struct {
+	database.ServiceDB
+	deadlines chan time.Duration
+}
+
+func (d *hungDatabase) ListServices(ctx context.Context, namespace string) ([]database.ServiceRecord, error) {
+	var remaining time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining = time.Until(deadline)
+	}
+	d.deadlines <- remaining
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestPassIsBoundedWhileDatabaseHangs(t *testing.T) {
+	db := &hungDatabase{deadlines: make(chan time.Duration, 1)}
+	s := New(db, nil, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	deadline := <-db.deadlines
+	if deadline <= 0 || deadline > passTimeout {
+		t.Errorf("services listed with a %v deadline, want at most %v", deadline, passTimeout)
+	}
+
+	cancel()
+	<-done
+}
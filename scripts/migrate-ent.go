@@ -48,19 +48,57 @@ func main() {
 		log.Println("Schema would be created with the following structure:")
 		log.Println("- Table: services")
 		log.Println("  - id: BIGINT AUTO_INCREMENT PRIMARY KEY")  
+		log.Println("  - namespace: VARCHAR(63) NOT NULL DEFAULT 'default'")
 		log.Println("  - name: VARCHAR(255) NOT NULL")
 		log.Println("  - endpoint: VARCHAR(500) NOT NULL") 
 		log.Println("  - type: ENUM(...) NOT NULL DEFAULT 'SERVICE_TYPE_UNSPECIFIED'")
 		log.Println("  - status: VARCHAR(50) NOT NULL DEFAULT 'active'")
+		log.Println("  - check_interval: BIGINT NOT NULL DEFAULT 60")
+		log.Println("  - labels: JSON NULL")
 		log.Println("  - last_heartbeat: TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP")
 		log.Println("  - created_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP")
 		log.Println("  - updated_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP")
 		log.Println("- Indexes:")
-		log.Println("  - UNIQUE(name, endpoint)")
+		log.Println("  - UNIQUE(namespace, name, endpoint)")
+		log.Println("  - INDEX(namespace)")
 		log.Println("  - INDEX(type)")
 		log.Println("  - INDEX(status)")
 		log.Println("  - INDEX(last_heartbeat)")
 		log.Println("  - INDEX(type, status)")
+		log.Println("- Table: namespaces")
+		log.Println("  - id: BIGINT AUTO_INCREMENT PRIMARY KEY")
+		log.Println("  - name: VARCHAR(63) NOT NULL UNIQUE")
+		log.Println("  - description: VARCHAR(500) NOT NULL DEFAULT ''")
+		log.Println("  - max_services: BIGINT NOT NULL DEFAULT 0")
+		log.Println("  - min_check_interval: BIGINT NOT NULL DEFAULT 0")
+		log.Println("  - created_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP")
+		log.Println("  - updated_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP")
+		log.Println("- Table: audit_events")
+		log.Println("  - id: BIGINT AUTO_INCREMENT PRIMARY KEY")
+		log.Println("  - service_id, namespace, action, actor, peer, request_id")
+		log.Println("  - before, after, changes: JSON")
+		log.Println("  - prev_hash, hash: VARCHAR(64), UNIQUE(hash)")
+		log.Println("  - created_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP")
+		log.Println("- Table: check_results")
+		log.Println("  - id: BIGINT AUTO_INCREMENT PRIMARY KEY")
+		log.Println("  - service_id: BIGINT NOT NULL")
+		log.Println("  - status: VARCHAR(50) NOT NULL")
+		log.Println("  - message: VARCHAR(1000) NOT NULL DEFAULT ''")
+		log.Println("  - latency_ms: BIGINT NOT NULL DEFAULT 0")
+		log.Println("  - checked_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP")
+		log.Println("  - INDEX(service_id, checked_at), INDEX(checked_at)")
+		log.Println("- Table: incidents")
+		log.Println("  - id: BIGINT AUTO_INCREMENT PRIMARY KEY")
+		log.Println("  - title: VARCHAR(255) NOT NULL")
+		log.Println("  - status: ENUM('investigating', 'identified', 'monitoring', 'resolved') NOT NULL")
+		log.Println("  - impact: ENUM('none', 'minor', 'major', 'critical') NOT NULL")
+		log.Println("  - components: JSON NULL")
+		log.Println("  - created_at, updated_at: TIMESTAMP, resolved_at: TIMESTAMP NULL")
+		log.Println("- Table: incident_updates")
+		log.Println("  - id: BIGINT AUTO_INCREMENT PRIMARY KEY")
+		log.Println("  - incident_id: BIGINT NOT NULL")
+		log.Println("  - status: ENUM(...) NOT NULL, message: VARCHAR(5000) NOT NULL")
+		log.Println("  - created_at: TIMESTAMP DEFAULT CURRENT_TIMESTAMP")
 		
 		log.Println("Migration complete (dry run)")
 		return
@@ -84,7 +122,7 @@ func main() {
 	log.Println("✅ Watchdog service is ready to run")
 	
 	// Optionally show some stats
-	services, err := entClient.ListServices()
+	services, err := entClient.ListServices(ctx, "")
 	if err != nil {
 		log.Printf("Warning: Could not count services: %v", err)
 	} else {
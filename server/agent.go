@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/agent"
+	"watchdog/api"
+)
+
+// probeLabels route checks to agents, so only admins may change them:
+// whoever sets them decides where a service is probed from
+var probeLabels = []string{agent.Label, agent.LocationsLabel}
+
+// AgentConnect hands the stream of a remote probe agent to the hub. Agents
+// run checks on behalf of the server, so they must present the token bound
+// to their name in AGENT_TOKENS, or the admin token.
+func (s *WatchdogServer) AgentConnect(stream api.WatchdogService_AgentConnectServer) error {
+	ctx := stream.Context()
+	name, ok := s.agentName(ctx)
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "agents require a token from AGENT_TOKENS or the admin token")
+	}
+
+	return s.agents.Serve(stream, name)
+}
+
+// agentName returns the agent name bound to the caller's token, empty for
+// the admin token, which may connect under any name
+func (s *WatchdogServer) agentName(ctx context.Context) (string, bool) {
+	if s.isAdmin(ctx) {
+		return "", true
+	}
+
+	token := bearerToken(ctx)
+	if token == "" {
+		return "", false
+	}
+	for agentToken, name := range s.agentTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(agentToken)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// checkProbeLabels refuses changes of the probe routing labels by callers
+// other than admins. current is nil for a new service.
+func (s *WatchdogServer) checkProbeLabels(ctx context.Context, current, requested map[string]string) error {
+	for _, key := range probeLabels {
+		if current[key] != requested[key] && !s.isAdmin(ctx) {
+			return status.Errorf(codes.PermissionDenied, "only admins may set the %s label", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/database"
+	"watchdog/ent/auditevent"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// encodeAuditJSON renders a stored JSON column for the API, empty when unset
+func encodeAuditJSON(value map[string]any) string {
+	if value == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func (s *WatchdogServer) ListAuditEvents(ctx context.Context, req *api.ListAuditEventsRequest) (*api.ListAuditEventsResponse, error) {
+	filter := database.AuditFilter{
+		Namespace: namespaceOrDefault(req.Namespace),
+		Actor:     req.Actor,
+		Limit:     int(req.Limit),
+	}
+
+	if req.AllNamespaces {
+		if !s.isAdmin(ctx) {
+			return nil, status.Errorf(codes.PermissionDenied, "listing audit events across namespaces requires an admin token")
+		}
+		filter.Namespace = ""
+	}
+
+	if req.ServiceId != "" {
+		serviceID, err := strconv.ParseInt(req.ServiceId, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
+		}
+		filter.ServiceID = serviceID
+	}
+
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		filter.Until = time.Unix(req.Until, 0)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+
+	events, err := s.db.ListAuditEvents(ctx, filter)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list audit events", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list audit events")
+	}
+
+	var apiEvents []*api.AuditEvent
+	for _, event := range events {
+		var serviceID, entityID string
+		if event.Entity == auditevent.EntityService {
+			serviceID = fmt.Sprintf("%d", event.ServiceID)
+		} else {
+			entityID = fmt.Sprintf("%d", event.EntityID)
+		}
+		apiEvents = append(apiEvents, &api.AuditEvent{
+			Id:          fmt.Sprintf("%d", event.ID),
+			ServiceId:   serviceID,
+			Entity:      string(event.Entity),
+			EntityId:    entityID,
+			Namespace:   event.Namespace,
+			Action:      string(event.Action),
+			Actor:       event.Actor,
+			Peer:        event.Peer,
+			RequestId:   event.RequestID,
+			BeforeJson:  encodeAuditJSON(event.Before),
+			AfterJson:   encodeAuditJSON(event.After),
+			ChangesJson: encodeAuditJSON(event.Changes),
+			PrevHash:    event.PrevHash,
+			Hash:        event.Hash,
+			CreatedAt:   event.CreatedAt.Unix(),
+		})
+	}
+
+	return &api.ListAuditEventsResponse{
+		Events: apiEvents,
+	}, nil
+}
+
+func (s *WatchdogServer) VerifyAuditLog(ctx context.Context, req *api.VerifyAuditLogRequest) (*api.VerifyAuditLogResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "verifying the audit log requires an admin token")
+	}
+
+	result, err := s.db.VerifyAuditChain(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to verify audit log", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to verify audit log")
+	}
+
+	if !result.Intact {
+		logger.ErrorContext(ctx, "audit log verification failed", "event_id", result.FirstBrokenID)
+		return &api.VerifyAuditLogResponse{
+			Intact:        false,
+			CheckedEvents: int64(result.CheckedEvents),
+			FirstBrokenId: fmt.Sprintf("%d", result.FirstBrokenID),
+			Message:       fmt.Sprintf("Audit log was tampered with at event %d", result.FirstBrokenID),
+		}, nil
+	}
+
+	return &api.VerifyAuditLogResponse{
+		Intact:        true,
+		CheckedEvents: int64(result.CheckedEvents),
+		Message:       fmt.Sprintf("Audit log intact, %d events verified", result.CheckedEvents),
+	}, nil
+}
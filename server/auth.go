@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+// bearerToken extracts the token from the "authorization: Bearer <token>"
+// request metadata
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get("authorization") {
+		if token, found := strings.CutPrefix(value, "Bearer "); found {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
+
+// isAdmin reports whether the caller presented the admin token.
+// Without a configured admin token nobody is an admin.
+func (s *WatchdogServer) isAdmin(ctx context.Context) bool {
+	if s.adminToken == "" {
+		return false
+	}
+
+	token := bearerToken(ctx)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1
+}
+
+// callerName identifies the caller by the bearer token it presented
+func (s *WatchdogServer) callerName(ctx context.Context) string {
+	token := bearerToken(ctx)
+	if token == "" {
+		return "anonymous"
+	}
+
+	if s.isAdmin(ctx) {
+		return "admin"
+	}
+
+	for apiToken, name := range s.apiTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(apiToken)) == 1 {
+			return name
+		}
+	}
+
+	return "anonymous"
+}
+
+// Authenticated reports whether the caller presented the admin token or
+// one of the configured API tokens
+func (s *WatchdogServer) Authenticated(ctx context.Context) bool {
+	return s.KnownToken(bearerToken(ctx))
+}
+
+// KnownToken reports whether token is the admin token or one of the
+// configured API tokens
+func (s *WatchdogServer) KnownToken(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	if s.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1 {
+		return true
+	}
+
+	for apiToken := range s.apiTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(apiToken)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorizeMutation refuses service mutations without the admin token or an
+// API token once API_TOKENS is set, so every audited change is attributed to
+// a named caller. Without API tokens, mutations are audited as "anonymous".
+func (s *WatchdogServer) authorizeMutation(ctx context.Context) error {
+	if len(s.apiTokens) == 0 || s.Authenticated(ctx) {
+		return nil
+	}
+	return status.Errorf(codes.Unauthenticated, "service mutations require an API token")
+}
+
+// requestID returns the request ID assigned to the call, the x-request-id
+// sent by the caller or a new one
+func requestID(ctx context.Context) string {
+	if id := logging.RequestID(ctx); id != "" {
+		return id
+	}
+	return logging.IncomingRequestID(ctx)
+}
+
+// peerAddress returns the network address of the caller
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// auditContext attaches the caller identity recorded by the audit log
+func (s *WatchdogServer) auditContext(ctx context.Context) context.Context {
+	return database.WithActor(ctx, database.Actor{
+		Name:      s.callerName(ctx),
+		Peer:      peerAddress(ctx),
+		RequestID: requestID(ctx),
+	})
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// withToken returns a context carrying token as incoming bearer metadata
+func withToken(token string) context.Context {
+	ctx := context.Background()
+	if token == "" {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthorizeMutation(t *testing.T) {
+	tests := []struct {
+		name      string
+		apiTokens map[string]string
+		token     string
+		want      codes.Code
+		caller    string
+	}{
+		// Without API tokens the limitation is documented: mutations are
+		// allowed and audited as anonymous
+		{name: "no tokens configured", token: "", want: codes.OK, caller: "anonymous"},
+		{name: "no tokens configured, unknown token", token: "guess", want: codes.OK, caller: "anonymous"},
+		{name: "anonymous", apiTokens: map[string]string{"ci-secret": "ci"}, token: "", want: codes.Unauthenticated},
+		{name: "unknown token", apiTokens: map[string]string{"ci-secret": "ci"}, token: "guess", want: codes.Unauthenticated},
+		{name: "api token", apiTokens: map[string]string{"ci-secret": "ci"}, token: "ci-secret", want: codes.OK, caller: "ci"},
+		{name: "admin token", apiTokens: map[string]string{"ci-secret": "ci"}, token: "root-secret", want: codes.OK, caller: "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &WatchdogServer{adminToken: "root-secret", apiTokens: tt.apiTokens}
+			ctx := withToken(tt.token)
+
+			err := s.authorizeMutation(ctx)
+			if status.Code(err) != tt.want {
+				t.Fatalf("authorizeMutation() = %v, want %v", err, tt.want)
+			}
+			if err == nil {
+				if got := s.callerName(ctx); got != tt.caller {
+					t.Errorf("callerName() = %q, want %q", got, tt.caller)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/federation"
+)
+
+func (s *WatchdogServer) ListFederatedServices(ctx context.Context, req *api.ListFederatedServicesRequest) (*api.ListFederatedServicesResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "listing federated services requires an admin token")
+	}
+	if s.federation == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "federation is not enabled")
+	}
+
+	// This watchdog is a cluster of the view like its children
+	clusters := s.federation.Clusters()
+	if req.Cluster == "" || req.Cluster == s.clusterName {
+		local, err := s.listServices(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range local.Services {
+			svc.Id = s.clusterName + "/" + svc.Id
+		}
+		clusters = append([]federation.Cluster{{
+			Name:      s.clusterName,
+			Reachable: true,
+			SyncedAt:  time.Now(),
+			Services:  local.Services,
+		}}, clusters...)
+	}
+
+	resp := &api.ListFederatedServicesResponse{}
+	found := false
+	for _, cluster := range clusters {
+		if req.Cluster != "" && cluster.Name != req.Cluster {
+			continue
+		}
+		found = true
+		resp.Clusters = append(resp.Clusters, summarize(cluster))
+		resp.Services = append(resp.Services, cluster.Services...)
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "cluster not found")
+	}
+
+	return resp, nil
+}
+
+// summarize counts the services of a cluster by their latest check
+func summarize(cluster federation.Cluster) *api.ClusterSummary {
+	summary := &api.ClusterSummary{
+		Name:      cluster.Name,
+		Address:   cluster.Address,
+		Reachable: cluster.Reachable,
+		Error:     cluster.Error,
+		Services:  int32(len(cluster.Services)),
+	}
+	if !cluster.SyncedAt.IsZero() {
+		summary.SyncedAt = cluster.SyncedAt.Unix()
+	}
+
+	for _, svc := range cluster.Services {
+		switch svc.LastCheckStatus {
+		case "healthy":
+			summary.Healthy++
+		case "unhealthy":
+			summary.Unhealthy++
+		default:
+			summary.Unknown++
+		}
+	}
+	return summary
+}
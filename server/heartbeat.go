@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/servicestatus"
+)
+
+func (s *WatchdogServer) Heartbeat(ctx context.Context, req *api.HeartbeatRequest) (*api.HeartbeatResponse, error) {
+	if err := s.authorizeMutation(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.ServiceId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "service ID cannot be empty")
+	}
+
+	serviceID, err := strconv.ParseInt(req.ServiceId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
+	}
+
+	service, err := s.getNamespacedService(ctx, serviceID, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// A heartbeat moves a service between active and not ready. Draining
+	// services come back on the next ready heartbeat after a restart, any
+	// other status was set by an operator, e.g. "maintenance", and is kept.
+	newStatus := ""
+	switch service.Status {
+	case servicestatus.Active, servicestatus.NotReady, servicestatus.Draining:
+		want := servicestatus.NotReady
+		if req.Ready {
+			want = servicestatus.Active
+		}
+		if want != service.Status {
+			newStatus = want
+		}
+	}
+
+	if err := s.db.Heartbeat(s.auditContext(ctx), serviceID, newStatus); err != nil {
+		if err.Error() == "service not found" {
+			return nil, status.Errorf(codes.NotFound, "service not found")
+		}
+		logger.ErrorContext(ctx, "failed to record heartbeat", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to record heartbeat")
+	}
+
+	if newStatus == "" {
+		newStatus = service.Status
+	}
+	return &api.HeartbeatResponse{Status: newStatus}, nil
+}
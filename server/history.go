@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+)
+
+const (
+	defaultCheckResultLimit = 100
+	maxCheckResultLimit     = 1000
+)
+
+func (s *WatchdogServer) ListCheckResults(ctx context.Context, req *api.ListCheckResultsRequest) (*api.ListCheckResultsResponse, error) {
+	if req.ServiceId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "service ID cannot be empty")
+	}
+
+	serviceID, err := strconv.ParseInt(req.ServiceId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
+	}
+
+	if _, err := s.getNamespacedService(ctx, serviceID, req.Namespace); err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultCheckResultLimit
+	}
+	if limit > maxCheckResultLimit {
+		limit = maxCheckResultLimit
+	}
+
+	results, err := s.db.ListCheckResults(ctx, serviceID, since, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list check results", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list check results")
+	}
+
+	var apiResults []*api.CheckResult
+	for _, result := range results {
+		apiResult := &api.CheckResult{
+			Status:    result.Status,
+			Message:   result.Message,
+			LatencyMs: result.LatencyMs,
+			CheckedAt: result.CheckedAt.Unix(),
+		}
+		for _, location := range result.Locations {
+			apiResult.Locations = append(apiResult.Locations, &api.LocationResult{
+				Location:  location.Location,
+				Status:    location.Status,
+				Message:   location.Message,
+				LatencyMs: location.LatencyMs,
+			})
+		}
+		apiResults = append(apiResults, apiResult)
+	}
+
+	return &api.ListCheckResultsResponse{
+		Results: apiResults,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+)
+
+var tracer = otel.Tracer("watchdog/server")
+
+// forwardedHeaders are copied from HTTP requests into gRPC metadata so HTTP
+// callers authenticate and get audited the same way as gRPC callers
+var forwardedHeaders = []string{"authorization", "x-request-id"}
+
+// httpContext builds the context an RPC handler expects from an HTTP request:
+// incoming metadata from the forwarded headers, the remote address as peer
+// and the caller's trace context
+func httpContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, header := range forwardedHeaders {
+		if values := r.Header.Values(header); len(values) > 0 {
+			md.Set(header, values...)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+
+	return ctx
+}
+
+// HTTPCaller calls RPC handlers for HTTP requests through the unary
+// interceptors of the gRPC server, so HTTP calls are logged, counted and
+// traced like native ones
+type HTTPCaller struct {
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+// NewHTTPCaller creates a caller running interceptors in the order
+// grpc.ChainUnaryInterceptor runs them
+func NewHTTPCaller(interceptors ...grpc.UnaryServerInterceptor) *HTTPCaller {
+	return &HTTPCaller{interceptors: interceptors}
+}
+
+// Invoke calls handler as the WatchdogService method, e.g. "ListServices".
+// Headers set by the interceptors, such as x-request-id, are copied to w.
+func (c *HTTPCaller) Invoke(w http.ResponseWriter, r *http.Request, method string, req any, handler grpc.UnaryHandler) (any, error) {
+	fullMethod := "/" + api.WatchdogService_ServiceDesc.ServiceName + "/" + method
+
+	// Native calls get their server span from the otelgrpc stats handler,
+	// which has no interceptor form
+	ctx, span := tracer.Start(httpContext(r), api.WatchdogService_ServiceDesc.ServiceName+"/"+method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(api.WatchdogService_ServiceDesc.ServiceName),
+			semconv.RPCMethod(method),
+		))
+	defer span.End()
+
+	stream := &httpStream{method: fullMethod}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	info := &grpc.UnaryServerInfo{Server: c, FullMethod: fullMethod}
+	resp, err := c.chain(0, info, handler)(ctx, req)
+
+	for key, values := range stream.header {
+		w.Header()[http.CanonicalHeaderKey(key)] = values
+	}
+
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+	return resp, err
+}
+
+// chain wraps handler in the interceptors from the i-th on
+func (c *HTTPCaller) chain(i int, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	if i == len(c.interceptors) {
+		return handler
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		return c.interceptors[i](ctx, req, info, c.chain(i+1, info, handler))
+	}
+}
+
+// CallHTTP is Invoke for a typed RPC handler
+func CallHTTP[Req, Resp any](c *HTTPCaller, w http.ResponseWriter, r *http.Request, method string, handler func(context.Context, Req) (Resp, error), req Req) (Resp, error) {
+	resp, err := c.Invoke(w, r, method, req, func(ctx context.Context, req any) (any, error) {
+		return handler(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	return resp.(Resp), nil
+}
+
+// httpStream collects the headers interceptors set with grpc.SetHeader
+type httpStream struct {
+	method string
+	header metadata.MD
+}
+
+func (s *httpStream) Method() string {
+	return s.method
+}
+
+func (s *httpStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *httpStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *httpStream) SetTrailer(metadata.MD) error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/database"
+	"watchdog/ent/incident"
+)
+
+const (
+	defaultIncidentLimit = 20
+	maxIncidentLimit     = 200
+)
+
+// toAPIIncident converts an incident and its timeline for the API
+func toAPIIncident(record database.IncidentRecord, updates []database.StatusUpdateRecord) *api.Incident {
+	apiIncident := &api.Incident{
+		Id:         strconv.FormatInt(record.ID, 10),
+		Title:      record.Title,
+		Status:     string(record.Status),
+		Impact:     string(record.Impact),
+		Components: record.Components,
+		CreatedAt:  record.CreatedAt.Unix(),
+		UpdatedAt:  record.UpdatedAt.Unix(),
+	}
+	if record.ResolvedAt != nil {
+		apiIncident.ResolvedAt = record.ResolvedAt.Unix()
+	}
+
+	for _, update := range updates {
+		apiIncident.Updates = append(apiIncident.Updates, &api.IncidentUpdate{
+			Status:    string(update.Status),
+			Message:   update.Message,
+			CreatedAt: update.CreatedAt.Unix(),
+		})
+	}
+
+	return apiIncident
+}
+
+// parseIncidentStatus validates an incident status, empty returns fallback
+func parseIncidentStatus(value string, fallback incident.Status) (incident.Status, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if err := incident.StatusValidator(incident.Status(value)); err != nil {
+		return "", status.Errorf(codes.InvalidArgument,
+			"incident status must be investigating, identified, monitoring or resolved")
+	}
+	return incident.Status(value), nil
+}
+
+// parseIncidentImpact validates an incident impact, empty returns fallback
+func parseIncidentImpact(value string, fallback incident.Impact) (incident.Impact, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if err := incident.ImpactValidator(incident.Impact(value)); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "incident impact must be none, minor, major or critical")
+	}
+	return incident.Impact(value), nil
+}
+
+// incidentWithTimeline loads the timeline of a single incident for a response
+func (s *WatchdogServer) incidentWithTimeline(ctx context.Context, record *database.IncidentRecord) (*api.Incident, error) {
+	timelines, err := s.db.ListStatusUpdates(ctx, []int64{record.ID})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list incident updates", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list incident updates")
+	}
+	return toAPIIncident(*record, timelines[record.ID]), nil
+}
+
+func (s *WatchdogServer) CreateIncident(ctx context.Context, req *api.CreateIncidentRequest) (*api.Incident, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "creating incidents requires an admin token")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "incident title cannot be empty")
+	}
+	if len(title) > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "incident title must be at most 255 characters")
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "incident message cannot be empty")
+	}
+
+	incidentStatus, err := parseIncidentStatus(req.Status, incident.DefaultStatus)
+	if err != nil {
+		return nil, err
+	}
+	impact, err := parseIncidentImpact(req.Impact, incident.DefaultImpact)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.db.CreateIncident(ctx, database.IncidentRecord{
+		Title:      title,
+		Status:     incidentStatus,
+		Impact:     impact,
+		Components: req.Components,
+	}, message)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to create incident", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create incident")
+	}
+
+	return s.incidentWithTimeline(ctx, created)
+}
+
+func (s *WatchdogServer) PostIncidentUpdate(ctx context.Context, req *api.PostIncidentUpdateRequest) (*api.Incident, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "posting incident updates requires an admin token")
+	}
+
+	if req.IncidentId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "incident ID cannot be empty")
+	}
+	incidentID, err := strconv.ParseInt(req.IncidentId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid incident ID format")
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "incident message cannot be empty")
+	}
+
+	current, err := s.db.GetIncident(ctx, incidentID)
+	if err != nil {
+		if err.Error() == "incident not found" {
+			return nil, status.Errorf(codes.NotFound, "incident not found")
+		}
+		logger.ErrorContext(ctx, "failed to get incident", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get incident")
+	}
+
+	incidentStatus, err := parseIncidentStatus(req.Status, current.Status)
+	if err != nil {
+		return nil, err
+	}
+	impact, err := parseIncidentImpact(req.Impact, "")
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.AddStatusUpdate(ctx, incidentID, incidentStatus, impact, message)
+	if err != nil {
+		if err.Error() == "incident not found" {
+			return nil, status.Errorf(codes.NotFound, "incident not found")
+		}
+		logger.ErrorContext(ctx, "failed to update incident", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to update incident")
+	}
+
+	return s.incidentWithTimeline(ctx, updated)
+}
+
+func (s *WatchdogServer) ListIncidents(ctx context.Context, req *api.ListIncidentsRequest) (*api.ListIncidentsResponse, error) {
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultIncidentLimit
+	}
+	if limit > maxIncidentLimit {
+		limit = maxIncidentLimit
+	}
+
+	incidents, err := s.db.ListIncidents(ctx, since, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list incidents", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list incidents")
+	}
+
+	incidentIDs := make([]int64, len(incidents))
+	for i, record := range incidents {
+		incidentIDs[i] = record.ID
+	}
+
+	timelines, err := s.db.ListStatusUpdates(ctx, incidentIDs)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list incident updates", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list incident updates")
+	}
+
+	var apiIncidents []*api.Incident
+	for _, record := range incidents {
+		apiIncidents = append(apiIncidents, toAPIIncident(record, timelines[record.ID]))
+	}
+
+	return &api.ListIncidentsResponse{
+		Incidents: apiIncidents,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/database"
+)
+
+// namespaceNamePattern mirrors the validation in the Namespace schema
+var namespaceNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// namespaceRecordFromQuota validates the quota fields shared by create and update requests
+func namespaceRecordFromQuota(name, description string, maxServices, minCheckInterval int32) (database.NamespaceRecord, error) {
+	if name == "" {
+		return database.NamespaceRecord{}, status.Errorf(codes.InvalidArgument, "namespace name cannot be empty")
+	}
+
+	if len(name) > 63 || !namespaceNamePattern.MatchString(name) {
+		return database.NamespaceRecord{}, status.Errorf(codes.InvalidArgument,
+			"namespace name must be at most 63 lowercase letters, digits or dashes")
+	}
+
+	if maxServices < 0 || minCheckInterval < 0 {
+		return database.NamespaceRecord{}, status.Errorf(codes.InvalidArgument, "namespace quotas cannot be negative")
+	}
+
+	return database.NamespaceRecord{
+		Name:             name,
+		Description:      description,
+		MaxServices:      int(maxServices),
+		MinCheckInterval: int(minCheckInterval),
+	}, nil
+}
+
+func (s *WatchdogServer) CreateNamespace(ctx context.Context, req *api.CreateNamespaceRequest) (*api.CreateNamespaceResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "creating namespaces requires an admin token")
+	}
+
+	namespace, err := namespaceRecordFromQuota(req.Name, req.Description, req.MaxServices, req.MinCheckIntervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.CreateNamespace(ctx, namespace)
+	if err != nil {
+		if err.Error() == "namespace already exists" {
+			return nil, status.Errorf(codes.AlreadyExists, "namespace already exists")
+		}
+		logger.ErrorContext(ctx, "failed to create namespace", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create namespace")
+	}
+
+	return &api.CreateNamespaceResponse{
+		Message: fmt.Sprintf("Namespace %s created successfully", req.Name),
+	}, nil
+}
+
+func (s *WatchdogServer) ListNamespaces(ctx context.Context, req *api.ListNamespacesRequest) (*api.ListNamespacesResponse, error) {
+	namespaces, err := s.db.ListNamespaces(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list namespaces", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list namespaces")
+	}
+
+	var apiNamespaces []*api.NamespaceInfo
+	for _, namespace := range namespaces {
+		serviceCount, err := s.db.CountServices(ctx, namespace.Name)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to count services of namespace", "namespace", namespace.Name, "error", err)
+		}
+
+		apiNamespaces = append(apiNamespaces, &api.NamespaceInfo{
+			Name:                    namespace.Name,
+			Description:             namespace.Description,
+			MaxServices:             int32(namespace.MaxServices),
+			MinCheckIntervalSeconds: int32(namespace.MinCheckInterval),
+			ServiceCount:            int32(serviceCount),
+		})
+	}
+
+	return &api.ListNamespacesResponse{
+		Namespaces: apiNamespaces,
+	}, nil
+}
+
+func (s *WatchdogServer) UpdateNamespace(ctx context.Context, req *api.UpdateNamespaceRequest) (*api.UpdateNamespaceResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "updating namespaces requires an admin token")
+	}
+
+	namespace, err := namespaceRecordFromQuota(req.Name, req.Description, req.MaxServices, req.MinCheckIntervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.UpdateNamespace(ctx, namespace)
+	if err != nil {
+		if err.Error() == "namespace not found" {
+			return nil, status.Errorf(codes.NotFound, "namespace not found")
+		}
+		logger.ErrorContext(ctx, "failed to update namespace", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to update namespace")
+	}
+
+	return &api.UpdateNamespaceResponse{
+		Message: "Namespace updated successfully",
+	}, nil
+}
+
+func (s *WatchdogServer) DeleteNamespace(ctx context.Context, req *api.DeleteNamespaceRequest) (*api.DeleteNamespaceResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "deleting namespaces requires an admin token")
+	}
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "namespace name cannot be empty")
+	}
+
+	if req.Name == database.DefaultNamespace {
+		return nil, status.Errorf(codes.FailedPrecondition, "the default namespace cannot be deleted")
+	}
+
+	err := s.db.DeleteNamespace(ctx, req.Name)
+	if err != nil {
+		switch err.Error() {
+		case "namespace not found":
+			return nil, status.Errorf(codes.NotFound, "namespace not found")
+		case "namespace is not empty":
+			return nil, status.Errorf(codes.FailedPrecondition, "namespace still has registered services")
+		}
+		logger.ErrorContext(ctx, "failed to delete namespace", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to delete namespace")
+	}
+
+	return &api.DeleteNamespaceResponse{
+		Message: "Namespace deleted successfully",
+	}, nil
+}
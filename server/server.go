@@ -2,59 +2,195 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
-	"net/http"
-	"os/exec"
+	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"watchdog/agent"
 	"watchdog/api"
+	"watchdog/config"
 	"watchdog/database"
 	"watchdog/ent/service"
+	"watchdog/federation"
+	"watchdog/logging"
+	"watchdog/probe"
+	"watchdog/servicestatus"
 )
 
+var logger = logging.For("server")
+
+// defaultCheckInterval is used when a service is registered without one
+const defaultCheckInterval = 60
+
 type WatchdogServer struct {
 	api.UnimplementedWatchdogServiceServer
-	db database.ServiceDB
+	db         database.ServiceDB
+	adminToken string
+	apiTokens  map[string]string
+	// agentTokens maps bearer tokens to the agent names they may connect as
+	agentTokens map[string]string
+	agents      *agent.Hub
+	// leaderElection reports the scheduler lease holder in GetHealth
+	leaderElection bool
+	// memberTimeout is how long shard members stay live without a
+	// heartbeat, 0 when sharding is disabled
+	memberTimeout time.Duration
+	// federation aggregates child watchdogs, nil when not federating
+	federation *federation.Federation
+	// clusterName names this watchdog in the federated view
+	clusterName string
 }
 
-func NewWatchdogServer(db database.ServiceDB) *WatchdogServer {
+func NewWatchdogServer(db database.ServiceDB, cfg config.ServerConfig, agents *agent.Hub, fed *federation.Federation, clusterName string) *WatchdogServer {
+	if cfg.AdminToken == "" {
+		logger.Warn("ADMIN_TOKEN is not set, admin RPCs are refused")
+	}
+
+	var memberTimeout time.Duration
+	if cfg.ShardingEnabled {
+		memberTimeout = time.Duration(cfg.MemberTimeout) * time.Second
+	}
+
 	return &WatchdogServer{
-		db: db,
+		db:             db,
+		adminToken:     cfg.AdminToken,
+		apiTokens:      cfg.APITokens,
+		agentTokens:    cfg.AgentTokens,
+		agents:         agents,
+		leaderElection: cfg.LeaderElection,
+		memberTimeout:  memberTimeout,
+		federation:     fed,
+		clusterName:    clusterName,
 	}
 }
 
+// namespaceOrDefault maps an empty request namespace to the default namespace
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return database.DefaultNamespace
+	}
+	return namespace
+}
+
+// getNamespacedService looks up a service and hides it from callers of other namespaces
+func (s *WatchdogServer) getNamespacedService(ctx context.Context, serviceID int64, namespace string) (*database.ServiceRecord, error) {
+	service, err := s.db.GetService(ctx, serviceID)
+	if err != nil {
+		if err.Error() == "service not found" {
+			return nil, status.Errorf(codes.NotFound, "service not found")
+		}
+		logger.ErrorContext(ctx, "failed to get service", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get service")
+	}
+
+	if service.Namespace != namespaceOrDefault(namespace) {
+		return nil, status.Errorf(codes.NotFound, "service not found")
+	}
+
+	return service, nil
+}
+
+// getNamespace looks up the namespace of a request
+func (s *WatchdogServer) getNamespace(ctx context.Context, name string) (*database.NamespaceRecord, error) {
+	namespace, err := s.db.GetNamespace(ctx, namespaceOrDefault(name))
+	if err != nil {
+		if err.Error() == "namespace not found" {
+			return nil, status.Errorf(codes.NotFound, "namespace %q not found", namespaceOrDefault(name))
+		}
+		logger.ErrorContext(ctx, "failed to get namespace", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get namespace")
+	}
+
+	return namespace, nil
+}
+
+// validateCheckInterval enforces the namespace minimum check interval
+func validateCheckInterval(namespace *database.NamespaceRecord, checkInterval int) error {
+	if checkInterval < 0 {
+		return status.Errorf(codes.InvalidArgument, "check interval cannot be negative")
+	}
+	if checkInterval > 0 && checkInterval < namespace.MinCheckInterval {
+		return status.Errorf(codes.InvalidArgument,
+			"check interval %ds is below the minimum of %ds for namespace %s",
+			checkInterval, namespace.MinCheckInterval, namespace.Name)
+	}
+	return nil
+}
+
+// labelKeyPattern allows DNS-style label keys such as env or app.kubernetes.io/name
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+// validateLabels checks label keys and values for sane lengths and characters
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if len(key) > 63 || !labelKeyPattern.MatchString(key) {
+			return status.Errorf(codes.InvalidArgument, "invalid label key %q", key)
+		}
+		if len(value) > 255 {
+			return status.Errorf(codes.InvalidArgument, "label %q value is longer than 255 characters", key)
+		}
+	}
+	return nil
+}
+
 func (s *WatchdogServer) GetHealth(ctx context.Context, req *api.HealthRequest) (*api.HealthResponse, error) {
 	if err := s.db.HealthCheck(); err != nil {
-		log.Printf("Database health check failed: %v", err)
+		logger.ErrorContext(ctx, "database health check failed", "error", err)
 		return &api.HealthResponse{
 			Status:  "unhealthy",
 			Message: "Database connection failed",
 		}, nil
 	}
 
-	services, err := s.db.ListServices()
+	// Without a namespace the count covers every namespace
+	serviceCount, err := s.db.CountServices(ctx, req.Namespace)
 	if err != nil {
-		log.Printf("Failed to count services: %v", err)
+		logger.ErrorContext(ctx, "failed to count services", "error", err)
 	}
 
-	err = s.db.LogHealthCheck("healthy", len(services))
+	err = s.db.LogHealthCheck("healthy", serviceCount)
 	if err != nil {
-		log.Printf("Failed to log health check: %v", err)
+		logger.ErrorContext(ctx, "failed to log health check", "error", err)
 	}
 
-	return &api.HealthResponse{
+	resp := &api.HealthResponse{
 		Status:  "healthy",
-		Message: fmt.Sprintf("Watchdog service is running with %d registered services", len(services)),
-	}, nil
+		Message: fmt.Sprintf("Watchdog service is running with %d registered services", serviceCount),
+	}
+	if s.leaderElection {
+		resp.Leader = s.currentLeader(ctx)
+		if resp.Leader == "" {
+			resp.Message += ", no replica is leading"
+		} else {
+			resp.Message += ", led by " + resp.Leader
+		}
+	}
+	return resp, nil
+}
+
+// currentLeader returns the holder of the scheduler lease, empty when it
+// has lapsed
+func (s *WatchdogServer) currentLeader(ctx context.Context) string {
+	lease, err := s.db.GetLease(ctx, database.SchedulerLease)
+	if err != nil {
+		if err.Error() != "lease not found" {
+			logger.ErrorContext(ctx, "failed to get scheduler lease", "error", err)
+		}
+		return ""
+	}
+	return lease.Holder
 }
 
 func (s *WatchdogServer) RegisterService(ctx context.Context, req *api.RegisterServiceRequest) (*api.RegisterServiceResponse, error) {
+	if err := s.authorizeMutation(ctx); err != nil {
+		return nil, err
+	}
+
 	if req.Name == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "service name cannot be empty")
 	}
@@ -63,17 +199,49 @@ func (s *WatchdogServer) RegisterService(ctx context.Context, req *api.RegisterS
 		return nil, status.Errorf(codes.InvalidArgument, "service endpoint cannot be empty")
 	}
 
+	if err := validateLabels(req.Labels); err != nil {
+		return nil, err
+	}
+	if err := s.checkProbeLabels(ctx, nil, req.Labels); err != nil {
+		return nil, err
+	}
+
+	namespace, err := s.getNamespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	checkInterval := int(req.CheckIntervalSeconds)
+	if err := validateCheckInterval(namespace, checkInterval); err != nil {
+		return nil, err
+	}
+	if checkInterval == 0 {
+		checkInterval = max(defaultCheckInterval, namespace.MinCheckInterval)
+	}
+
 	service := database.ServiceRecord{
+		Namespace:     namespace.Name,
 		Name:          req.Name,
 		Endpoint:      req.Endpoint,
 		Type:          apiToEntServiceType(req.Type),
-		Status:        "active",
+		Status:        servicestatus.Active,
+		CheckInterval: checkInterval,
+		Labels:        req.Labels,
 		LastHeartbeat: time.Now(),
 	}
 
-	serviceID, err := s.db.CreateService(service)
+	serviceID, err := s.db.CreateService(s.auditContext(ctx), service)
 	if err != nil {
-		log.Printf("Failed to create service: %v", err)
+		switch err.Error() {
+		case "service already exists":
+			return nil, status.Errorf(codes.AlreadyExists, "service already exists")
+		case "namespace quota exceeded":
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"namespace %s has reached its quota of %d services", namespace.Name, namespace.MaxServices)
+		case "namespace not found":
+			return nil, status.Errorf(codes.NotFound, "namespace %q not found", namespace.Name)
+		}
+		logger.ErrorContext(ctx, "failed to create service", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to register service")
 	}
 
@@ -84,6 +252,10 @@ func (s *WatchdogServer) RegisterService(ctx context.Context, req *api.RegisterS
 }
 
 func (s *WatchdogServer) UnregisterService(ctx context.Context, req *api.UnregisterServiceRequest) (*api.UnregisterServiceResponse, error) {
+	if err := s.authorizeMutation(ctx); err != nil {
+		return nil, err
+	}
+
 	if req.ServiceId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "service ID cannot be empty")
 	}
@@ -94,38 +266,75 @@ func (s *WatchdogServer) UnregisterService(ctx context.Context, req *api.Unregis
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
 	}
 
-	err = s.db.DeleteService(serviceID)
+	if _, err := s.getNamespacedService(ctx, serviceID, req.Namespace); err != nil {
+		return nil, err
+	}
+
+	ctx = s.auditContext(ctx)
+	err = s.db.DeleteService(ctx, serviceID)
 	if err != nil {
 		if err.Error() == "service not found" {
 			return nil, status.Errorf(codes.NotFound, "service not found")
 		}
-		log.Printf("Failed to delete service: %v", err)
+		logger.ErrorContext(ctx, "failed to delete service", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to unregister service")
 	}
 
+	actor := database.ActorFromContext(ctx)
+	logger.InfoContext(ctx, "service unregistered", "service_id", serviceID, "actor", actor.Name, "peer", actor.Peer)
+
 	return &api.UnregisterServiceResponse{
 		Message: "Service unregistered successfully",
 	}, nil
 }
 
 func (s *WatchdogServer) ListServices(ctx context.Context, req *api.ListServicesRequest) (*api.ListServicesResponse, error) {
-	services, err := s.db.ListServices()
+	namespace, err := s.listedNamespace(ctx, req.Namespace, req.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listServices(ctx, namespace)
+}
+
+// listedNamespace resolves the namespace a listing covers, empty for all
+// namespaces, which requires an admin token
+func (s *WatchdogServer) listedNamespace(ctx context.Context, namespace string, all bool) (string, error) {
+	if !all {
+		return namespaceOrDefault(namespace), nil
+	}
+	if !s.isAdmin(ctx) {
+		return "", status.Errorf(codes.PermissionDenied, "listing services across namespaces requires an admin token")
+	}
+	return "", nil
+}
+
+// listServices lists the services of a namespace with their latest check
+func (s *WatchdogServer) listServices(ctx context.Context, namespace string) (*api.ListServicesResponse, error) {
+	services, err := s.db.ListServices(ctx, namespace)
 	if err != nil {
-		log.Printf("Failed to list services: %v", err)
+		logger.ErrorContext(ctx, "failed to list services", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to list services")
 	}
 
+	serviceIDs := make([]int64, len(services))
+	for i, service := range services {
+		serviceIDs[i] = service.ID
+	}
+
+	latest, err := s.db.LatestCheckResults(ctx, serviceIDs)
+	if err != nil {
+		// The listing is still useful without the last check
+		logger.ErrorContext(ctx, "failed to load latest check results", "error", err)
+	}
+
 	var apiServices []*api.ServiceInfo
-	for _, service := range services {
-		apiService := &api.ServiceInfo{
-			Id:            fmt.Sprintf("%d", service.ID),
-			Name:          service.Name,
-			Endpoint:      service.Endpoint,
-			Status:        service.Status,
-			LastHeartbeat: service.LastHeartbeat.Unix(),
-			Type:          stringToServiceType(string(service.Type)),
+	for i := range services {
+		var lastCheck *database.CheckResultRecord
+		if result, ok := latest[services[i].ID]; ok {
+			lastCheck = &result
 		}
-		apiServices = append(apiServices, apiService)
+		apiServices = append(apiServices, toAPIService(&services[i], lastCheck))
 	}
 
 	return &api.ListServicesResponse{
@@ -133,7 +342,60 @@ func (s *WatchdogServer) ListServices(ctx context.Context, req *api.ListServices
 	}, nil
 }
 
+func (s *WatchdogServer) GetService(ctx context.Context, req *api.GetServiceRequest) (*api.ServiceInfo, error) {
+	if req.ServiceId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "service ID cannot be empty")
+	}
+
+	serviceID, err := strconv.ParseInt(req.ServiceId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
+	}
+
+	service, err := s.getNamespacedService(ctx, serviceID, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastCheck *database.CheckResultRecord
+	results, err := s.db.ListCheckResults(ctx, serviceID, time.Time{}, 1)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to load latest check result", "service_id", serviceID, "error", err)
+	} else if len(results) > 0 {
+		lastCheck = &results[0]
+	}
+
+	return toAPIService(service, lastCheck), nil
+}
+
+// toAPIService converts a service record and its optional last check result
+func toAPIService(service *database.ServiceRecord, lastCheck *database.CheckResultRecord) *api.ServiceInfo {
+	apiService := &api.ServiceInfo{
+		Id:                   fmt.Sprintf("%d", service.ID),
+		Name:                 service.Name,
+		Endpoint:             service.Endpoint,
+		Status:               service.Status,
+		LastHeartbeat:        service.LastHeartbeat.Unix(),
+		Type:                 stringToServiceType(string(service.Type)),
+		Namespace:            service.Namespace,
+		CheckIntervalSeconds: int32(service.CheckInterval),
+		Labels:               service.Labels,
+	}
+
+	if lastCheck != nil {
+		apiService.LastCheckedAt = lastCheck.CheckedAt.Unix()
+		apiService.LastCheckStatus = lastCheck.Status
+		apiService.LastCheckLatencyMs = lastCheck.LatencyMs
+	}
+
+	return apiService
+}
+
 func (s *WatchdogServer) UpdateService(ctx context.Context, req *api.UpdateServiceRequest) (*api.UpdateServiceResponse, error) {
+	if err := s.authorizeMutation(ctx); err != nil {
+		return nil, err
+	}
+
 	if req.ServiceId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "service ID cannot be empty")
 	}
@@ -144,12 +406,42 @@ func (s *WatchdogServer) UpdateService(ctx context.Context, req *api.UpdateServi
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
 	}
 
-	err = s.db.UpdateService(serviceID, req.Status, req.Name, apiToEntServiceType(req.Type), req.Endpoint)
+	current, err := s.getNamespacedService(ctx, serviceID, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := s.getNamespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCheckInterval(namespace, int(req.CheckIntervalSeconds)); err != nil {
+		return nil, err
+	}
+
+	if err := validateLabels(req.Labels); err != nil {
+		return nil, err
+	}
+
+	// A nil map keeps the current labels
+	var labels map[string]string
+	if req.ClearLabels {
+		labels = map[string]string{}
+	} else if len(req.Labels) > 0 {
+		labels = req.Labels
+	}
+	if labels != nil {
+		if err := s.checkProbeLabels(ctx, current.Labels, labels); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.db.UpdateService(s.auditContext(ctx), serviceID, req.Status, req.Name, apiToEntServiceType(req.Type), req.Endpoint, int(req.CheckIntervalSeconds), labels)
 	if err != nil {
 		if err.Error() == "service not found" {
 			return nil, status.Errorf(codes.NotFound, "service not found")
 		}
-		log.Printf("Failed to update service: %v", err)
+		logger.ErrorContext(ctx, "failed to update service", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to update service")
 	}
 
@@ -239,45 +531,6 @@ func apiToEntServiceType(apiType api.ServiceType) service.Type {
 	}
 }
 
-func (s *WatchdogServer) checkHTTPHealth(endpoint string) (string, error) {
-	client := http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(endpoint)
-	if err != nil {
-		return "unhealthy", err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "unhealthy", fmt.Errorf("HTTP status code: %d", resp.StatusCode)
-	}
-
-	return "healthy", nil
-}
-
-func (s *WatchdogServer) checkSystemdHealth(endpoint string) (string, error) {
-	out, err := exec.CommandContext(context.Background(), "systemctl", "is-active", endpoint).Output()
-	if err != nil {
-		return "unhealthy", err
-	}
-	if strings.TrimSpace(string(out)) != "active" {
-		return "unhealthy", fmt.Errorf("systemd health check command returned: %s", string(out))
-	}
-
-	return "healthy", nil
-}
-
-func (s *WatchdogServer) checkGRPCHealth(endpoint string) (string, error) {
-	return "healthy", nil
-}
-
-func (s *WatchdogServer) checkDatabaseHealth(endpoint string) (string, error) {
-	return "healthy", nil
-}
-
 func (s *WatchdogServer) CheckServiceHealth(ctx context.Context, req *api.CheckServiceHealthRequest) (*api.HealthResponse, error) {
 	if req.ServiceId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "service ID cannot be empty")
@@ -288,56 +541,30 @@ func (s *WatchdogServer) CheckServiceHealth(ctx context.Context, req *api.CheckS
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID format")
 	}
 
-	service, err := s.db.GetService(serviceID)
+	service, err := s.getNamespacedService(ctx, serviceID, req.Namespace)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "service not found")
+		return nil, err
 	}
 
-	healthStatus := "unhealthy"
-
-	switch service.Type {
-	case "SERVICE_TYPE_HTTP":
-		healthStatus, err = s.checkHTTPHealth(service.Endpoint)
-		if err != nil {
-			log.Printf("HTTP health check failed for service %d (%s): %v", serviceID, service.Endpoint, err)
-			return &api.HealthResponse{
-				Status:  "unhealthy",
-				Message: fmt.Sprintf("Service is unreachable: %v", err),
-			}, nil
-		}
-	case "SERVICE_TYPE_GRPC":
-		healthStatus, err = s.checkGRPCHealth(service.Endpoint)
-		if err != nil {
-			log.Printf("gRPC health check failed for service %d (%s): %v", serviceID, service.Endpoint, err)
-			return &api.HealthResponse{
-				Status:  "unhealthy",
-				Message: fmt.Sprintf("Service is unreachable: %v", err),
-			}, nil
-		}
-	case "SERVICE_TYPE_SYSTEMD":
-		healthStatus, err = s.checkSystemdHealth(service.Endpoint)
-		if err != nil {
-			log.Printf("Systemd health check failed for service %d (%s): %v", serviceID, service.Endpoint, err)
-			return &api.HealthResponse{
-				Status:  "unhealthy",
-				Message: fmt.Sprintf("Service is unreachable: %v", err),
-			}, nil
-		}
-	case "SERVICE_TYPE_DATABASE", "SERVICE_TYPE_CACHE", "SERVICE_TYPE_QUEUE", "SERVICE_TYPE_STORAGE", "SERVICE_TYPE_EXTERNAL_API", "SERVICE_TYPE_MICROSERVICE", "SERVICE_TYPE_OTHER":
-		healthStatus, err = s.checkDatabaseHealth(service.Endpoint)
-		if err != nil {
-			log.Printf("Database health check failed for service %d (%s): %v", serviceID, service.Endpoint, err)
-			return &api.HealthResponse{
-				Status:  "unhealthy",
-				Message: fmt.Sprintf("Service is unreachable: %v", err),
-			}, nil
-		}
-	default:
+	result := s.agents.Probe(ctx, service)
+	if errors.Is(result.Err, probe.ErrUnsupportedServiceType) {
 		return nil, status.Errorf(codes.InvalidArgument, "Unsupported service type")
 	}
 
+	err = s.db.RecordCheckResult(ctx, database.CheckResultRecord{
+		ServiceID: serviceID,
+		Status:    result.Status,
+		Message:   result.Message,
+		LatencyMs: result.Latency.Milliseconds(),
+		CheckedAt: result.CheckedAt,
+		Locations: result.Locations,
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to record check result", "service_id", serviceID, "error", err)
+	}
+
 	return &api.HealthResponse{
-		Status:  healthStatus,
-		Message: "Service health checked successfully",
+		Status:  result.Status,
+		Message: result.Message,
 	}, nil
 }
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"watchdog/api"
+	"watchdog/shard"
+)
+
+func (s *WatchdogServer) ListShards(ctx context.Context, req *api.ListShardsRequest) (*api.ListShardsResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "listing shards requires an admin token")
+	}
+	if s.memberTimeout == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "sharding is not enabled")
+	}
+
+	members, err := s.db.ListMembers(ctx, s.memberTimeout)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list shard members", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list shard members")
+	}
+
+	services, err := s.db.ListServices(ctx, "")
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list services", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list services")
+	}
+
+	// The ring every replica builds from the same members
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.Instance
+	}
+	ring := shard.NewRing(names)
+
+	owned := make(map[string][]string, len(members))
+	for _, service := range services {
+		owner := ring.Owner(service.ID)
+		owned[owner] = append(owned[owner], strconv.FormatInt(service.ID, 10))
+	}
+
+	apiMembers := make([]*api.ShardMember, len(members))
+	for i, member := range members {
+		apiMembers[i] = &api.ShardMember{
+			Instance:   member.Instance,
+			JoinedAt:   member.JoinedAt.Unix(),
+			SeenAt:     member.SeenAt.Unix(),
+			ServiceIds: owned[member.Instance],
+		}
+	}
+
+	return &api.ListShardsResponse{
+		Members: apiMembers,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"watchdog/api"
+)
+
+const (
+	// watchDebounce coalesces bursts of changes, e.g. a round of scheduled
+	// checks, into one update
+	watchDebounce = time.Second
+	// watchPollInterval bounds how stale a watch gets when the database is
+	// changed by another process
+	watchPollInterval = 15 * time.Second
+)
+
+func (s *WatchdogServer) WatchServices(req *api.WatchServicesRequest, stream api.WatchdogService_WatchServicesServer) error {
+	ctx := stream.Context()
+
+	namespace, err := s.listedNamespace(ctx, req.Namespace, req.AllNamespaces)
+	if err != nil {
+		return err
+	}
+
+	changes := s.db.WatchChanges(ctx)
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	var last *api.ListServicesResponse
+	for {
+		resp, err := s.listServices(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		if req.Name != "" {
+			resp.Services = slices.DeleteFunc(resp.Services, func(svc *api.ServiceInfo) bool {
+				return svc.Name != req.Name
+			})
+		}
+		if last == nil || !proto.Equal(resp, last) {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			last = resp
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			continue
+		case <-changes:
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchDebounce):
+		}
+	}
+}
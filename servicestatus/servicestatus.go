@@ -0,0 +1,21 @@
+// Package servicestatus defines the statuses of registered services and
+// which of them may receive traffic. It has no dependencies, so the server
+// and the client SDK share it.
+package servicestatus
+
+// Statuses of a service. Heartbeats move self-registered services between
+// active and not ready, and services on their way down set draining. Any
+// other status was set by an operator, e.g. "maintenance".
+const (
+	Active   = "active"
+	NotReady = "not_ready"
+	Draining = "draining"
+)
+
+// Routable reports whether a service with the given status may receive
+// traffic. Only active services are, or services without a status, which
+// were registered before statuses existed. Every other status, including
+// ones set by operators, keeps the service out of rotation.
+func Routable(status string) bool {
+	return status == Active || status == ""
+}
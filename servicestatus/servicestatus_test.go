@@ -0,0 +1,23 @@
+package servicestatus
+
+import "testing"
+
+func TestRoutable(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{status: Active, want: true},
+		{status: "", want: true},
+		{status: NotReady, want: false},
+		{status: Draining, want: false},
+		{status: "maintenance", want: false},
+		{status: "Active", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := Routable(tt.status); got != tt.want {
+			t.Errorf("Routable(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package shard
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+var logger = logging.For("shard")
+
+// leaveTimeout bounds leaving the membership on shutdown
+const leaveTimeout = 2 * time.Second
+
+// Members is the number of live replicas sharing the scheduled checks
+var Members = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "watchdog_shard_members",
+	Help: "Live replicas sharing the scheduled checks.",
+})
+
+// Store is what membership needs from the database
+type Store interface {
+	TouchMember(ctx context.Context, instance string, timeout time.Duration) error
+	ListMembers(ctx context.Context, timeout time.Duration) ([]database.MemberRecord, error)
+	RemoveMember(ctx context.Context, instance string) error
+}
+
+// Membership keeps this replica registered among the replicas sharing a
+// database and splits the services between the live ones
+type Membership struct {
+	db       Store
+	instance string
+	timeout  time.Duration
+
+	ring atomic.Pointer[Ring]
+}
+
+// NewMembership creates the membership of the replica named instance.
+// Replicas not seen for timeout are considered gone and their services move
+// to the others.
+func NewMembership(db Store, instance string, timeout time.Duration) *Membership {
+	if timeout < 3*time.Second {
+		timeout = 3 * time.Second
+	}
+
+	return &Membership{
+		db:       db,
+		instance: instance,
+		timeout:  timeout,
+	}
+}
+
+// Instance returns the name of this replica
+func (m *Membership) Instance() string {
+	return m.instance
+}
+
+// Owns reports whether this replica owns a service. It owns nothing until
+// it has joined, or once it could not reach the database for longer than
+// the others wait before taking its services over.
+func (m *Membership) Owns(serviceID int64) bool {
+	ring := m.ring.Load()
+	return ring != nil && ring.Owner(serviceID) == m.instance
+}
+
+// Run sends heartbeats and refreshes the ring until ctx is cancelled, then
+// leaves so the others take over right away
+func (m *Membership) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.timeout / 3)
+	defer ticker.Stop()
+
+	var refreshedAt time.Time
+	for {
+		if err := m.refresh(ctx); err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("failed to refresh membership", "error", err)
+			}
+			if !refreshedAt.IsZero() && time.Since(refreshedAt) > m.timeout-m.timeout/3 {
+				m.setRing(nil)
+			}
+		} else {
+			refreshedAt = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			m.leave()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh records a heartbeat and rebuilds the ring from the live members,
+// giving up once the next refresh is due
+func (m *Membership) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout/3)
+	defer cancel()
+
+	if err := m.db.TouchMember(ctx, m.instance, m.timeout); err != nil {
+		return err
+	}
+
+	members, err := m.db.ListMembers(ctx, m.timeout)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.Instance
+	}
+	m.setRing(NewRing(names))
+	return nil
+}
+
+// setRing replaces the ring, logging membership changes
+func (m *Membership) setRing(ring *Ring) {
+	previous := m.ring.Swap(ring)
+
+	var before, after []string
+	if previous != nil {
+		before = previous.Members()
+	}
+	if ring != nil {
+		after = ring.Members()
+	}
+	Members.Set(float64(len(after)))
+	if !slices.Equal(before, after) {
+		logger.Info("shard members changed", "instance", m.instance, "members", after)
+	}
+}
+
+func (m *Membership) leave() {
+	m.setRing(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), leaveTimeout)
+	defer cancel()
+	if err := m.db.RemoveMember(ctx, m.instance); err != nil {
+		logger.Warn("failed to leave membership", "error", err)
+		return
+	}
+	logger.Info("left membership", "instance", m.instance)
+}
@@ -0,0 +1,82 @@
+package shard
+
+import (
+	"cmp"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// points is the number of positions each member takes on the ring, which
+// evens out the share of services per member
+const points = 128
+
+// Ring assigns services to members by consistent hashing, so a member
+// joining or leaving only moves the services it takes or gives up
+type Ring struct {
+	members []string
+	hashes  []uint64
+	owners  []string
+}
+
+// NewRing builds a ring of the given members
+func NewRing(members []string) *Ring {
+	r := &Ring{members: slices.Sorted(slices.Values(members))}
+	r.members = slices.Compact(r.members)
+
+	type point struct {
+		hash  uint64
+		owner string
+	}
+	all := make([]point, 0, len(r.members)*points)
+	for _, member := range r.members {
+		for i := range points {
+			all = append(all, point{hash: hash(member + "#" + strconv.Itoa(i)), owner: member})
+		}
+	}
+	// Ties between members are broken by name on every replica alike
+	slices.SortFunc(all, func(a, b point) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), strings.Compare(a.owner, b.owner))
+	})
+
+	r.hashes = make([]uint64, len(all))
+	r.owners = make([]string, len(all))
+	for i, p := range all {
+		r.hashes[i] = p.hash
+		r.owners[i] = p.owner
+	}
+	return r
+}
+
+// Members returns the members of the ring, sorted by name
+func (r *Ring) Members() []string {
+	return r.members
+}
+
+// Owner returns the member owning a service, empty for an empty ring
+func (r *Ring) Owner(serviceID int64) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	key := hash(strconv.FormatInt(serviceID, 10))
+	i, _ := slices.BinarySearch(r.hashes, key)
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[i]
+}
+
+// hash is 64-bit FNV-1a followed by a finalizer, since FNV alone spreads
+// short keys such as service IDs poorly
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
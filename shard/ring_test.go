@@ -0,0 +1,110 @@
+package shard
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+const services = 10000
+
+// owners maps every service ID to its owner on r
+func owners(r *Ring) map[int64]string {
+	owned := make(map[int64]string, services)
+	for id := int64(1); id <= services; id++ {
+		owned[id] = r.Owner(id)
+	}
+	return owned
+}
+
+func members(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("watchdog-%d", i)
+	}
+	return names
+}
+
+func TestRingMembers(t *testing.T) {
+	r := NewRing([]string{"c", "a", "b", "a"})
+	if got, want := r.Members(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Members() = %q, want %q", got, want)
+	}
+}
+
+func TestRingEmpty(t *testing.T) {
+	if owner := NewRing(nil).Owner(42); owner != "" {
+		t.Errorf("Owner() on an empty ring = %q, want none", owner)
+	}
+}
+
+func TestRingIsDeterministic(t *testing.T) {
+	a := NewRing([]string{"x", "y", "z"})
+	b := NewRing([]string{"z", "x", "y"})
+	for id := int64(1); id <= 1000; id++ {
+		if a.Owner(id) != b.Owner(id) {
+			t.Fatalf("service %d owned by %s and %s depending on member order", id, a.Owner(id), b.Owner(id))
+		}
+	}
+}
+
+func TestRingDistribution(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 10} {
+		t.Run(fmt.Sprintf("%d members", n), func(t *testing.T) {
+			counts := make(map[string]int)
+			for _, owner := range owners(NewRing(members(n))) {
+				counts[owner]++
+			}
+
+			if len(counts) != n {
+				t.Fatalf("%d members own services, want %d", len(counts), n)
+			}
+			fair := services / n
+			for member, count := range counts {
+				// 128 points per member keep every share within 30% of fair
+				if count < fair*7/10 || count > fair*13/10 {
+					t.Errorf("%s owns %d services, fair share is %d", member, count, fair)
+				}
+			}
+		})
+	}
+}
+
+func TestRingMinimalMovement(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		after  []string
+	}{
+		{name: "member joins", before: members(4), after: members(5)},
+		{name: "member leaves", before: members(5), after: members(4)},
+		{name: "middle member leaves", before: members(5), after: slices.Delete(members(5), 2, 3)},
+		{name: "second member joins", before: members(1), after: members(2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := owners(NewRing(tt.before))
+			after := owners(NewRing(tt.after))
+
+			moved := 0
+			for id, owner := range before {
+				if after[id] == owner {
+					continue
+				}
+				moved++
+				// Only services of a leaving member or taken by a joining
+				// one may move
+				if slices.Contains(tt.after, owner) && slices.Contains(tt.before, after[id]) {
+					t.Fatalf("service %d moved from %s to %s, both members before and after", id, owner, after[id])
+				}
+			}
+
+			// About one member's share moves, bounded like the distribution
+			larger := max(len(tt.before), len(tt.after))
+			if limit := services / larger * 13 / 10; moved > limit {
+				t.Errorf("%d services moved, want at most %d", moved, limit)
+			}
+		})
+	}
+}
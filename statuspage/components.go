@@ -0,0 +1,84 @@
+package statuspage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"watchdog/database"
+)
+
+// Component is a customer-facing part of the product. Its state is derived
+// from the services it maps to, either by name or by label selector.
+type Component struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Namespace of the mapped services, defaults to "default"
+	Namespace string `json:"namespace"`
+	// Services lists service names that belong to the component
+	Services []string `json:"services"`
+	// Labels selects every service carrying all of the given labels
+	Labels map[string]string `json:"labels"`
+}
+
+// componentsFile is the format of the components file
+type componentsFile struct {
+	Components []Component `json:"components"`
+}
+
+// LoadComponents reads the curated component list from a JSON file
+func LoadComponents(path string) ([]Component, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status page components: %w", err)
+	}
+
+	var file componentsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse status page components: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range file.Components {
+		component := &file.Components[i]
+		if component.Name == "" {
+			return nil, fmt.Errorf("status page component %d has no name", i+1)
+		}
+		if seen[component.Name] {
+			return nil, fmt.Errorf("status page component %q is listed twice", component.Name)
+		}
+		seen[component.Name] = true
+
+		if len(component.Services) == 0 && len(component.Labels) == 0 {
+			return nil, fmt.Errorf("status page component %q maps to no services or labels", component.Name)
+		}
+		if component.Namespace == "" {
+			component.Namespace = database.DefaultNamespace
+		}
+	}
+
+	return file.Components, nil
+}
+
+// matches reports whether the service belongs to the component
+func (c Component) matches(service database.ServiceRecord) bool {
+	if service.Namespace != c.Namespace {
+		return false
+	}
+
+	for _, name := range c.Services {
+		if service.Name == name {
+			return true
+		}
+	}
+
+	if len(c.Labels) == 0 {
+		return false
+	}
+	for key, value := range c.Labels {
+		if service.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package statuspage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Link      atomLink    `xml:"link"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// handleFeed publishes one Atom entry per incident, updated with every
+// timeline entry so feed readers pick up new updates
+func (p *Page) handleFeed(w http.ResponseWriter, r *http.Request) {
+	snapshot := p.snapshotOrError(w, r)
+	if snapshot == nil {
+		return
+	}
+
+	base := p.baseURL(r)
+	feed := atomFeed{
+		Title:   snapshot.Title,
+		ID:      base + "/",
+		Updated: snapshot.GeneratedAt.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: base + "/"},
+			{Href: base + "/feed.atom", Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+
+	for _, incident := range snapshot.Incidents {
+		link := fmt.Sprintf("%s/incidents/%d", base, incident.ID)
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:     fmt.Sprintf("%s (%s)", incident.Title, incident.Status),
+			ID:        link,
+			Published: incident.CreatedAt.Format(time.RFC3339),
+			Updated:   incident.UpdatedAt.Format(time.RFC3339),
+			Link:      atomLink{Href: link},
+			Content:   atomContent{Type: "html", Body: timelineHTML(incident)},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, _ = w.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	_ = encoder.Encode(feed)
+}
+
+// timelineHTML renders the incident timeline as escaped HTML content
+func timelineHTML(incident Incident) string {
+	var b strings.Builder
+	if len(incident.Components) > 0 {
+		fmt.Fprintf(&b, "<p>Affected: %s</p>", html.EscapeString(strings.Join(incident.Components, ", ")))
+	}
+	for _, update := range incident.Updates {
+		fmt.Fprintf(&b, "<p><strong>%s</strong> %s<br>%s</p>",
+			html.EscapeString(stateLabel(update.Status)),
+			update.CreatedAt.Format("Jan 2, 15:04 UTC"),
+			html.EscapeString(update.Message))
+	}
+	return b.String()
+}
@@ -0,0 +1,284 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"watchdog/database"
+)
+
+// uptimeDays is the length of the uptime history shown per component
+const uptimeDays = 90
+
+// Component states, from best to worst
+const (
+	StateOperational = "operational"
+	StateUnknown     = "unknown"
+	StateDegraded    = "degraded_performance"
+	StatePartial     = "partial_outage"
+	StateMajor       = "major_outage"
+)
+
+var stateRank = map[string]int{
+	StateOperational: 0,
+	StateUnknown:     1,
+	StateDegraded:    2,
+	StatePartial:     3,
+	StateMajor:       4,
+}
+
+// impactState is the state an open incident forces on its components
+var impactState = map[string]string{
+	"minor":    StateDegraded,
+	"major":    StatePartial,
+	"critical": StateMajor,
+}
+
+// Snapshot is everything the status page shows. It only carries component
+// names and aggregates, never service names, endpoints or other internals.
+type Snapshot struct {
+	Title       string            `json:"title"`
+	Status      string            `json:"status"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Components  []ComponentStatus `json:"components"`
+	Incidents   []Incident        `json:"incidents"`
+}
+
+// ComponentStatus is the current state and uptime history of a component
+type ComponentStatus struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"`
+	// Uptime over the whole history in percent, nil without checks
+	Uptime *float64 `json:"uptime_90d"`
+	Days   []Day    `json:"days"`
+}
+
+// Day is one uptime bar
+type Day struct {
+	Date string `json:"date"`
+	// Uptime in percent, nil without checks on that day
+	Uptime *float64 `json:"uptime"`
+	Checks int      `json:"checks"`
+}
+
+// Incident is an incident as published on the status page
+type Incident struct {
+	ID         int64      `json:"id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	Impact     string     `json:"impact"`
+	Components []string   `json:"components"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	Updates    []Update   `json:"updates"`
+}
+
+// Update is an entry of an incident timeline
+type Update struct {
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// buildSnapshot computes the status page from services, check history and incidents
+func buildSnapshot(ctx context.Context, db database.ServiceDB, title string, components []Component, now time.Time) (*Snapshot, error) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(uptimeDays - 1))
+
+	// Services of every namespace referenced by a component
+	servicesByNamespace := make(map[string][]database.ServiceRecord)
+	for _, component := range components {
+		if _, ok := servicesByNamespace[component.Namespace]; ok {
+			continue
+		}
+		services, err := db.ListServices(ctx, component.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		servicesByNamespace[component.Namespace] = services
+	}
+
+	members := make([][]int64, len(components))
+	var serviceIDs []int64
+	for i, component := range components {
+		for _, service := range servicesByNamespace[component.Namespace] {
+			if component.matches(service) {
+				members[i] = append(members[i], service.ID)
+				serviceIDs = append(serviceIDs, service.ID)
+			}
+		}
+	}
+
+	latest, err := db.LatestCheckResults(ctx, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := db.DailyCheckCounts(ctx, serviceIDs, since)
+	if err != nil {
+		return nil, err
+	}
+	countsByService := make(map[int64][]database.DailyCheckCount)
+	for _, count := range counts {
+		countsByService[count.ServiceID] = append(countsByService[count.ServiceID], count)
+	}
+
+	incidents, err := loadIncidents(ctx, db, since)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Title:       title,
+		Status:      StateOperational,
+		GeneratedAt: now.UTC(),
+		Incidents:   incidents,
+	}
+
+	for i, component := range components {
+		componentStatus := ComponentStatus{
+			Name:        component.Name,
+			Description: component.Description,
+			Status:      currentState(members[i], latest),
+		}
+
+		// Open incidents override the measured state when they are worse
+		for _, incident := range incidents {
+			if incident.ResolvedAt != nil || !slices.Contains(incident.Components, component.Name) {
+				continue
+			}
+			if state, ok := impactState[incident.Impact]; ok && stateRank[state] > stateRank[componentStatus.Status] {
+				componentStatus.Status = state
+			}
+		}
+
+		componentStatus.Days, componentStatus.Uptime = uptimeHistory(members[i], countsByService, since)
+
+		if stateRank[componentStatus.Status] > stateRank[snapshot.Status] {
+			snapshot.Status = componentStatus.Status
+		}
+		snapshot.Components = append(snapshot.Components, componentStatus)
+	}
+
+	return snapshot, nil
+}
+
+// currentState derives the state of a component from the latest check of its services
+func currentState(serviceIDs []int64, latest map[int64]database.CheckResultRecord) string {
+	checked, unhealthy := 0, 0
+	for _, id := range serviceIDs {
+		result, ok := latest[id]
+		if !ok {
+			continue
+		}
+		checked++
+		if result.Status != "healthy" {
+			unhealthy++
+		}
+	}
+
+	switch {
+	case checked == 0:
+		return StateUnknown
+	case unhealthy == 0:
+		return StateOperational
+	case unhealthy == checked:
+		return StateMajor
+	default:
+		return StatePartial
+	}
+}
+
+// uptimeHistory builds one bar per day from the daily check counts of the services
+func uptimeHistory(serviceIDs []int64, counts map[int64][]database.DailyCheckCount, since time.Time) ([]Day, *float64) {
+	healthyByDay := make(map[string]int)
+	totalByDay := make(map[string]int)
+	healthy, total := 0, 0
+	for _, id := range serviceIDs {
+		for _, count := range counts[id] {
+			date := count.Day.Format(time.DateOnly)
+			healthyByDay[date] += count.Healthy
+			totalByDay[date] += count.Total
+			healthy += count.Healthy
+			total += count.Total
+		}
+	}
+
+	days := make([]Day, uptimeDays)
+	for i := range days {
+		date := since.AddDate(0, 0, i).Format(time.DateOnly)
+		days[i] = Day{
+			Date:   date,
+			Checks: totalByDay[date],
+			Uptime: percent(healthyByDay[date], totalByDay[date]),
+		}
+	}
+
+	return days, percent(healthy, total)
+}
+
+func percent(healthy, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	value := float64(healthy) * 100 / float64(total)
+	return &value
+}
+
+// loadIncidents loads recent and open incidents with their timelines
+func loadIncidents(ctx context.Context, db database.ServiceDB, since time.Time) ([]Incident, error) {
+	records, err := db.ListIncidents(ctx, since, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(records))
+	for i, record := range records {
+		ids[i] = record.ID
+	}
+	timelines, err := db.ListStatusUpdates(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := make([]Incident, 0, len(records))
+	for _, record := range records {
+		incident := Incident{
+			ID:         record.ID,
+			Title:      record.Title,
+			Status:     string(record.Status),
+			Impact:     string(record.Impact),
+			Components: record.Components,
+			CreatedAt:  record.CreatedAt.UTC(),
+			UpdatedAt:  record.UpdatedAt.UTC(),
+		}
+		if record.ResolvedAt != nil {
+			resolvedAt := record.ResolvedAt.UTC()
+			incident.ResolvedAt = &resolvedAt
+		}
+		for _, update := range timelines[record.ID] {
+			incident.Updates = append(incident.Updates, Update{
+				Status:    string(update.Status),
+				Message:   update.Message,
+				CreatedAt: update.CreatedAt.UTC(),
+			})
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+// findIncident returns the incident with the given ID from the snapshot
+func (s *Snapshot) findIncident(id int64) (Incident, error) {
+	for _, incident := range s.Incidents {
+		if incident.ID == id {
+			return incident, nil
+		}
+	}
+	return Incident{}, fmt.Errorf("incident not found")
+}
@@ -0,0 +1,117 @@
+package statuspage
+
+import (
+	"testing"
+	"time"
+
+	"watchdog/database"
+)
+
+func TestUptimeHistory(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(i int) time.Time { return since.AddDate(0, 0, i) }
+	counts := map[int64][]database.DailyCheckCount{
+		1: {
+			{ServiceID: 1, Day: day(0), Healthy: 8, Total: 10},
+			{ServiceID: 1, Day: day(uptimeDays - 1), Healthy: 5, Total: 5},
+		},
+		2: {{ServiceID: 2, Day: day(0), Healthy: 2, Total: 10}},
+		3: {{ServiceID: 3, Day: day(1), Healthy: 0, Total: 4}},
+	}
+
+	type bar struct {
+		uptime float64
+		checks int
+	}
+
+	tests := []struct {
+		name       string
+		serviceIDs []int64
+		// bars are the days with checks by index, every other day is empty
+		bars   map[int]bar
+		uptime float64
+	}{
+		{
+			name:       "one service",
+			serviceIDs: []int64{1},
+			bars:       map[int]bar{0: {80, 10}, uptimeDays - 1: {100, 5}},
+			uptime:     86.66666666666667,
+		},
+		{
+			name:       "services of a day are added up",
+			serviceIDs: []int64{1, 2},
+			bars:       map[int]bar{0: {50, 20}, uptimeDays - 1: {100, 5}},
+			uptime:     60,
+		},
+		{
+			name:       "outage",
+			serviceIDs: []int64{3},
+			bars:       map[int]bar{1: {0, 4}},
+			uptime:     0,
+		},
+		{
+			name:       "never checked",
+			serviceIDs: []int64{4},
+			uptime:     -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, uptime := uptimeHistory(tt.serviceIDs, counts, since)
+			if len(days) != uptimeDays {
+				t.Fatalf("got %d days, want %d", len(days), uptimeDays)
+			}
+
+			for i, got := range days {
+				if want := day(i).Format(time.DateOnly); got.Date != want {
+					t.Errorf("day %d is %s, want %s", i, got.Date, want)
+				}
+				want, checked := tt.bars[i]
+				switch {
+				case !checked && (got.Uptime != nil || got.Checks != 0):
+					t.Errorf("day %d = %v%% of %d checks, want no checks", i, *got.Uptime, got.Checks)
+				case checked && (got.Uptime == nil || *got.Uptime != want.uptime || got.Checks != want.checks):
+					t.Errorf("day %d = %+v, want %v%% of %d checks", i, got, want.uptime, want.checks)
+				}
+			}
+
+			switch {
+			case tt.uptime < 0 && uptime != nil:
+				t.Errorf("uptime = %v, want none", *uptime)
+			case tt.uptime >= 0 && (uptime == nil || *uptime != tt.uptime):
+				t.Errorf("uptime = %v, want %v", uptime, tt.uptime)
+			}
+		})
+	}
+}
+
+func TestCurrentState(t *testing.T) {
+	latest := map[int64]database.CheckResultRecord{
+		1: {Status: "healthy"},
+		2: {Status: "healthy"},
+		3: {Status: "unhealthy"},
+		4: {Status: "timeout"},
+	}
+
+	tests := []struct {
+		name       string
+		serviceIDs []int64
+		want       string
+	}{
+		{name: "no services", want: StateUnknown},
+		{name: "never checked", serviceIDs: []int64{5}, want: StateUnknown},
+		{name: "healthy", serviceIDs: []int64{1, 2}, want: StateOperational},
+		{name: "unchecked services are ignored", serviceIDs: []int64{1, 5}, want: StateOperational},
+		{name: "some unhealthy", serviceIDs: []int64{1, 3}, want: StatePartial},
+		{name: "all unhealthy", serviceIDs: []int64{3, 4}, want: StateMajor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentState(tt.serviceIDs, latest); got != tt.want {
+				t.Errorf("currentState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package statuspage
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"watchdog/config"
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+var logger = logging.For("statuspage")
+
+// cacheTTL is how long a snapshot is served before it is rebuilt. Responses
+// carry the same max-age so a CDN or static mirror can cache them.
+const cacheTTL = time.Minute
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// Page serves the public status page. It reads the database directly and
+// only exposes component aggregates and published incidents, none of the
+// internal API.
+type Page struct {
+	db         database.ServiceDB
+	cfg        config.StatusPageConfig
+	components []Component
+	pages      map[string]*template.Template
+	mux        *http.ServeMux
+
+	mu       sync.Mutex
+	snapshot *Snapshot
+	expires  time.Time
+}
+
+// New creates the status page for the components of the configured file
+func New(db database.ServiceDB, cfg config.StatusPageConfig) (*Page, error) {
+	components, err := LoadComponents(cfg.ComponentsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Page{
+		db:         db,
+		cfg:        cfg,
+		components: components,
+		pages:      make(map[string]*template.Template),
+		mux:        http.NewServeMux(),
+	}
+
+	funcs := template.FuncMap{
+		"stateLabel":  stateLabel,
+		"uptimeClass": uptimeClass,
+		"percent":     formatPercent,
+		"timestamp":   func(t time.Time) string { return t.Format("Jan 2, 2006 15:04 UTC") },
+	}
+	for _, page := range []string{"index", "incident"} {
+		tmpl, err := template.New("layout.html").Funcs(funcs).
+			ParseFS(templateFS, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", page, err)
+		}
+		p.pages[page] = tmpl
+	}
+
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	p.mux.Handle("GET /static/", cached(http.StripPrefix("/static/", http.FileServerFS(static))))
+	p.mux.HandleFunc("GET /{$}", p.handleIndex)
+	p.mux.HandleFunc("GET /status.json", p.handleJSON)
+	p.mux.HandleFunc("GET /incidents/{id}", p.handleIncident)
+	p.mux.HandleFunc("GET /feed.atom", p.handleFeed)
+
+	return p, nil
+}
+
+// ServeHTTP serves the status page
+func (p *Page) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'self'")
+	p.mux.ServeHTTP(w, r)
+}
+
+// cached marks responses as publicly cacheable for the snapshot lifetime
+func cached(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheTTL.Seconds())))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// current returns the cached snapshot, rebuilding it when it expired. A stale
+// snapshot is served when the database is unavailable.
+func (p *Page) current(ctx context.Context) (*Snapshot, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.snapshot != nil && now.Before(p.expires) {
+		return p.snapshot, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	snapshot, err := buildSnapshot(ctx, p.db, p.cfg.Title, p.components, now)
+	if err != nil {
+		if p.snapshot != nil {
+			logger.WarnContext(ctx, "failed to refresh status page, serving stale data", "error", err)
+			return p.snapshot, nil
+		}
+		return nil, err
+	}
+
+	p.snapshot = snapshot
+	p.expires = now.Add(cacheTTL)
+	return snapshot, nil
+}
+
+// snapshotOrError loads the snapshot and reports a failure to the client
+func (p *Page) snapshotOrError(w http.ResponseWriter, r *http.Request) *Snapshot {
+	snapshot, err := p.current(r.Context())
+	if err != nil {
+		logger.ErrorContext(r.Context(), "failed to build status page", "error", err)
+		http.Error(w, "status temporarily unavailable", http.StatusServiceUnavailable)
+		return nil
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheTTL.Seconds())))
+	w.Header().Set("Last-Modified", snapshot.GeneratedAt.Format(http.TimeFormat))
+	return snapshot
+}
+
+func (p *Page) render(w http.ResponseWriter, page string, data any) {
+	var buf strings.Builder
+	if err := p.pages[page].Execute(&buf, data); err != nil {
+		logger.Error("failed to render status page", "page", page, "error", err)
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(buf.String()))
+}
+
+type incidentData struct {
+	*Snapshot
+	Incident Incident
+}
+
+func (p *Page) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if snapshot := p.snapshotOrError(w, r); snapshot != nil {
+		p.render(w, "index", snapshot)
+	}
+}
+
+func (p *Page) handleIncident(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	snapshot := p.snapshotOrError(w, r)
+	if snapshot == nil {
+		return
+	}
+
+	incident, err := snapshot.findIncident(id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	p.render(w, "incident", incidentData{Snapshot: snapshot, Incident: incident})
+}
+
+func (p *Page) handleJSON(w http.ResponseWriter, r *http.Request) {
+	snapshot := p.snapshotOrError(w, r)
+	if snapshot == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(snapshot)
+}
+
+// baseURL is the configured public URL, or the URL the request was made to
+func (p *Page) baseURL(r *http.Request) string {
+	if p.cfg.BaseURL != "" {
+		return p.cfg.BaseURL
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// stateLabel turns "partial_outage" into "Partial outage"
+func stateLabel(state string) string {
+	label := strings.ReplaceAll(state, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// uptimeClass maps a day of uptime onto a bar color class
+func uptimeClass(uptime *float64) string {
+	switch {
+	case uptime == nil:
+		return "none"
+	case *uptime >= 99.9:
+		return "ok"
+	case *uptime >= 95:
+		return "warn"
+	default:
+		return "bad"
+	}
+}
+
+func formatPercent(uptime *float64) string {
+	if uptime == nil {
+		return "no data"
+	}
+	return strconv.FormatFloat(*uptime, 'f', 2, 64) + "%"
+}
@@ -0,0 +1,136 @@
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd
+const listenFDsStart = 3
+
+// Socket is a listening socket passed by systemd
+type Socket struct {
+	// Name is the FileDescriptorName of the socket, "unknown" when unset
+	Name string
+	// Listener is set for stream sockets (ListenStream=)
+	Listener net.Listener
+	// PacketConn is set for datagram sockets (ListenDatagram=)
+	PacketConn net.PacketConn
+}
+
+// address returns the local address of the socket
+func (s Socket) address() string {
+	if s.Listener != nil {
+		return s.Listener.Addr().String()
+	}
+	return s.PacketConn.LocalAddr().String()
+}
+
+// Listeners returns the sockets passed by systemd socket activation in
+// file descriptor order, nil when the process was not socket activated.
+// The environment variables are cleared so child processes do not inherit
+// the sockets.
+func Listeners() ([]Socket, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, nil
+	}
+
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	var sockets []Socket
+	for i := 0; i < count; i++ {
+		fd := listenFDsStart + i
+		syscall.CloseOnExec(fd)
+
+		name := "unknown"
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+
+		file := os.NewFile(uintptr(fd), name)
+		socket := Socket{Name: name}
+		if socket.Listener, err = net.FileListener(file); err != nil {
+			socket.PacketConn, err = net.FilePacketConn(file)
+		}
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("socket %d (%s) is neither a stream nor a datagram socket: %w", fd, name, err)
+		}
+		sockets = append(sockets, socket)
+	}
+
+	return sockets, nil
+}
+
+// Assign matches passed stream sockets to the roles the process listens
+// on. A socket named after a role is used for it, the other sockets fill the
+// remaining roles in order. Roles without a socket are absent from the
+// result, sockets without a role are closed.
+func Assign(sockets []Socket, roles ...string) map[string]net.Listener {
+	return assign(sockets, roles, func(socket Socket) net.Listener {
+		return socket.Listener
+	})
+}
+
+// AssignPackets is Assign for the passed datagram sockets
+func AssignPackets(sockets []Socket, roles ...string) map[string]net.PacketConn {
+	return assign(sockets, roles, func(socket Socket) net.PacketConn {
+		return socket.PacketConn
+	})
+}
+
+// assign matches the sockets for which conn returns a connection to roles
+func assign[T interface {
+	comparable
+	Close() error
+}](sockets []Socket, roles []string, conn func(Socket) T) map[string]T {
+	var none T
+	assigned := make(map[string]T)
+	isRole := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		isRole[role] = true
+	}
+
+	var rest []Socket
+	for _, socket := range sockets {
+		if conn(socket) == none {
+			continue
+		}
+		if isRole[socket.Name] && assigned[socket.Name] == none {
+			assigned[socket.Name] = conn(socket)
+		} else {
+			rest = append(rest, socket)
+		}
+	}
+
+	for _, role := range roles {
+		if assigned[role] != none || len(rest) == 0 {
+			continue
+		}
+		assigned[role] = conn(rest[0])
+		rest = rest[1:]
+	}
+
+	for _, socket := range rest {
+		logger.Warn("closing passed socket without a role", "name", socket.Name, "address", socket.address())
+		conn(socket).Close()
+	}
+
+	return assigned
+}
@@ -0,0 +1,120 @@
+package systemd
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAssign(t *testing.T) {
+	tests := []struct {
+		name    string
+		sockets []string
+		roles   []string
+		// want maps each role to the index of the socket assigned to it
+		want map[string]int
+		// closed lists the indexes of the sockets without a role
+		closed []int
+	}{
+		{
+			name:    "named",
+			sockets: []string{"http", "grpc"},
+			roles:   []string{"grpc", "http", "status"},
+			want:    map[string]int{"grpc": 1, "http": 0},
+		},
+		{
+			name:    "unnamed in role order",
+			sockets: []string{"unknown", "unknown"},
+			roles:   []string{"grpc", "http", "status"},
+			want:    map[string]int{"grpc": 0, "http": 1},
+		},
+		{
+			name:    "unnamed fill the roles left by named ones",
+			sockets: []string{"unknown", "grpc", "unknown"},
+			roles:   []string{"grpc", "http", "status"},
+			want:    map[string]int{"grpc": 1, "http": 0, "status": 2},
+		},
+		{
+			name:    "unknown names count as unnamed",
+			sockets: []string{"metrics", "grpc"},
+			roles:   []string{"grpc", "http"},
+			want:    map[string]int{"grpc": 1, "http": 0},
+		},
+		{
+			name:    "second socket of a role fills the next role",
+			sockets: []string{"grpc", "grpc"},
+			roles:   []string{"grpc", "http"},
+			want:    map[string]int{"grpc": 0, "http": 1},
+		},
+		{
+			name:    "sockets without a role are closed",
+			sockets: []string{"grpc", "unknown", "unknown"},
+			roles:   []string{"grpc", "http"},
+			want:    map[string]int{"grpc": 0, "http": 1},
+			closed:  []int{2},
+		},
+		{
+			name:  "no sockets",
+			roles: []string{"grpc", "http"},
+			want:  map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sockets []Socket
+			for _, name := range tt.sockets {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { listener.Close() })
+				sockets = append(sockets, Socket{Name: name, Listener: listener})
+			}
+
+			got := Assign(sockets, tt.roles...)
+			if len(got) != len(tt.want) {
+				t.Errorf("assigned %d roles, want %d", len(got), len(tt.want))
+			}
+			for role, i := range tt.want {
+				if got[role] != sockets[i].Listener {
+					t.Errorf("role %s got %v, want socket %d", role, got[role], i)
+				}
+			}
+			for _, i := range tt.closed {
+				if conn, err := net.Dial("tcp", sockets[i].Listener.Addr().String()); err == nil {
+					conn.Close()
+					t.Errorf("socket %d without a role is still open", i)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignPackets(t *testing.T) {
+	stream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	datagram, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datagram.Close()
+
+	// systemd names both sockets of a unit with ListenStream= and
+	// ListenDatagram= after its FileDescriptorName=
+	sockets := []Socket{
+		{Name: "dns", Listener: stream},
+		{Name: "dns", PacketConn: datagram},
+	}
+
+	listeners := Assign(sockets, "grpc", "dns")
+	if listeners["dns"] != stream || listeners["grpc"] != nil {
+		t.Errorf("Assign() = %v, want only the stream socket as dns", listeners)
+	}
+	packets := AssignPackets(sockets, "dns")
+	if packets["dns"] != datagram || len(packets) != 1 {
+		t.Errorf("AssignPackets() = %v, want the datagram socket as dns", packets)
+	}
+}
@@ -0,0 +1,90 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"watchdog/logging"
+)
+
+// Notification states understood by systemd, see sd_notify(3)
+const (
+	Ready    = "READY=1"
+	Stopping = "STOPPING=1"
+	Watchdog = "WATCHDOG=1"
+)
+
+var logger = logging.For("systemd")
+
+// Notify sends a state to the service manager through $NOTIFY_SOCKET. It
+// reports false without an error when not started by systemd with
+// Type=notify.
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+
+	// A leading @ names a socket in the abstract namespace
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to notify socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("failed to send %q to notify socket: %w", state, err)
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns the WatchdogSec configured for the service, zero
+// when the systemd watchdog is disabled or meant for another process
+func WatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond
+}
+
+// RunWatchdog pings the systemd watchdog at half the configured interval
+// for as long as alive reports no error, until ctx is cancelled. Once alive
+// fails the pings stop and systemd restarts the service when the interval
+// runs out.
+func RunWatchdog(ctx context.Context, alive func() error) {
+	interval := WatchdogInterval()
+	if interval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+
+	for {
+		if err := alive(); err != nil {
+			logger.ErrorContext(ctx, "withholding watchdog ping", "error", err)
+		} else if _, err := Notify(Watchdog); err != nil {
+			logger.WarnContext(ctx, "failed to ping watchdog", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// notifySocket listens on a unixgram socket in a temporary directory and
+// points $NOTIFY_SOCKET at it
+func notifySocket(t *testing.T) *net.UnixConn {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", path, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", path)
+	return conn
+}
+
+// receive returns the next datagram, or false once timeout passes
+func receive(t *testing.T, conn *net.UnixConn, timeout time.Duration) (string, bool) {
+	t.Helper()
+	buf := make([]byte, 256)
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	n, err := conn.Read(buf)
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "", false
+	}
+	if err != nil {
+		t.Fatalf("failed to read notification: %v", err)
+	}
+	return string(buf[:n]), true
+}
+
+func TestNotify(t *testing.T) {
+	for _, state := range []string{Ready, Stopping} {
+		t.Run(state, func(t *testing.T) {
+			conn := notifySocket(t)
+
+			sent, err := Notify(state)
+			if err != nil || !sent {
+				t.Fatalf("Notify(%q) = %v, %v, want true, nil", state, sent, err)
+			}
+			if got, ok := receive(t, conn, time.Second); !ok || got != state {
+				t.Errorf("received %q, want %q", got, state)
+			}
+		})
+	}
+}
+
+func TestNotifyWithoutSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	sent, err := Notify(Ready)
+	if err != nil || sent {
+		t.Errorf("Notify() = %v, %v, want false, nil", sent, err)
+	}
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	self := strconv.Itoa(os.Getpid())
+	tests := []struct {
+		name string
+		usec string
+		pid  string
+		want time.Duration
+	}{
+		{name: "disabled", usec: "", want: 0},
+		{name: "invalid", usec: "soon", want: 0},
+		{name: "any process", usec: "2000000", want: 2 * time.Second},
+		{name: "this process", usec: "2000000", pid: self, want: 2 * time.Second},
+		{name: "other process", usec: "2000000", pid: "1", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WATCHDOG_USEC", tt.usec)
+			t.Setenv("WATCHDOG_PID", tt.pid)
+			if got := WatchdogInterval(); got != tt.want {
+				t.Errorf("WatchdogInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWatchdogPacesPings(t *testing.T) {
+	conn := notifySocket(t)
+	t.Setenv("WATCHDOG_USEC", strconv.Itoa(int((200 * time.Millisecond).Microseconds())))
+	t.Setenv("WATCHDOG_PID", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunWatchdog(ctx, func() error { return nil })
+	}()
+
+	// The first ping is sent right away, the next ones every 100ms
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		got, ok := receive(t, conn, time.Second)
+		if !ok || got != Watchdog {
+			t.Fatalf("ping %d: received %q, want %q", i, got, Watchdog)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond || elapsed > 800*time.Millisecond {
+		t.Errorf("3 pings took %v, want about 200ms at half the interval", elapsed)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestRunWatchdogWithholdsPings(t *testing.T) {
+	conn := notifySocket(t)
+	t.Setenv("WATCHDOG_USEC", strconv.Itoa(int((100 * time.Millisecond).Microseconds())))
+	t.Setenv("WATCHDOG_PID", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunWatchdog(ctx, func() error { return errors.New("scheduler stalled") })
+	}()
+
+	if got, ok := receive(t, conn, 300*time.Millisecond); ok {
+		t.Errorf("received %q while alive fails, want no ping", got)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestRunWatchdogDisabled(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunWatchdog(context.Background(), func() error { return nil })
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunWatchdog kept running without WATCHDOG_USEC")
+	}
+}
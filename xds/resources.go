@@ -0,0 +1,241 @@
+package xds
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"watchdog/client"
+	"watchdog/database"
+	"watchdog/ent/service"
+	"watchdog/servicestatus"
+)
+
+// group is the set of services published as one xDS cluster
+type group struct {
+	name      string
+	endpoints []*endpointv3.LbEndpoint
+	// byAddress indexes endpoints by host:port
+	byAddress map[string]int
+}
+
+// add publishes an endpoint once per host:port. Services registered twice
+// at the same address, e.g. by a replica and through a heartbeat, keep the
+// healthiest status.
+func (g *group) add(host string, port uint32, health corev3.HealthStatus) {
+	key := net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+	if i, ok := g.byAddress[key]; ok {
+		if healthRank[health] > healthRank[g.endpoints[i].HealthStatus] {
+			g.endpoints[i].HealthStatus = health
+		}
+		return
+	}
+	g.byAddress[key] = len(g.endpoints)
+	g.endpoints = append(g.endpoints, lbEndpoint(host, port, health))
+}
+
+// healthRank orders the statuses from the least to the most usable
+var healthRank = map[corev3.HealthStatus]int{
+	corev3.HealthStatus_UNHEALTHY: 0,
+	corev3.HealthStatus_DRAINING:  1,
+	corev3.HealthStatus_UNKNOWN:   2,
+	corev3.HealthStatus_HEALTHY:   3,
+}
+
+// resources are the listeners, clusters and endpoints of every group
+type resources struct {
+	listeners []types.Resource
+	clusters  []types.Resource
+	endpoints []types.Resource
+}
+
+// groupName is the resource name of the group of a service: its name, or
+// the value of groupLabel when set, followed by its namespace. Clients dial
+// it as xds:///<group>.<namespace>.
+func groupName(svc *database.ServiceRecord, groupLabel string) string {
+	name := svc.Name
+	if value := svc.Labels[groupLabel]; groupLabel != "" && value != "" {
+		name = value
+	}
+	return name + "." + svc.Namespace
+}
+
+// build groups the gRPC and HTTP services into xDS resources
+func build(services []database.ServiceRecord, latest map[int64]database.CheckResultRecord, groupLabel string) resources {
+	groups := make(map[string]*group)
+	for i := range services {
+		svc := &services[i]
+		if svc.Type != service.TypeSERVICE_TYPE_GRPC && svc.Type != service.TypeSERVICE_TYPE_HTTP {
+			continue
+		}
+
+		host, port, err := address(svc.Endpoint)
+		if err != nil {
+			logger.Debug("skipping service without a usable address", "service_id", svc.ID, "endpoint", svc.Endpoint, "error", err)
+			continue
+		}
+
+		var lastCheck *database.CheckResultRecord
+		if result, ok := latest[svc.ID]; ok {
+			lastCheck = &result
+		}
+
+		name := groupName(svc, groupLabel)
+		g := groups[name]
+		if g == nil {
+			g = &group{name: name, byAddress: make(map[string]int)}
+			groups[name] = g
+		}
+		g.add(host, port, healthStatus(svc, lastCheck))
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var r resources
+	for _, name := range names {
+		g := groups[name]
+		r.listeners = append(r.listeners, listener(g.name))
+		r.clusters = append(r.clusters, cluster(g.name))
+		r.endpoints = append(r.endpoints, loadAssignment(g))
+	}
+	return r
+}
+
+// healthStatus derives the health of an endpoint from the status of its
+// service and its latest check. Endpoints never checked are UNKNOWN, which
+// gRPC clients still send requests to. Services that are not routable, e.g.
+// not ready or in maintenance, are UNHEALTHY whatever their checks say.
+func healthStatus(svc *database.ServiceRecord, lastCheck *database.CheckResultRecord) corev3.HealthStatus {
+	if svc.Status == servicestatus.Draining {
+		return corev3.HealthStatus_DRAINING
+	}
+	if !servicestatus.Routable(svc.Status) {
+		return corev3.HealthStatus_UNHEALTHY
+	}
+
+	if lastCheck == nil {
+		return corev3.HealthStatus_UNKNOWN
+	}
+	if lastCheck.Status == "healthy" {
+		return corev3.HealthStatus_HEALTHY
+	}
+	return corev3.HealthStatus_UNHEALTHY
+}
+
+// address extracts the host and port clients connect to from a service
+// endpoint, e.g. "10.0.0.5:9090", "dns:///api:9090" or
+// "https://api.example.com/healthz"
+func address(endpoint string) (string, uint32, error) {
+	hostPort, err := client.HostPort(endpoint)
+	if err != nil {
+		return "", 0, err
+	}
+
+	host, portText, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portText, 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, fmt.Errorf("invalid port %q", portText)
+	}
+	return host, uint32(port), nil
+}
+
+func lbEndpoint(host string, port uint32, health corev3.HealthStatus) *endpointv3.LbEndpoint {
+	return &endpointv3.LbEndpoint{
+		HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
+			Endpoint: &endpointv3.Endpoint{
+				Address: &corev3.Address{
+					Address: &corev3.Address_SocketAddress{
+						SocketAddress: &corev3.SocketAddress{
+							Protocol:      corev3.SocketAddress_TCP,
+							Address:       host,
+							PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: port},
+						},
+					},
+				},
+			},
+		},
+		HealthStatus: health,
+	}
+}
+
+// listener is the API listener gRPC clients resolve first, routing every
+// request to the cluster of the same name
+func listener(name string) types.Resource {
+	router, _ := anypb.New(&routerv3.Router{})
+	manager, _ := anypb.New(&hcmv3.HttpConnectionManager{
+		RouteSpecifier: &hcmv3.HttpConnectionManager_RouteConfig{
+			RouteConfig: &routev3.RouteConfiguration{
+				Name: name,
+				VirtualHosts: []*routev3.VirtualHost{{
+					Name:    name,
+					Domains: []string{"*"},
+					Routes: []*routev3.Route{{
+						Match: &routev3.RouteMatch{
+							PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: ""},
+						},
+						Action: &routev3.Route_Route{
+							Route: &routev3.RouteAction{
+								ClusterSpecifier: &routev3.RouteAction_Cluster{Cluster: name},
+							},
+						},
+					}},
+				}},
+			},
+		},
+		HttpFilters: []*hcmv3.HttpFilter{{
+			Name:       "envoy.filters.http.router",
+			ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: router},
+		}},
+	})
+
+	return &listenerv3.Listener{
+		Name:        name,
+		ApiListener: &listenerv3.ApiListener{ApiListener: manager},
+	}
+}
+
+// cluster round-robins over the endpoints published through EDS
+func cluster(name string) types.Resource {
+	return &clusterv3.Cluster{
+		Name:                 name,
+		ClusterDiscoveryType: &clusterv3.Cluster_Type{Type: clusterv3.Cluster_EDS},
+		EdsClusterConfig: &clusterv3.Cluster_EdsClusterConfig{
+			EdsConfig: &corev3.ConfigSource{
+				ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}},
+				ResourceApiVersion:    corev3.ApiVersion_V3,
+			},
+		},
+		LbPolicy: clusterv3.Cluster_ROUND_ROBIN,
+	}
+}
+
+// loadAssignment puts every endpoint of a group in a single locality
+func loadAssignment(g *group) types.Resource {
+	return &endpointv3.ClusterLoadAssignment{
+		ClusterName: g.name,
+		Endpoints: []*endpointv3.LocalityLbEndpoints{{
+			Locality:            &corev3.Locality{},
+			LbEndpoints:         g.endpoints,
+			LoadBalancingWeight: wrapperspb.UInt32(1),
+		}},
+	}
+}
@@ -0,0 +1,200 @@
+package xds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	clusterservice "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
+	discoverygrpc "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	listenerservice "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"watchdog/database"
+	"watchdog/logging"
+)
+
+var logger = logging.For("xds")
+
+const (
+	// debounce coalesces bursts of changes, e.g. a round of scheduled
+	// checks, into one push while keeping status changes prompt
+	debounce = 250 * time.Millisecond
+	// pollInterval bounds how stale the resources get when the database is
+	// changed by another replica
+	pollInterval = 15 * time.Second
+)
+
+// Server publishes the gRPC and HTTP services as xDS listeners, clusters
+// and endpoints, so gRPC clients dialing xds:///<name>.<namespace> balance
+// over the instances watchdog considers healthy
+type Server struct {
+	db         database.ServiceDB
+	groupLabel string
+	cache      cachev3.SnapshotCache
+	xds        serverv3.Server
+	version    string
+}
+
+// Authorizer reports whether the caller of a discovery stream or fetch may
+// see the published resources
+type Authorizer func(ctx context.Context) bool
+
+// New creates an xDS server. Services sharing the value of groupLabel form
+// one cluster, services without it are grouped by name. Only callers
+// accepted by authorized get the resources.
+func New(ctx context.Context, db database.ServiceDB, groupLabel string, authorized Authorizer) *Server {
+	// Every client gets the same resources. gRPC clients subscribe to single
+	// names over ADS, so the cache must not wait for the whole snapshot to
+	// be requested.
+	cache := cachev3.NewSnapshotCache(false, anyNode{}, cacheLogger{})
+
+	authorize := func(ctx context.Context) error {
+		if !authorized(ctx) {
+			return status.Errorf(codes.Unauthenticated, "xDS requires the admin token or an API token")
+		}
+		return nil
+	}
+	callbacks := serverv3.CallbackFuncs{
+		StreamOpenFunc: func(ctx context.Context, _ int64, _ string) error {
+			return authorize(ctx)
+		},
+		DeltaStreamOpenFunc: func(ctx context.Context, _ int64, _ string) error {
+			return authorize(ctx)
+		},
+		FetchRequestFunc: func(ctx context.Context, _ *discoverygrpc.DiscoveryRequest) error {
+			return authorize(ctx)
+		},
+	}
+
+	return &Server{
+		db:         db,
+		groupLabel: groupLabel,
+		cache:      cache,
+		xds:        serverv3.NewServer(ctx, cache, callbacks),
+	}
+}
+
+// Register adds the aggregated and per-type discovery services to a gRPC
+// server
+func (s *Server) Register(grpcServer *grpc.Server) {
+	discoverygrpc.RegisterAggregatedDiscoveryServiceServer(grpcServer, s.xds)
+	listenerservice.RegisterListenerDiscoveryServiceServer(grpcServer, s.xds)
+	clusterservice.RegisterClusterDiscoveryServiceServer(grpcServer, s.xds)
+	endpointservice.RegisterEndpointDiscoveryServiceServer(grpcServer, s.xds)
+}
+
+// Run keeps the published resources up to date until ctx is cancelled
+func (s *Server) Run(ctx context.Context) {
+	changes := s.db.WatchChanges(ctx)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.update(ctx); err != nil && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "failed to update xDS resources", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			continue
+		case <-changes:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(debounce):
+		}
+	}
+}
+
+// update rebuilds the resources and publishes them when they changed
+func (s *Server) update(ctx context.Context) error {
+	services, err := s.db.ListServices(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	serviceIDs := make([]int64, len(services))
+	for i, svc := range services {
+		serviceIDs[i] = svc.ID
+	}
+	latest, err := s.db.LatestCheckResults(ctx, serviceIDs)
+	if err != nil {
+		return err
+	}
+
+	r := build(services, latest, s.groupLabel)
+	byType := map[resourcev3.Type][]types.Resource{
+		resourcev3.ListenerType: r.listeners,
+		resourcev3.ClusterType:  r.clusters,
+		resourcev3.EndpointType: r.endpoints,
+	}
+
+	// Replicas publishing the same resources report the same version
+	version, err := fingerprint(r)
+	if err != nil {
+		return err
+	}
+	if version == s.version {
+		return nil
+	}
+
+	snapshot, err := cachev3.NewSnapshot(version, byType)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Consistent(); err != nil {
+		return err
+	}
+	if err := s.cache.SetSnapshot(ctx, "", snapshot); err != nil {
+		return err
+	}
+
+	s.version = version
+	logger.InfoContext(ctx, "published xDS resources", "version", version, "clusters", len(r.clusters))
+	return nil
+}
+
+// fingerprint hashes the resources into a version string
+func fingerprint(r resources) (string, error) {
+	h := sha256.New()
+	for _, list := range [][]types.Resource{r.listeners, r.clusters, r.endpoints} {
+		for _, resource := range list {
+			data, err := proto.MarshalOptions{Deterministic: true}.Marshal(resource)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal resource: %w", err)
+			}
+			h.Write(data)
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// anyNode maps every client onto the single shared snapshot
+type anyNode struct{}
+
+func (anyNode) ID(*corev3.Node) string { return "" }
+
+// cacheLogger sends the snapshot cache logs to the xds component logger
+type cacheLogger struct{}
+
+func (cacheLogger) Debugf(format string, args ...any) { logger.Debug(fmt.Sprintf(format, args...)) }
+func (cacheLogger) Infof(format string, args ...any)  { logger.Debug(fmt.Sprintf(format, args...)) }
+func (cacheLogger) Warnf(format string, args ...any)  { logger.Warn(fmt.Sprintf(format, args...)) }
+func (cacheLogger) Errorf(format string, args ...any) { logger.Error(fmt.Sprintf(format, args...)) }
@@ -0,0 +1,167 @@
+package xds
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	clusterservice "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
+	discoverygrpc "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"watchdog/client"
+	"watchdog/database"
+	"watchdog/ent/service"
+)
+
+func TestBuildDeduplicatesEndpoints(t *testing.T) {
+	grpcType := service.TypeSERVICE_TYPE_GRPC
+	services := []database.ServiceRecord{
+		{ID: 1, Name: "api", Namespace: "default", Type: grpcType, Endpoint: "10.0.0.1:9090"},
+		{ID: 2, Name: "api", Namespace: "default", Type: grpcType, Endpoint: "dns:///10.0.0.1:9090"},
+		{ID: 3, Name: "api", Namespace: "default", Type: grpcType, Endpoint: "10.0.0.2:9090"},
+		{ID: 4, Name: "api", Namespace: "default", Type: grpcType, Endpoint: "10.0.0.2:9091"},
+		{ID: 5, Name: "web", Namespace: "default", Type: service.TypeSERVICE_TYPE_HTTP, Endpoint: "http://10.0.0.1:9090/health"},
+	}
+	latest := map[int64]database.CheckResultRecord{
+		1: {Status: "unhealthy"},
+		2: {Status: "healthy"},
+		3: {Status: "unhealthy"},
+	}
+
+	want := map[string]map[string]corev3.HealthStatus{
+		"api.default": {
+			"10.0.0.1:9090": corev3.HealthStatus_HEALTHY,
+			"10.0.0.2:9090": corev3.HealthStatus_UNHEALTHY,
+			"10.0.0.2:9091": corev3.HealthStatus_UNKNOWN,
+		},
+		// The same address in another group is another endpoint
+		"web.default": {
+			"10.0.0.1:9090": corev3.HealthStatus_UNKNOWN,
+		},
+	}
+
+	r := build(services, latest, "")
+	if len(r.endpoints) != len(want) {
+		t.Fatalf("built %d load assignments, want %d", len(r.endpoints), len(want))
+	}
+	for _, resource := range r.endpoints {
+		assignment := resource.(*endpointv3.ClusterLoadAssignment)
+		got := map[string]corev3.HealthStatus{}
+		for _, endpoint := range assignment.Endpoints[0].LbEndpoints {
+			socket := endpoint.GetEndpoint().GetAddress().GetSocketAddress()
+			key := net.JoinHostPort(socket.GetAddress(), strconv.FormatUint(uint64(socket.GetPortValue()), 10))
+			if _, ok := got[key]; ok {
+				t.Errorf("%s publishes %s twice", assignment.ClusterName, key)
+			}
+			got[key] = endpoint.HealthStatus
+		}
+
+		expected := want[assignment.ClusterName]
+		if len(got) != len(expected) {
+			t.Errorf("%s endpoints = %v, want %v", assignment.ClusterName, got, expected)
+		}
+		for key, health := range expected {
+			if got[key] != health {
+				t.Errorf("%s %s health = %v, want %v", assignment.ClusterName, key, got[key], health)
+			}
+		}
+	}
+}
+
+func TestHealthStatus(t *testing.T) {
+	passed := &database.CheckResultRecord{Status: "healthy"}
+	failed := &database.CheckResultRecord{Status: "unhealthy"}
+
+	tests := []struct {
+		status    string
+		lastCheck *database.CheckResultRecord
+		want      corev3.HealthStatus
+	}{
+		{status: "active", lastCheck: passed, want: corev3.HealthStatus_HEALTHY},
+		{status: "active", lastCheck: failed, want: corev3.HealthStatus_UNHEALTHY},
+		{status: "active", lastCheck: nil, want: corev3.HealthStatus_UNKNOWN},
+		{status: "", lastCheck: passed, want: corev3.HealthStatus_HEALTHY},
+		{status: "draining", lastCheck: passed, want: corev3.HealthStatus_DRAINING},
+		{status: "not_ready", lastCheck: passed, want: corev3.HealthStatus_UNHEALTHY},
+		{status: "maintenance", lastCheck: passed, want: corev3.HealthStatus_UNHEALTHY},
+		{status: "maintenance", lastCheck: nil, want: corev3.HealthStatus_UNHEALTHY},
+	}
+
+	for _, tt := range tests {
+		svc := &database.ServiceRecord{Status: tt.status}
+		if got := healthStatus(svc, tt.lastCheck); got != tt.want {
+			t.Errorf("healthStatus(%q, %+v) = %v, want %v", tt.status, tt.lastCheck, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoveryRequiresToken(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	control := New(ctx, nil, "", func(ctx context.Context) bool {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		return len(values) == 1 && values[0] == "Bearer secret"
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	control.Register(s)
+	go s.Serve(listener)
+	defer s.Stop()
+
+	tests := []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{
+		{name: "no token", want: codes.Unauthenticated},
+		{name: "unknown token", token: "guess", want: codes.Unauthenticated},
+		// Authorized calls fail later, no snapshot was published
+		{name: "token", token: "secret", want: codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, _, err := client.XDSCredentials{Token: tt.token, PlaintextToken: true}.Build(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := []grpc.DialOption{grpc.WithCredentialsBundle(bundle)}
+			if tt.token == "" {
+				opts = []grpc.DialOption{grpc.WithTransportCredentials(bundle.TransportCredentials())}
+			}
+			conn, err := grpc.NewClient(listener.Addr().String(), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			stream, err := discoverygrpc.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == codes.Unauthenticated {
+				if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+					t.Errorf("ADS stream error = %v, want %v", err, codes.Unauthenticated)
+				}
+			}
+
+			_, err = clusterservice.NewClusterDiscoveryServiceClient(conn).FetchClusters(ctx, &discoverygrpc.DiscoveryRequest{})
+			if status.Code(err) != tt.want {
+				t.Errorf("FetchClusters error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}